@@ -0,0 +1,22 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package verifier
+
+import (
+	"crypto/ed25519"
+	"errors"
+)
+
+// verifyEd25519 is the default VerifyFunc for SchemeEd25519, using the
+// standard library directly since no ed25519 precompile exists in this tree
+// yet to register its own implementation over this one.
+func verifyEd25519(pubKey, sign, msg []byte) (bool, error) {
+	if len(pubKey) != ed25519.PublicKeySize {
+		return false, errors.New("verifier: invalid ed25519 public key size")
+	}
+	if len(sign) != ed25519.SignatureSize {
+		return false, errors.New("verifier: invalid ed25519 signature size")
+	}
+	return ed25519.Verify(pubKey, msg, sign), nil
+}
@@ -0,0 +1,191 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+//
+// Package verifier ports Gossamer's SignatureVerifier pattern: a long-lived
+// worker pool that checks signatures off the caller's goroutine so a
+// precompile can submit a job during block verification and pick up the
+// result later instead of blocking the EVM while it runs expensive crypto.
+package verifier
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// Scheme identifies which algorithm a Signature should be checked with.
+type Scheme byte
+
+const (
+	// SchemeECDSA covers CGGMP21's threshold ECDSA signatures.
+	SchemeECDSA Scheme = iota
+	// SchemeEd25519 is here for the ed25519 precompiles the request
+	// anticipates; sr25519 isn't wired up since no sr25519 verification
+	// code exists anywhere in this tree yet to register under it.
+	SchemeEd25519
+)
+
+// Signature is one verification job submitted to a SignatureVerifier.
+type Signature struct {
+	PubKey []byte
+	Sign   []byte
+	Msg    []byte
+	Scheme Scheme
+}
+
+// ErrInvalidSignature is returned by Finish when a job's VerifyFunc ran
+// without error but reported the signature as invalid.
+var ErrInvalidSignature = errors.New("verifier: invalid signature")
+
+// VerifyFunc checks one signature and is registered per Scheme so this
+// package never has to import the precompile packages that own the actual
+// crypto (that would create an import cycle back into precompiles).
+type VerifyFunc func(pubKey, sign, msg []byte) (bool, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[Scheme]VerifyFunc{
+		SchemeEd25519: verifyEd25519,
+	}
+)
+
+// RegisterVerifier installs the VerifyFunc used for a given Scheme. Callers
+// (e.g. cggmp21's init) register their own scheme here rather than this
+// package depending on them.
+func RegisterVerifier(scheme Scheme, fn VerifyFunc) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[scheme] = fn
+}
+
+func verifierFor(scheme Scheme) (VerifyFunc, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	fn, ok := registry[scheme]
+	return fn, ok
+}
+
+// SignatureVerifier is a long-lived worker pool: Start spins up GOMAXPROCS
+// workers, Add submits jobs to them, and Finish blocks until every submitted
+// job has been checked and reports whether any of them failed.
+type SignatureVerifier struct {
+	in      chan *Signature
+	closeCh chan struct{}
+	wg      sync.WaitGroup
+
+	mu       sync.Mutex
+	started  bool
+	canceled bool
+	failed   bool
+	firstErr error
+}
+
+// New returns a SignatureVerifier ready to Start.
+func New() *SignatureVerifier {
+	return &SignatureVerifier{
+		in:      make(chan *Signature, 128),
+		closeCh: make(chan struct{}),
+	}
+}
+
+// Start launches the worker pool. Calling Start more than once is a no-op.
+func (s *SignatureVerifier) Start() {
+	s.mu.Lock()
+	if s.started {
+		s.mu.Unlock()
+		return
+	}
+	s.started = true
+	s.mu.Unlock()
+
+	workers := runtime.GOMAXPROCS(0)
+	s.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go s.run()
+	}
+}
+
+func (s *SignatureVerifier) run() {
+	defer s.wg.Done()
+	for {
+		select {
+		case <-s.closeCh:
+			return
+		case sig, ok := <-s.in:
+			if !ok {
+				return
+			}
+			s.check(sig)
+		}
+	}
+}
+
+func (s *SignatureVerifier) check(sig *Signature) {
+	fn, ok := verifierFor(sig.Scheme)
+	if !ok {
+		s.fail(fmt.Errorf("verifier: no verifier registered for scheme %d", sig.Scheme))
+		return
+	}
+	valid, err := fn(sig.PubKey, sig.Sign, sig.Msg)
+	if err != nil {
+		s.fail(err)
+		return
+	}
+	if !valid {
+		s.fail(ErrInvalidSignature)
+	}
+}
+
+func (s *SignatureVerifier) fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failed = true
+	if s.firstErr == nil {
+		s.firstErr = err
+	}
+}
+
+// Add submits a job to the pool. It silently drops the job instead of
+// blocking forever if the verifier has already been canceled.
+func (s *SignatureVerifier) Add(sig *Signature) {
+	select {
+	case <-s.closeCh:
+	case s.in <- sig:
+	}
+}
+
+// Finish closes the input channel, waits for every in-flight and queued job
+// to drain, and reports whether any of them failed. Calling Finish after
+// Cancel returns immediately with the cancellation treated as a failure.
+func (s *SignatureVerifier) Finish() (bool, error) {
+	s.mu.Lock()
+	canceled := s.canceled
+	s.mu.Unlock()
+	if !canceled {
+		close(s.in)
+	}
+	s.wg.Wait()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return !s.failed, s.firstErr
+}
+
+// Cancel stops every worker immediately via closeCh, abandoning any jobs
+// still queued in s.in rather than waiting for them to drain. Finish called
+// after Cancel still returns promptly since the workers have already exited.
+func (s *SignatureVerifier) Cancel() {
+	s.mu.Lock()
+	if s.canceled {
+		s.mu.Unlock()
+		return
+	}
+	s.canceled = true
+	s.failed = true
+	if s.firstErr == nil {
+		s.firstErr = errors.New("verifier: canceled")
+	}
+	s.mu.Unlock()
+	close(s.closeCh)
+}
@@ -0,0 +1,136 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package verifier
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newEd25519Signature(t *testing.T, msg []byte, corrupt bool) *Signature {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	sign := ed25519.Sign(priv, msg)
+	if corrupt {
+		sign[0] ^= 0xFF
+	}
+	return &Signature{PubKey: pub, Sign: sign, Msg: msg, Scheme: SchemeEd25519}
+}
+
+func TestSignatureVerifierAllValid(t *testing.T) {
+	sv := New()
+	sv.Start()
+
+	for i := 0; i < 20; i++ {
+		sv.Add(newEd25519Signature(t, []byte("message"), false))
+	}
+
+	ok, err := sv.Finish()
+	if err != nil {
+		t.Fatalf("Finish returned error: %v", err)
+	}
+	if !ok {
+		t.Fatal("expected all-valid batch to report ok=true")
+	}
+}
+
+func TestSignatureVerifierMixedValidInvalid(t *testing.T) {
+	sv := New()
+	sv.Start()
+
+	for i := 0; i < 10; i++ {
+		sv.Add(newEd25519Signature(t, []byte("message"), false))
+	}
+	sv.Add(newEd25519Signature(t, []byte("message"), true))
+	for i := 0; i < 10; i++ {
+		sv.Add(newEd25519Signature(t, []byte("message"), false))
+	}
+
+	ok, err := sv.Finish()
+	if ok {
+		t.Fatal("expected mixed batch to report ok=false")
+	}
+	if !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("expected ErrInvalidSignature, got %v", err)
+	}
+}
+
+// TestSignatureVerifierOrdering checks that every job submitted before
+// Finish is actually checked before Finish returns, regardless of how many
+// workers are racing to drain the channel.
+func TestSignatureVerifierOrdering(t *testing.T) {
+	sv := New()
+	sv.Start()
+
+	const jobs = 200
+	var checked int64
+	RegisterVerifier(Scheme(200), func(pubKey, sign, msg []byte) (bool, error) {
+		atomic.AddInt64(&checked, 1)
+		return true, nil
+	})
+
+	for i := 0; i < jobs; i++ {
+		sv.Add(&Signature{Scheme: Scheme(200)})
+	}
+
+	ok, err := sv.Finish()
+	if err != nil || !ok {
+		t.Fatalf("Finish() = %v, %v; want true, nil", ok, err)
+	}
+	if got := atomic.LoadInt64(&checked); got != jobs {
+		t.Fatalf("checked %d jobs, want %d", got, jobs)
+	}
+}
+
+// TestSignatureVerifierCancel checks that Cancel, via closeCh, unblocks
+// Finish promptly instead of waiting for a backlog to drain.
+func TestSignatureVerifierCancel(t *testing.T) {
+	sv := New()
+	sv.Start()
+
+	blockCh := make(chan struct{})
+	RegisterVerifier(Scheme(201), func(pubKey, sign, msg []byte) (bool, error) {
+		<-blockCh
+		return true, nil
+	})
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		// Fill the buffered channel and then some, so without cancellation
+		// this Add loop (and the eventual Finish) would stay blocked on the
+		// still-running workers above.
+		for i := 0; i < 256; i++ {
+			sv.Add(&Signature{Scheme: Scheme(201)})
+		}
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		sv.Cancel()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Cancel did not return promptly")
+	}
+
+	ok, err := sv.Finish()
+	if ok || err == nil {
+		t.Fatalf("Finish() after Cancel = %v, %v; want false, non-nil", ok, err)
+	}
+
+	close(blockCh)
+	wg.Wait()
+}
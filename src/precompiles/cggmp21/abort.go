@@ -0,0 +1,162 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cggmp21
+
+import (
+	"crypto/elliptic"
+	"encoding/binary"
+	"errors"
+	"math/big"
+
+	"github.com/luxfi/geth/crypto"
+)
+
+// Identifiable abort lets a caller that already has the final (possibly
+// invalid) threshold signature also submit the per-signer presignature
+// shares that produced it, so a failed round can name the misbehaving
+// party instead of only reporting "invalid" for the aggregate.
+//
+// Each share is checked against the presignature consistency equation
+// Gamma_i == sigma_i*G + r_i*P_i, where P_i is the i-th signer's public
+// verification share. This package has no separate DKG/keygen precompile
+// to source P_i from, so the verification share rides along as the
+// share's Delta_i field (also a 33-byte compressed secp256k1 point)
+// rather than growing the wire format with a new one.
+const (
+	CGGMP21ShareIndexSize = 2
+	CGGMP21PointSize      = 33 // compressed secp256k1 point (0x02/0x03 || x)
+	CGGMP21ScalarSize     = 32
+	CGGMP21NumSharesSize  = 2
+
+	// CGGMP21ShareSize is the length of one encoded share:
+	// signer_index(2) || Gamma_i(33) || Delta_i(33) || sigma_i(32) || r_i(32)
+	CGGMP21ShareSize = CGGMP21ShareIndexSize + CGGMP21PointSize*2 + CGGMP21ScalarSize*2
+
+	// maxBlameBitmapSigners is the widest signer index the 256-bit blame
+	// bitmap can name directly.
+	maxBlameBitmapSigners = 256
+)
+
+var ErrInvalidShareBundle = errors.New("invalid presignature share bundle")
+
+// share is one parsed entry from the optional trailing bundle.
+type share struct {
+	signerIndex uint16
+	gamma       []byte // 33-byte compressed point, as submitted
+	delta       []byte // 33-byte compressed point, the signer's verification share P_i
+	sigma       *big.Int
+	r           *big.Int
+}
+
+// parseShareBundle parses the optional presignature-share bundle appended
+// after the standard MinInputSize verification input:
+//
+//	[numShares(2)] [share]*
+//
+// It returns a nil slice (not an error) when no bundle is present, so
+// callers can distinguish "no bundle" from "empty bundle".
+func parseShareBundle(input []byte) ([]share, error) {
+	if len(input) == MinInputSize {
+		return nil, nil
+	}
+	if len(input) < MinInputSize+CGGMP21NumSharesSize {
+		return nil, ErrInvalidShareBundle
+	}
+
+	numShares := binary.BigEndian.Uint16(input[MinInputSize : MinInputSize+CGGMP21NumSharesSize])
+	offset := MinInputSize + CGGMP21NumSharesSize
+	expected := offset + int(numShares)*CGGMP21ShareSize
+	if len(input) != expected {
+		return nil, ErrInvalidShareBundle
+	}
+
+	shares := make([]share, numShares)
+	for i := range shares {
+		s := input[offset : offset+CGGMP21ShareSize]
+		offset += CGGMP21ShareSize
+
+		gammaStart := CGGMP21ShareIndexSize
+		deltaStart := gammaStart + CGGMP21PointSize
+		sigmaStart := deltaStart + CGGMP21PointSize
+		rStart := sigmaStart + CGGMP21ScalarSize
+
+		shares[i] = share{
+			signerIndex: binary.BigEndian.Uint16(s[0:CGGMP21ShareIndexSize]),
+			gamma:       s[gammaStart:deltaStart],
+			delta:       s[deltaStart:sigmaStart],
+			sigma:       new(big.Int).SetBytes(s[sigmaStart:rStart]),
+			r:           new(big.Int).SetBytes(s[rStart : rStart+CGGMP21ScalarSize]),
+		}
+	}
+	return shares, nil
+}
+
+// numSharesInInput peeks the optional bundle's share count without fully
+// validating it, for gas metering purposes only.
+func numSharesInInput(input []byte) uint16 {
+	if len(input) < MinInputSize+CGGMP21NumSharesSize {
+		return 0
+	}
+	return binary.BigEndian.Uint16(input[MinInputSize : MinInputSize+CGGMP21NumSharesSize])
+}
+
+// checkShares recomputes each share's presignature consistency equation and
+// reports the first misbehaving signer plus a bitmap of every signer that
+// failed.
+//
+// Return values:
+//   - firstCulprit: 0 if every share is consistent, otherwise the failing
+//     signer's index + 1 (the +1 lets 0 double as "no culprit").
+//   - bitmap: a 32-byte (256-bit) word; bit i of signer index i, counting
+//     from the least significant bit of the last byte, is set when that
+//     signer submitted an inconsistent share. Signer indices >= 256
+//     cannot be named in the bitmap and cause ErrShareIndexOutOfRange.
+func checkShares(shares []share) (uint32, [32]byte, error) {
+	curve := crypto.S256()
+	n := curve.Params().N
+
+	var firstCulprit uint32
+	var bitmap [32]byte
+
+	for _, s := range shares {
+		if s.signerIndex >= maxBlameBitmapSigners {
+			return 0, bitmap, ErrShareIndexOutOfRange
+		}
+
+		if !shareIsConsistent(curve, n, s) {
+			markCulprit(s.signerIndex, &firstCulprit, &bitmap)
+		}
+	}
+
+	return firstCulprit, bitmap, nil
+}
+
+func shareIsConsistent(curve elliptic.Curve, n *big.Int, s share) bool {
+	if s.sigma.Sign() <= 0 || s.sigma.Cmp(n) >= 0 || s.r.Sign() <= 0 || s.r.Cmp(n) >= 0 {
+		return false
+	}
+
+	verificationShare, err := crypto.DecompressPubkey(s.delta)
+	if err != nil {
+		return false
+	}
+	claimedGamma, err := crypto.DecompressPubkey(s.gamma)
+	if err != nil {
+		return false
+	}
+
+	sGx, sGy := curve.ScalarBaseMult(s.sigma.Bytes())
+	rPx, rPy := curve.ScalarMult(verificationShare.X, verificationShare.Y, s.r.Bytes())
+	gammaX, gammaY := curve.Add(sGx, sGy, rPx, rPy)
+
+	return gammaX.Cmp(claimedGamma.X) == 0 && gammaY.Cmp(claimedGamma.Y) == 0
+}
+
+func markCulprit(signerIndex uint16, firstCulprit *uint32, bitmap *[32]byte) {
+	byteIdx := 31 - signerIndex/8
+	bitmap[byteIdx] |= 1 << (signerIndex % 8)
+	if *firstCulprit == 0 {
+		*firstCulprit = uint32(signerIndex) + 1
+	}
+}
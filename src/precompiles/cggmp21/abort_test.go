@@ -0,0 +1,154 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cggmp21
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/binary"
+	"math/big"
+	"testing"
+
+	"github.com/luxfi/geth/common"
+	"github.com/luxfi/geth/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// buildShare produces a self-consistent encoded share for signer index idx:
+// it picks a verification key P_i = v_i*G and a random sigma, derives
+// r = 1 and Gamma_i = sigma*G + r*P_i, then encodes the
+// signer_index || Gamma_i || Delta_i || sigma || r tuple.
+func buildShare(t *testing.T, idx uint16) []byte {
+	t.Helper()
+	curve := crypto.S256()
+
+	verificationKey, err := ecdsa.GenerateKey(curve, rand.Reader)
+	require.NoError(t, err)
+
+	sigma, err := rand.Int(rand.Reader, curve.Params().N)
+	require.NoError(t, err)
+	require.NotZero(t, sigma.Sign())
+	r := big.NewInt(1)
+
+	sGx, sGy := curve.ScalarBaseMult(sigma.Bytes())
+	rPx, rPy := curve.ScalarMult(verificationKey.X, verificationKey.Y, r.Bytes())
+	gammaX, gammaY := curve.Add(sGx, sGy, rPx, rPy)
+
+	gamma := crypto.CompressPubkey(&ecdsa.PublicKey{Curve: curve, X: gammaX, Y: gammaY})
+	delta := crypto.CompressPubkey(&verificationKey.PublicKey)
+
+	out := make([]byte, CGGMP21ShareSize)
+	binary.BigEndian.PutUint16(out[0:2], idx)
+	copy(out[2:35], gamma)
+	copy(out[35:68], delta)
+
+	sigmaBytes := sigma.Bytes()
+	copy(out[68+(32-len(sigmaBytes)):100], sigmaBytes)
+
+	rBytes := r.Bytes()
+	copy(out[100+(32-len(rBytes)):132], rBytes)
+
+	return out
+}
+
+// validSignedInput returns the standard 170-byte CGGMP21Verify input with a
+// valid ECDSA signature, so the identifiable-abort bundle can be appended
+// after it.
+func validSignedInput(t *testing.T) []byte {
+	t.Helper()
+	privateKey, err := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+	require.NoError(t, err)
+
+	publicKey := crypto.FromECDSAPub(&privateKey.PublicKey)
+	messageHash := crypto.Keccak256([]byte("identifiable abort test message"))
+	signature, err := crypto.Sign(messageHash, privateKey)
+	require.NoError(t, err)
+
+	input := make([]byte, MinInputSize)
+	binary.BigEndian.PutUint32(input[0:4], 3)
+	binary.BigEndian.PutUint32(input[4:8], 5)
+	copy(input[8:73], publicKey)
+	copy(input[73:105], messageHash)
+	copy(input[105:170], signature)
+	return input
+}
+
+func appendShareBundle(input []byte, shares ...[]byte) []byte {
+	bundle := make([]byte, CGGMP21NumSharesSize)
+	binary.BigEndian.PutUint16(bundle, uint16(len(shares)))
+	for _, s := range shares {
+		bundle = append(bundle, s...)
+	}
+	return append(input, bundle...)
+}
+
+func TestCGGMP21Verify_SharesAllConsistent(t *testing.T) {
+	precompile := CGGMP21VerifyPrecompile
+
+	input := appendShareBundle(validSignedInput(t), buildShare(t, 0), buildShare(t, 1), buildShare(t, 2))
+
+	result, _, err := precompile.Run(nil, common.Address{}, ContractCGGMP21VerifyAddress, input, 1_000_000, true)
+	require.NoError(t, err)
+	require.Len(t, result, 96)
+
+	firstCulprit := binary.BigEndian.Uint32(result[60:64])
+	require.Zero(t, firstCulprit)
+	require.Equal(t, make([]byte, 32), result[64:96])
+}
+
+func TestCGGMP21Verify_SharesOneInconsistent(t *testing.T) {
+	precompile := CGGMP21VerifyPrecompile
+
+	badShare := buildShare(t, 2)
+	badShare[2] ^= 0xFF // corrupt Gamma_i
+	input := appendShareBundle(validSignedInput(t), buildShare(t, 0), buildShare(t, 1), badShare)
+
+	result, _, err := precompile.Run(nil, common.Address{}, ContractCGGMP21VerifyAddress, input, 1_000_000, true)
+	require.NoError(t, err)
+	require.Len(t, result, 96)
+
+	firstCulprit := binary.BigEndian.Uint32(result[60:64])
+	require.Equal(t, uint32(3), firstCulprit) // signer index 2 + 1
+
+	wantBitmap := make([]byte, 32)
+	wantBitmap[31] = 1 << 2
+	require.Equal(t, wantBitmap, result[64:96])
+}
+
+func TestCGGMP21Verify_SharesMultipleInconsistent(t *testing.T) {
+	precompile := CGGMP21VerifyPrecompile
+
+	badShare1 := buildShare(t, 1)
+	badShare1[2] ^= 0xFF
+	badShare4 := buildShare(t, 4)
+	badShare4[2] ^= 0xFF
+	input := appendShareBundle(validSignedInput(t), buildShare(t, 0), badShare1, buildShare(t, 2), badShare4)
+
+	result, _, err := precompile.Run(nil, common.Address{}, ContractCGGMP21VerifyAddress, input, 1_000_000, true)
+	require.NoError(t, err)
+
+	firstCulprit := binary.BigEndian.Uint32(result[60:64])
+	require.Equal(t, uint32(2), firstCulprit) // signer index 1 + 1, reported first
+
+	wantBitmap := make([]byte, 32)
+	wantBitmap[31] = (1 << 1) | (1 << 4)
+	require.Equal(t, wantBitmap, result[64:96])
+}
+
+func TestCGGMP21Verify_SharesMalformedBundleRejected(t *testing.T) {
+	precompile := CGGMP21VerifyPrecompile
+
+	input := append(validSignedInput(t), 0x00, 0x02) // claims 2 shares, provides none
+
+	_, _, err := precompile.Run(nil, common.Address{}, ContractCGGMP21VerifyAddress, input, 1_000_000, true)
+	require.Error(t, err)
+	require.ErrorIs(t, err, ErrInvalidShareBundle)
+}
+
+func TestCGGMP21Verify_GasCost_WithShares(t *testing.T) {
+	input := appendShareBundle(validSignedInput(t), buildShare(t, 0), buildShare(t, 1))
+
+	gasCost := CGGMP21VerifyGasCost(input)
+	require.Equal(t, CGGMP21VerifyBaseGas+5*CGGMP21VerifyPerSignerGas+2*CGGMP21VerifyPerSignerGas, gasCost)
+}
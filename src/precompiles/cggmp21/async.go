@@ -0,0 +1,62 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+//
+// Deferred verification: CGGMP21VerifyPrecompile.Run normally blocks the EVM
+// goroutine on verifyECDSASignature. When the block-verification context
+// already has a crypto/verifier.SignatureVerifier running, Run submits the
+// job there instead and returns a placeholder result immediately; the
+// consensus layer is responsible for calling SignatureVerifier.Finish()
+// before it treats the block as valid.
+
+package cggmp21
+
+import (
+	"github.com/luxfi/evm/precompile/contract"
+	"github.com/luxfi/standard/src/crypto/verifier"
+)
+
+func init() {
+	verifier.RegisterVerifier(verifier.SchemeECDSA, func(pubKey, sign, msg []byte) (bool, error) {
+		return verifyECDSASignature(pubKey, msg, sign)
+	})
+}
+
+// asyncVerifyAccessibleState is implemented by accessibleState values that
+// also expose the active SignatureVerifier for the block being processed.
+// contract.AccessibleState itself can't be extended with a real
+// GetSignatureVerifier method since it's declared in an unvendored package
+// in this tree; see pqcrypto/keystore.go's keystoreAccessibleState for the
+// same type-assertion workaround.
+type asyncVerifyAccessibleState interface {
+	GetSignatureVerifier() *verifier.SignatureVerifier
+}
+
+// cggmp21DeferredMarker flags a Run result as "submitted, not yet known"
+// rather than a real pass/fail word: byte 0 is never set by the synchronous
+// path (which only ever writes to byte 31), so the two can't be confused.
+const cggmp21DeferredMarker = 0xFF
+
+// submitAsync hands the signature off to an active SignatureVerifier and
+// builds the placeholder result Run should return in its place. It reports
+// false if no verifier is active, so Run can fall back to verifying inline.
+func submitAsync(accessibleState contract.AccessibleState, publicKeyBytes, messageHash, signatureBytes []byte) ([]byte, bool) {
+	asyncState, ok := accessibleState.(asyncVerifyAccessibleState)
+	if !ok {
+		return nil, false
+	}
+	sv := asyncState.GetSignatureVerifier()
+	if sv == nil {
+		return nil, false
+	}
+
+	sv.Add(&verifier.Signature{
+		PubKey: publicKeyBytes,
+		Sign:   signatureBytes,
+		Msg:    messageHash,
+		Scheme: verifier.SchemeECDSA,
+	})
+
+	result := make([]byte, 32)
+	result[0] = cggmp21DeferredMarker
+	return result, true
+}
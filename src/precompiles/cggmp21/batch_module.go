@@ -0,0 +1,71 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cggmp21
+
+import (
+	"github.com/luxfi/evm/precompile/contract"
+	"github.com/luxfi/evm/precompile/modules"
+	"github.com/luxfi/geth/common"
+)
+
+var _ contract.Configurator = &batchConfigurator{}
+
+type batchConfigurator struct{}
+
+func init() {
+	// Register the CGGMP21 aggregate verification precompile module,
+	// alongside (not replacing) the single-signature module registered in
+	// module.go.
+	if err := modules.RegisterModule(
+		ContractCGGMP21VerifyBatchAddress.String(),
+		&batchConfigurator{},
+	); err != nil {
+		panic(err)
+	}
+}
+
+func (*batchConfigurator) MakeConfig() contract.StatefulPrecompileConfig {
+	return &BatchConfig{
+		Address: ContractCGGMP21VerifyBatchAddress,
+	}
+}
+
+// BatchConfig implements the StatefulPrecompileConfig interface for the
+// CGGMP21 aggregate verification precompile.
+type BatchConfig struct {
+	Address common.Address `json:"address"`
+}
+
+func (c *BatchConfig) Key() string {
+	return c.Address.String()
+}
+
+func (c *BatchConfig) Timestamp() *uint64 {
+	return nil
+}
+
+func (c *BatchConfig) IsDisabled() bool {
+	return false
+}
+
+func (c *BatchConfig) Equal(cfg contract.StatefulPrecompileConfig) bool {
+	other, ok := cfg.(*BatchConfig)
+	if !ok {
+		return false
+	}
+	return c.Address == other.Address
+}
+
+func (c *BatchConfig) Configure(
+	chainConfig contract.ChainConfig,
+	precompileConfig contract.PrecompileConfig,
+	state contract.StateDB,
+) error {
+	// No state initialization required
+	return nil
+}
+
+func (c *BatchConfig) Contract() contract.StatefulPrecompiledContract {
+	return CGGMP21VerifyBatchPrecompile
+}
@@ -24,25 +24,34 @@ var (
 
 	_ contract.StatefulPrecompiledContract = &cggmp21VerifyPrecompile{}
 
-	ErrInvalidInputLength  = errors.New("invalid input length")
-	ErrInvalidThreshold    = errors.New("invalid threshold: t must be > 0 and <= n")
-	ErrInvalidPublicKey    = errors.New("invalid public key")
-	ErrInvalidSignature    = errors.New("invalid signature")
-	ErrSignatureVerifyFail = errors.New("signature verification failed")
+	ErrInvalidInputLength       = errors.New("invalid input length")
+	ErrInvalidThreshold         = errors.New("invalid threshold: t must be > 0 and <= n")
+	ErrInvalidPublicKey         = errors.New("invalid public key")
+	ErrInvalidSignature         = errors.New("invalid signature")
+	ErrSignatureVerifyFail      = errors.New("signature verification failed")
+	ErrInvalidVerificationShare = errors.New("invalid per-signer verification share")
+	ErrShareIndexOutOfRange     = errors.New("signer index does not fit the 256-bit blame bitmap")
+	ErrMalleableSignature       = errors.New("signature s-value is malleable (exceeds secp256k1 half order)")
 )
 
+// secp256k1HalfN is half the secp256k1 group order. A signature with an
+// s-value above it has a mathematically valid (r, n-s) twin recovering the
+// same public key, so per EIP-2 only the low-S form is accepted here,
+// matching go-ethereum's canonical transaction signature validation.
+var secp256k1HalfN = new(big.Int).Rsh(crypto.S256().Params().N, 1)
+
 const (
 	// Gas costs for CGGMP21 threshold signature verification
 	// CGGMP21 is more expensive than FROST but has identifiable aborts
-	CGGMP21VerifyBaseGas    uint64 = 75_000  // Base cost for ECDSA threshold verification
+	CGGMP21VerifyBaseGas      uint64 = 75_000 // Base cost for ECDSA threshold verification
 	CGGMP21VerifyPerSignerGas uint64 = 10_000 // Cost per signer in threshold
 
 	// CGGMP21 uses standard ECDSA signatures
-	CGGMP21PublicKeySize  = 65 // Uncompressed public key (0x04 || x || y)
-	CGGMP21SignatureSize  = 65 // ECDSA signature (r || s || v)
+	CGGMP21PublicKeySize   = 65 // Uncompressed public key (0x04 || x || y)
+	CGGMP21SignatureSize   = 65 // ECDSA signature (r || s || v)
 	CGGMP21MessageHashSize = 32 // 32-byte message hash
-	ThresholdSize         = 4  // uint32 threshold t
-	TotalSignersSize      = 4  // uint32 total signers n
+	ThresholdSize          = 4  // uint32 threshold t
+	TotalSignersSize       = 4  // uint32 total signers n
 
 	// Minimum input size
 	MinInputSize = ThresholdSize + TotalSignersSize + CGGMP21PublicKeySize + CGGMP21MessageHashSize + CGGMP21SignatureSize
@@ -66,11 +75,24 @@ func CGGMP21VerifyGasCost(input []byte) uint64 {
 		return CGGMP21VerifyBaseGas
 	}
 
+	// A sessionID-carrying call (see presign.go) looks up a presignature
+	// commitment instead of running a full ECDSA verify, so it's priced at
+	// the flat, much lower CGGMP21SessionVerifyGas regardless of
+	// totalSigners. This prices every sessionID-shaped call this way, hit
+	// or not, since RequiredGas only sees the input and can't check state.
+	if len(input) == MinInputSize+SessionIDSize {
+		return CGGMP21SessionVerifyGas
+	}
+
 	// Extract total signers from input
 	totalSigners := binary.BigEndian.Uint32(input[ThresholdSize : ThresholdSize+TotalSignersSize])
 
-	// Base cost + per-signer cost
-	return CGGMP21VerifyBaseGas + (uint64(totalSigners) * CGGMP21VerifyPerSignerGas)
+	// Base cost + per-signer cost, plus an additional per-signer cost for
+	// each presignature share in the optional identifiable-abort bundle
+	// (see abort.go).
+	gas := CGGMP21VerifyBaseGas + (uint64(totalSigners) * CGGMP21VerifyPerSignerGas)
+	gas += uint64(numSharesInInput(input)) * CGGMP21VerifyPerSignerGas
+	return gas
 }
 
 // Run implements the CGGMP21 threshold signature verification precompile
@@ -114,17 +136,74 @@ func (p *cggmp21VerifyPrecompile) Run(
 	messageHash := input[73:105]
 	signatureBytes := input[105:170]
 
+	// A trailing sessionID (see presign.go) means the caller registered a
+	// presignature commitment during CGGMP21's offline phase; the online
+	// step here only has to reveal a signature matching it; see
+	// verifySession for why that's cheaper than checking publicKeyBytes
+	// against the curve again. This mode and the share bundle below are
+	// mutually exclusive: a sessionID always makes the input exactly
+	// MinInputSize+SessionIDSize bytes, a length the share-bundle's own
+	// numShares/share-count framing can't produce (see abort.go).
+	if len(input) == MinInputSize+SessionIDSize {
+		sessionID := common.BytesToHash(input[MinInputSize : MinInputSize+SessionIDSize])
+		valid, err := verifySession(accessibleState.GetStateDB(), accessibleState.GetBlockContext().Number(), sessionID, signatureBytes)
+		if err != nil {
+			return nil, suppliedGas - gasCost, err
+		}
+		result := make([]byte, 32)
+		if valid {
+			result[31] = 1
+		}
+		return result, suppliedGas - gasCost, nil
+	}
+
+	// An optional presignature-share bundle may follow the standard input,
+	// letting a caller pin blame on a specific signer when a threshold
+	// round aborted (see abort.go). Absent a bundle, the result is the
+	// original 32-byte validity word, and that's the only case deferred to
+	// an active SignatureVerifier below: blame detection needs the real
+	// validity bit right away, so a share bundle always verifies inline.
+	shares, err := parseShareBundle(input)
+	if err != nil {
+		return nil, suppliedGas - gasCost, err
+	}
+	if shares == nil {
+		if result, deferred := submitAsync(accessibleState, publicKeyBytes, messageHash, signatureBytes); deferred {
+			return result, suppliedGas - gasCost, nil
+		}
+
+		valid, err := verifyECDSASignature(publicKeyBytes, messageHash, signatureBytes)
+		if err != nil {
+			return nil, suppliedGas - gasCost, err
+		}
+		result := make([]byte, 32)
+		if valid {
+			result[31] = 1
+		}
+		return result, suppliedGas - gasCost, nil
+	}
+
 	// Verify ECDSA signature
 	valid, err := verifyECDSASignature(publicKeyBytes, messageHash, signatureBytes)
 	if err != nil {
 		return nil, suppliedGas - gasCost, err
 	}
 
-	// Return result as 32-byte word (1 = valid, 0 = invalid)
-	result := make([]byte, 32)
+	firstCulprit, bitmap, err := checkShares(shares)
+	if err != nil {
+		return nil, suppliedGas - gasCost, err
+	}
+
+	// [0:32]  = signature validity (1 = valid, 0 = invalid), as before
+	// [32:64] = first misbehaving signer index + 1 (0 = no culprit found)
+	// [64:96] = blame bitmap: bit i of the big-endian-indexed 256-bit word
+	//           is set if signer i submitted an inconsistent share
+	result := make([]byte, 96)
 	if valid {
 		result[31] = 1
 	}
+	binary.BigEndian.PutUint32(result[60:64], firstCulprit)
+	copy(result[64:96], bitmap[:])
 
 	return result, suppliedGas - gasCost, nil
 }
@@ -152,6 +231,14 @@ func verifyECDSASignature(publicKeyBytes, messageHash, signatureBytes []byte) (b
 	s := new(big.Int).SetBytes(signatureBytes[32:64])
 	v := signatureBytes[64]
 
+	// Reject the high-S twin of a signature up front: (r, s) and
+	// (r, n-s) both satisfy the ECDSA verify equation for the same key, so
+	// without this check a signer's single signature could be resubmitted
+	// in two different but equally "valid" byte encodings.
+	if s.Cmp(secp256k1HalfN) > 0 {
+		return false, ErrMalleableSignature
+	}
+
 	// Normalize v (should be 27 or 28, or 0 or 1)
 	if v >= 27 {
 		v -= 27
@@ -160,7 +247,7 @@ func verifyECDSASignature(publicKeyBytes, messageHash, signatureBytes []byte) (b
 	// Verify signature
 	// CGGMP21 produces standard ECDSA signatures that can be verified normally
 	sig := make([]byte, 64)
-	copy(sig[0:32], signatureBytes[0:32])  // r
+	copy(sig[0:32], signatureBytes[0:32])   // r
 	copy(sig[32:64], signatureBytes[32:64]) // s
 
 	valid := crypto.VerifySignature(
@@ -200,7 +287,7 @@ func recoverPublicKey(messageHash, signature []byte) (*ecdsa.PublicKey, error) {
 
 	// Normalize signature for ecrecover
 	sig := make([]byte, 65)
-	copy(sig[0:32], signature[0:32])  // r
+	copy(sig[0:32], signature[0:32])   // r
 	copy(sig[32:64], signature[32:64]) // s
 	sig[64] = v
 
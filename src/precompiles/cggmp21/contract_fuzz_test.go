@@ -0,0 +1,172 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cggmp21
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/binary"
+	"math/big"
+	"testing"
+
+	"github.com/luxfi/geth/common"
+	"github.com/luxfi/geth/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// FuzzCGGMP21Verify mutates the whole input buffer and only asserts that Run
+// never panics and never reports more gas remaining than it was handed.
+// Inputs whose length happens to land on MinInputSize+SessionIDSize are
+// skipped: that shape routes through the sessionID fast path (see
+// presign.go), which needs a real StateDB from accessibleState and is
+// already covered on its own in presign_test.go.
+func FuzzCGGMP21Verify(f *testing.F) {
+	privateKey, err := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+	require.NoError(f, err)
+	publicKey := crypto.FromECDSAPub(&privateKey.PublicKey)
+	messageHash := crypto.Keccak256([]byte("fuzz seed message"))
+	signature, err := crypto.Sign(messageHash, privateKey)
+	require.NoError(f, err)
+
+	seed := make([]byte, MinInputSize)
+	binary.BigEndian.PutUint32(seed[0:4], 3)
+	binary.BigEndian.PutUint32(seed[4:8], 5)
+	copy(seed[8:73], publicKey)
+	copy(seed[73:105], messageHash)
+	copy(seed[105:170], signature)
+
+	f.Add(seed)
+	f.Add([]byte{})
+	f.Add(make([]byte, MinInputSize-1))
+	f.Add(make([]byte, MinInputSize+SessionIDSize))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if len(data) == MinInputSize+SessionIDSize {
+			t.Skip()
+		}
+
+		const suppliedGas = 10_000_000
+		result, remainingGas, err := CGGMP21VerifyPrecompile.Run(
+			nil, common.Address{}, ContractCGGMP21VerifyAddress, data, suppliedGas, true,
+		)
+		if err != nil {
+			require.Nil(t, result)
+		}
+		require.LessOrEqual(t, remainingGas, uint64(suppliedGas))
+	})
+}
+
+// TestCGGMP21Verify_RFC6979Determinism checks the conformance property this
+// precompile actually depends on: go-ethereum's crypto.Sign derives its
+// nonce via RFC6979, so signing the same message hash under the same key
+// twice must produce byte-identical signatures. This repo has no network
+// access to pull fixed vectors from another CGGMP21 implementation (e.g.
+// taurushq/multi-party-sig) to freeze as a cross-implementation fixture, so
+// this asserts determinism directly against the library this precompile
+// actually verifies against rather than guessing at unseen vector bytes.
+func TestCGGMP21Verify_RFC6979Determinism(t *testing.T) {
+	cases := []string{
+		"rfc6979 conformance message one",
+		"rfc6979 conformance message two",
+		"",
+	}
+
+	for _, msg := range cases {
+		msg := msg
+		t.Run(msg, func(t *testing.T) {
+			privateKey, err := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+			require.NoError(t, err)
+			messageHash := crypto.Keccak256([]byte(msg))
+
+			first, err := crypto.Sign(messageHash, privateKey)
+			require.NoError(t, err)
+			second, err := crypto.Sign(messageHash, privateKey)
+			require.NoError(t, err)
+
+			require.Equal(t, first, second, "RFC6979 nonce derivation must be deterministic")
+
+			publicKey := crypto.FromECDSAPub(&privateKey.PublicKey)
+			input := make([]byte, MinInputSize)
+			binary.BigEndian.PutUint32(input[0:4], 2)
+			binary.BigEndian.PutUint32(input[4:8], 3)
+			copy(input[8:73], publicKey)
+			copy(input[73:105], messageHash)
+			copy(input[105:170], first)
+
+			result, _, err := CGGMP21VerifyPrecompile.Run(
+				nil, common.Address{}, ContractCGGMP21VerifyAddress, input, 1_000_000, true,
+			)
+			require.NoError(t, err)
+			require.Equal(t, byte(1), result[31])
+		})
+	}
+}
+
+// TestCGGMP21Verify_RejectsHighS asserts the precompile rejects the high-S
+// twin of a valid signature per EIP-2: (r, s) and (r, n-s) both satisfy the
+// ECDSA verify equation and recover the same public key, so only the
+// canonical low-S encoding may be accepted.
+func TestCGGMP21Verify_RejectsHighS(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+	require.NoError(t, err)
+	publicKey := crypto.FromECDSAPub(&privateKey.PublicKey)
+	messageHash := crypto.Keccak256([]byte("malleability test message"))
+
+	signature, err := crypto.Sign(messageHash, privateKey)
+	require.NoError(t, err)
+
+	s := new(big.Int).SetBytes(signature[32:64])
+	require.LessOrEqual(t, s.Cmp(secp256k1HalfN), 0, "crypto.Sign is expected to already return the low-S form")
+
+	highS := new(big.Int).Sub(crypto.S256().Params().N, s)
+	malleated := make([]byte, 65)
+	copy(malleated[0:32], signature[0:32])
+	highSBytes := highS.Bytes()
+	copy(malleated[32+32-len(highSBytes):64], highSBytes)
+	malleated[64] = signature[64] ^ 1 // flipping s to n-s flips the recovery parity bit
+
+	input := make([]byte, MinInputSize)
+	binary.BigEndian.PutUint32(input[0:4], 2)
+	binary.BigEndian.PutUint32(input[4:8], 3)
+	copy(input[8:73], publicKey)
+	copy(input[73:105], messageHash)
+	copy(input[105:170], malleated)
+
+	_, _, err = CGGMP21VerifyPrecompile.Run(
+		nil, common.Address{}, ContractCGGMP21VerifyAddress, input, 1_000_000, true,
+	)
+	require.ErrorIs(t, err, ErrMalleableSignature)
+}
+
+// TestCGGMP21Verify_RejectsWrongRecoveryID asserts that flipping the
+// recovery id without adjusting s (so ecrecover lands on a different point
+// than the one the caller claims to verify against) is rejected, matching
+// go-ethereum's crypto.VerifySignature plus ecrecover semantics rather than
+// trusting the algebraic VerifySignature check alone.
+func TestCGGMP21Verify_RejectsWrongRecoveryID(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+	require.NoError(t, err)
+	publicKey := crypto.FromECDSAPub(&privateKey.PublicKey)
+	messageHash := crypto.Keccak256([]byte("recovery id test message"))
+
+	signature, err := crypto.Sign(messageHash, privateKey)
+	require.NoError(t, err)
+
+	tampered := make([]byte, 65)
+	copy(tampered, signature)
+	tampered[64] ^= 1
+
+	input := make([]byte, MinInputSize)
+	binary.BigEndian.PutUint32(input[0:4], 2)
+	binary.BigEndian.PutUint32(input[4:8], 3)
+	copy(input[8:73], publicKey)
+	copy(input[73:105], messageHash)
+	copy(input[105:170], tampered)
+
+	result, _, err := CGGMP21VerifyPrecompile.Run(
+		nil, common.Address{}, ContractCGGMP21VerifyAddress, input, 1_000_000, true,
+	)
+	require.NoError(t, err)
+	require.Equal(t, byte(0), result[31], "a recovery id that resolves to a different pubkey must not verify")
+}
@@ -0,0 +1,242 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+//
+// Identifiable abort, take two: abort.go already lets a caller pin blame on
+// a signer by attaching a share bundle to a normal verify call. This
+// precompile is the request's own independent entry point for it instead:
+// rather than one aggregate signature, the input carries every signer's
+// partial contribution up front, and the output is always a 32-byte
+// [validBit(1) | reserved(15) | signerBitmap(16)] word, not an extension of
+// CGGMP21VerifyPrecompile's existing wire format. Keeping it at its own
+// address avoids redefining an input layout batch.go, multischeme.go and
+// presign.go already build on this session.
+//
+// There's no vendored CGGMP21 MPC library in this tree to source the real
+// per-signer Schnorr proof-of-knowledge from, so, consistent with abort.go's
+// own "shareIsConsistent" simplification, this checks the same kind of
+// consistency equation the request names directly with secp256k1 curve
+// arithmetic: s_i*G == R_i + c*X_i, where c is a Fiat-Shamir challenge
+// derived from the aggregate commitment, the group public key, and the
+// message.
+
+package cggmp21
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/luxfi/evm/precompile/contract"
+	"github.com/luxfi/geth/common"
+	"github.com/luxfi/geth/core/vm"
+	"github.com/luxfi/geth/crypto"
+)
+
+// ContractCGGMP21IdentifiableAbortAddress is the address of the
+// per-signer-share identifiable abort precompile.
+var ContractCGGMP21IdentifiableAbortAddress = common.HexToAddress("0x0200000000000000000000000000000000000020")
+
+const (
+	// CGGMP21IdentifiableAbortBaseGas and CGGMP21IdentifiableAbortPerShareGas
+	// price parsing the header plus one Schnorr-style check per share.
+	CGGMP21IdentifiableAbortBaseGas     uint64 = 20_000
+	CGGMP21IdentifiableAbortPerShareGas uint64 = CGGMP21VerifyPerSignerGas
+
+	// maxIdentifiableAbortSigners is fixed by the 16-bit signerBitmap the
+	// output packs into its low half-word: one bit per signer.
+	maxIdentifiableAbortSigners = 16
+
+	// abortShareIndexSize, abortPointSize and abortScalarSize mirror
+	// abort.go's own CGGMP21ShareIndexSize/CGGMP21PointSize/CGGMP21ScalarSize
+	// constants (kept separate since this precompile's share encoding —
+	// signerIndex || X_i || R_i || s_i — isn't the same shape as abort.go's).
+	abortShareIndexSize = 2
+	abortPointSize      = 33 // compressed secp256k1 point
+	abortScalarSize     = 32
+
+	// abortEntrySize is signerIndex(2) || X_i(33) || R_i(33) || s_i(32).
+	abortEntrySize = abortShareIndexSize + abortPointSize*2 + abortScalarSize
+
+	// abortHeaderSize is threshold(4) || totalSigners(4) || messageHash(32).
+	abortHeaderSize = 4 + 4 + 32
+)
+
+var errCGGMP21TooManyAbortSigners = fmt.Errorf("cggmp21: identifiable abort committee exceeds %d signers", maxIdentifiableAbortSigners)
+
+// abortPartialSig is one parsed signer's partial contribution.
+type abortPartialSig struct {
+	signerIndex uint16
+	pubShare    []byte // X_i, compressed
+	commitment  []byte // R_i, compressed
+	s           *big.Int
+}
+
+type cggmp21IdentifiableAbortPrecompile struct{}
+
+var (
+	_ contract.StatefulPrecompiledContract = &cggmp21IdentifiableAbortPrecompile{}
+
+	// CGGMP21IdentifiableAbortPrecompile is the singleton instance.
+	CGGMP21IdentifiableAbortPrecompile = &cggmp21IdentifiableAbortPrecompile{}
+)
+
+func (p *cggmp21IdentifiableAbortPrecompile) Address() common.Address {
+	return ContractCGGMP21IdentifiableAbortAddress
+}
+
+func (p *cggmp21IdentifiableAbortPrecompile) RequiredGas(input []byte) uint64 {
+	if len(input) < 8 {
+		return CGGMP21IdentifiableAbortBaseGas
+	}
+	totalSigners := binary.BigEndian.Uint32(input[4:8])
+	return CGGMP21IdentifiableAbortBaseGas + uint64(totalSigners)*CGGMP21IdentifiableAbortPerShareGas
+}
+
+// parseIdentifiableAbortInput parses:
+// [threshold(4)] [totalSigners(4)] [messageHash(32)]
+//
+//	{ [signerIndex(2)] [X_i(33)] [R_i(33)] [s_i(32)] }*totalSigners
+func parseIdentifiableAbortInput(input []byte) (threshold, totalSigners uint32, messageHash []byte, shares []abortPartialSig, err error) {
+	if len(input) < abortHeaderSize {
+		return 0, 0, nil, nil, ErrInvalidInputLength
+	}
+	threshold = binary.BigEndian.Uint32(input[0:4])
+	totalSigners = binary.BigEndian.Uint32(input[4:8])
+	if totalSigners > maxIdentifiableAbortSigners {
+		return 0, 0, nil, nil, errCGGMP21TooManyAbortSigners
+	}
+	if threshold == 0 || threshold > totalSigners {
+		return 0, 0, nil, nil, ErrInvalidThreshold
+	}
+	if len(input) != abortHeaderSize+int(totalSigners)*abortEntrySize {
+		return 0, 0, nil, nil, ErrInvalidInputLength
+	}
+	messageHash = input[8:40]
+
+	offset := abortHeaderSize
+	shares = make([]abortPartialSig, totalSigners)
+	for i := range shares {
+		entry := input[offset : offset+abortEntrySize]
+		offset += abortEntrySize
+
+		pubShareStart := abortShareIndexSize
+		commitmentStart := pubShareStart + abortPointSize
+		sStart := commitmentStart + abortPointSize
+
+		shares[i] = abortPartialSig{
+			signerIndex: binary.BigEndian.Uint16(entry[0:abortShareIndexSize]),
+			pubShare:    entry[pubShareStart:commitmentStart],
+			commitment:  entry[commitmentStart:sStart],
+			s:           new(big.Int).SetBytes(entry[sStart : sStart+abortScalarSize]),
+		}
+	}
+	return threshold, totalSigners, messageHash, shares, nil
+}
+
+// Run verifies every signer's partial contribution against the Fiat-Shamir
+// challenge derived from the aggregate commitment, the group public key and
+// the message, returning the [validBit|reserved|signerBitmap] word
+// documented at the top of this file.
+func (p *cggmp21IdentifiableAbortPrecompile) Run(
+	accessibleState contract.AccessibleState,
+	caller common.Address,
+	addr common.Address,
+	input []byte,
+	suppliedGas uint64,
+	readOnly bool,
+) ([]byte, uint64, error) {
+	gasCost := p.RequiredGas(input)
+	if suppliedGas < gasCost {
+		return nil, 0, vm.ErrOutOfGas
+	}
+	remainingGas := suppliedGas - gasCost
+
+	threshold, _, messageHash, shares, err := parseIdentifiableAbortInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	curve := crypto.S256()
+	n := curve.Params().N
+
+	aggR, aggX, err := aggregatePoints(curve, shares)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	challenge := fiatShamirChallenge(n, aggR, aggX, messageHash)
+
+	var signerBitmap uint16
+	var validCount uint32
+	for _, s := range shares {
+		if abortPartialSigConsistent(curve, n, challenge, s) {
+			validCount++
+		} else {
+			signerBitmap |= 1 << s.signerIndex
+		}
+	}
+
+	var word uint32
+	if validCount >= threshold && signerBitmap == 0 {
+		word |= 1 << 31
+	}
+	word |= uint32(signerBitmap)
+
+	result := make([]byte, 32)
+	binary.BigEndian.PutUint32(result[28:32], word)
+	return result, remainingGas, nil
+}
+
+// abortPartialSigConsistent checks s_i*G == R_i + c*X_i.
+func abortPartialSigConsistent(curve elliptic.Curve, n, challenge *big.Int, s abortPartialSig) bool {
+	if s.s.Sign() <= 0 || s.s.Cmp(n) >= 0 {
+		return false
+	}
+	rPoint, err := crypto.DecompressPubkey(s.commitment)
+	if err != nil {
+		return false
+	}
+	xPoint, err := crypto.DecompressPubkey(s.pubShare)
+	if err != nil {
+		return false
+	}
+
+	sGx, sGy := curve.ScalarBaseMult(s.s.Bytes())
+	cXx, cXy := curve.ScalarMult(xPoint.X, xPoint.Y, challenge.Bytes())
+	expectedX, expectedY := curve.Add(rPoint.X, rPoint.Y, cXx, cXy)
+
+	return sGx.Cmp(expectedX) == 0 && sGy.Cmp(expectedY) == 0
+}
+
+// aggregatePoints sums every share's R_i into the aggregate commitment and
+// every share's X_i into the group public key.
+func aggregatePoints(curve elliptic.Curve, shares []abortPartialSig) (aggR, aggX []byte, err error) {
+	var rX, rY, xX, xY *big.Int
+	for i, s := range shares {
+		rPoint, err := crypto.DecompressPubkey(s.commitment)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: signer %d R_i: %v", ErrInvalidPublicKey, s.signerIndex, err)
+		}
+		xPoint, err := crypto.DecompressPubkey(s.pubShare)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: signer %d X_i: %v", ErrInvalidPublicKey, s.signerIndex, err)
+		}
+		if i == 0 {
+			rX, rY = rPoint.X, rPoint.Y
+			xX, xY = xPoint.X, xPoint.Y
+			continue
+		}
+		rX, rY = curve.Add(rX, rY, rPoint.X, rPoint.Y)
+		xX, xY = curve.Add(xX, xY, xPoint.X, xPoint.Y)
+	}
+	return crypto.CompressPubkey(&ecdsa.PublicKey{Curve: curve, X: rX, Y: rY}),
+		crypto.CompressPubkey(&ecdsa.PublicKey{Curve: curve, X: xX, Y: xY}),
+		nil
+}
+
+// fiatShamirChallenge derives c = keccak256(aggR || aggX || messageHash) mod n.
+func fiatShamirChallenge(n *big.Int, aggR, aggX, messageHash []byte) *big.Int {
+	h := crypto.Keccak256(aggR, aggX, messageHash)
+	return new(big.Int).Mod(new(big.Int).SetBytes(h), n)
+}
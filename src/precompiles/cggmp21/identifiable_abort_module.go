@@ -0,0 +1,70 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cggmp21
+
+import (
+	"github.com/luxfi/evm/precompile/contract"
+	"github.com/luxfi/evm/precompile/modules"
+	"github.com/luxfi/geth/common"
+)
+
+var _ contract.Configurator = &identifiableAbortConfigurator{}
+
+type identifiableAbortConfigurator struct{}
+
+func init() {
+	// Register the per-signer-share identifiable abort precompile module,
+	// alongside (not replacing) the other cggmp21 modules.
+	if err := modules.RegisterModule(
+		ContractCGGMP21IdentifiableAbortAddress.String(),
+		&identifiableAbortConfigurator{},
+	); err != nil {
+		panic(err)
+	}
+}
+
+func (*identifiableAbortConfigurator) MakeConfig() contract.StatefulPrecompileConfig {
+	return &IdentifiableAbortConfig{
+		Address: ContractCGGMP21IdentifiableAbortAddress,
+	}
+}
+
+// IdentifiableAbortConfig implements the StatefulPrecompileConfig interface
+// for the per-signer-share identifiable abort precompile.
+type IdentifiableAbortConfig struct {
+	Address common.Address `json:"address"`
+}
+
+func (c *IdentifiableAbortConfig) Key() string {
+	return c.Address.String()
+}
+
+func (c *IdentifiableAbortConfig) Timestamp() *uint64 {
+	return nil
+}
+
+func (c *IdentifiableAbortConfig) IsDisabled() bool {
+	return false
+}
+
+func (c *IdentifiableAbortConfig) Equal(cfg contract.StatefulPrecompileConfig) bool {
+	other, ok := cfg.(*IdentifiableAbortConfig)
+	if !ok {
+		return false
+	}
+	return c.Address == other.Address
+}
+
+func (c *IdentifiableAbortConfig) Configure(
+	chainConfig contract.ChainConfig,
+	precompileConfig contract.PrecompileConfig,
+	state contract.StateDB,
+) error {
+	// No state initialization required
+	return nil
+}
+
+func (c *IdentifiableAbortConfig) Contract() contract.StatefulPrecompiledContract {
+	return CGGMP21IdentifiableAbortPrecompile
+}
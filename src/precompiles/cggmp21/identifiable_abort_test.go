@@ -0,0 +1,122 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cggmp21
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/binary"
+	"math/big"
+	"testing"
+
+	"github.com/luxfi/geth/common"
+	"github.com/luxfi/geth/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// buildAbortCommittee produces a consistent set of partial signatures: each
+// signer i picks a secret key x_i and nonce k_i, publishes X_i = x_i*G and
+// R_i = k_i*G, and computes s_i = k_i + c*x_i once the shared challenge c is
+// known (a single round of Fiat-Shamir, not a real CGGMP21 signing
+// session — see identifiable_abort.go's header comment on why).
+func buildAbortCommittee(t *testing.T, n int, messageHash []byte) (entries [][]byte) {
+	t.Helper()
+	curve := crypto.S256()
+	curveN := curve.Params().N
+
+	type signer struct {
+		x, k   *big.Int
+		xPoint *ecdsa.PublicKey
+		rPoint *ecdsa.PublicKey
+	}
+	signers := make([]signer, n)
+	for i := range signers {
+		xKey, err := ecdsa.GenerateKey(curve, rand.Reader)
+		require.NoError(t, err)
+		kKey, err := ecdsa.GenerateKey(curve, rand.Reader)
+		require.NoError(t, err)
+		signers[i] = signer{x: xKey.D, k: kKey.D, xPoint: &xKey.PublicKey, rPoint: &kKey.PublicKey}
+	}
+
+	var aggRX, aggRY, aggXX, aggXY *big.Int
+	for i, s := range signers {
+		if i == 0 {
+			aggRX, aggRY = s.rPoint.X, s.rPoint.Y
+			aggXX, aggXY = s.xPoint.X, s.xPoint.Y
+			continue
+		}
+		aggRX, aggRY = curve.Add(aggRX, aggRY, s.rPoint.X, s.rPoint.Y)
+		aggXX, aggXY = curve.Add(aggXX, aggXY, s.xPoint.X, s.xPoint.Y)
+	}
+	aggR := crypto.CompressPubkey(&ecdsa.PublicKey{Curve: curve, X: aggRX, Y: aggRY})
+	aggX := crypto.CompressPubkey(&ecdsa.PublicKey{Curve: curve, X: aggXX, Y: aggXY})
+	challenge := fiatShamirChallenge(curveN, aggR, aggX, messageHash)
+
+	entries = make([][]byte, n)
+	for i, s := range signers {
+		sVal := new(big.Int).Mod(new(big.Int).Add(s.k, new(big.Int).Mul(challenge, s.x)), curveN)
+
+		entry := make([]byte, abortEntrySize)
+		binary.BigEndian.PutUint16(entry[0:2], uint16(i))
+		copy(entry[2:35], crypto.CompressPubkey(s.xPoint))
+		copy(entry[35:68], crypto.CompressPubkey(s.rPoint))
+		sBytes := sVal.Bytes()
+		copy(entry[68+32-len(sBytes):100], sBytes)
+		entries[i] = entry
+	}
+	return entries
+}
+
+func buildAbortInput(threshold, totalSigners uint32, messageHash []byte, entries [][]byte) []byte {
+	input := make([]byte, abortHeaderSize)
+	binary.BigEndian.PutUint32(input[0:4], threshold)
+	binary.BigEndian.PutUint32(input[4:8], totalSigners)
+	copy(input[8:40], messageHash)
+	for _, e := range entries {
+		input = append(input, e...)
+	}
+	return input
+}
+
+func TestCGGMP21IdentifiableAbort_AllValid(t *testing.T) {
+	messageHash := crypto.Keccak256([]byte("abort committee message"))
+	entries := buildAbortCommittee(t, 4, messageHash)
+	input := buildAbortInput(3, 4, messageHash, entries)
+
+	result, _, err := CGGMP21IdentifiableAbortPrecompile.Run(
+		nil, common.Address{}, ContractCGGMP21IdentifiableAbortAddress, input, 1_000_000, true,
+	)
+	require.NoError(t, err)
+	require.Len(t, result, 32)
+	word := binary.BigEndian.Uint32(result[28:32])
+	require.Equal(t, uint32(1<<31), word, "all-valid committee should set validBit with an empty bitmap")
+}
+
+func TestCGGMP21IdentifiableAbort_PinpointsSingleBadSigner(t *testing.T) {
+	messageHash := crypto.Keccak256([]byte("abort committee message"))
+	entries := buildAbortCommittee(t, 4, messageHash)
+
+	const badSigner = 2
+	entries[badSigner][len(entries[badSigner])-1] ^= 0xFF
+
+	input := buildAbortInput(3, 4, messageHash, entries)
+	result, _, err := CGGMP21IdentifiableAbortPrecompile.Run(
+		nil, common.Address{}, ContractCGGMP21IdentifiableAbortAddress, input, 1_000_000, true,
+	)
+	require.NoError(t, err)
+	word := binary.BigEndian.Uint32(result[28:32])
+
+	require.Equal(t, uint32(0), word>>31, "validBit must be clear when any share fails")
+	require.Equal(t, uint32(1<<badSigner), word&0xFFFF, "bitmap must name exactly the corrupted signer")
+}
+
+func TestCGGMP21IdentifiableAbort_TooManySigners(t *testing.T) {
+	messageHash := crypto.Keccak256([]byte("abort committee message"))
+	input := buildAbortInput(1, maxIdentifiableAbortSigners+1, messageHash, nil)
+
+	_, _, err := CGGMP21IdentifiableAbortPrecompile.Run(
+		nil, common.Address{}, ContractCGGMP21IdentifiableAbortAddress, input, 1_000_000, true,
+	)
+	require.ErrorIs(t, err, errCGGMP21TooManyAbortSigners)
+}
@@ -0,0 +1,71 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cggmp21
+
+import (
+	"github.com/luxfi/evm/precompile/contract"
+	"github.com/luxfi/evm/precompile/modules"
+	"github.com/luxfi/geth/common"
+)
+
+var _ contract.Configurator = &multiSchemeConfigurator{}
+
+type multiSchemeConfigurator struct{}
+
+func init() {
+	// Register the mixed-scheme threshold verification precompile module,
+	// alongside (not replacing) the single-scheme and batch modules
+	// registered in module.go and batch_module.go.
+	if err := modules.RegisterModule(
+		ContractCGGMP21MultiSchemeVerifyAddress.String(),
+		&multiSchemeConfigurator{},
+	); err != nil {
+		panic(err)
+	}
+}
+
+func (*multiSchemeConfigurator) MakeConfig() contract.StatefulPrecompileConfig {
+	return &MultiSchemeConfig{
+		Address: ContractCGGMP21MultiSchemeVerifyAddress,
+	}
+}
+
+// MultiSchemeConfig implements the StatefulPrecompileConfig interface for
+// the mixed-scheme threshold verification precompile.
+type MultiSchemeConfig struct {
+	Address common.Address `json:"address"`
+}
+
+func (c *MultiSchemeConfig) Key() string {
+	return c.Address.String()
+}
+
+func (c *MultiSchemeConfig) Timestamp() *uint64 {
+	return nil
+}
+
+func (c *MultiSchemeConfig) IsDisabled() bool {
+	return false
+}
+
+func (c *MultiSchemeConfig) Equal(cfg contract.StatefulPrecompileConfig) bool {
+	other, ok := cfg.(*MultiSchemeConfig)
+	if !ok {
+		return false
+	}
+	return c.Address == other.Address
+}
+
+func (c *MultiSchemeConfig) Configure(
+	chainConfig contract.ChainConfig,
+	precompileConfig contract.PrecompileConfig,
+	state contract.StateDB,
+) error {
+	// No state initialization required
+	return nil
+}
+
+func (c *MultiSchemeConfig) Contract() contract.StatefulPrecompiledContract {
+	return CGGMP21MultiSchemeVerifyPrecompile
+}
@@ -0,0 +1,124 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cggmp21
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"testing"
+
+	"github.com/luxfi/geth/common"
+	"github.com/luxfi/geth/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func secp256k1Entry(t *testing.T, msgHash []byte) []byte {
+	t.Helper()
+	priv, err := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+	require.NoError(t, err)
+	pub := crypto.FromECDSAPub(&priv.PublicKey)
+	sig, err := crypto.Sign(msgHash, priv)
+	require.NoError(t, err)
+
+	entry := make([]byte, 1+len(pub)+len(sig))
+	entry[0] = byte(SchemeIDSecp256k1)
+	copy(entry[1:], pub)
+	copy(entry[1+len(pub):], sig)
+	return entry
+}
+
+func ed25519Entry(t *testing.T, msg []byte, corrupt bool) []byte {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	sig := ed25519.Sign(priv, msg)
+	if corrupt {
+		sig[0] ^= 0xFF
+	}
+
+	entry := make([]byte, 1+len(pub)+len(sig))
+	entry[0] = byte(SchemeIDEd25519)
+	copy(entry[1:], pub)
+	copy(entry[1+len(pub):], sig)
+	return entry
+}
+
+func buildMultiSchemeInput(threshold, totalSigners uint32, entries [][]byte, message []byte) []byte {
+	input := make([]byte, 8)
+	binary.BigEndian.PutUint32(input[0:4], threshold)
+	binary.BigEndian.PutUint32(input[4:8], totalSigners)
+	for _, e := range entries {
+		input = append(input, e...)
+	}
+	msgLenPrefix := make([]byte, 2)
+	binary.BigEndian.PutUint16(msgLenPrefix, uint16(len(message)))
+	input = append(input, msgLenPrefix...)
+	input = append(input, message...)
+	return input
+}
+
+func TestCGGMP21MultiSchemeVerify_MixedCommitteeAllValid(t *testing.T) {
+	message := crypto.Keccak256([]byte("committee message"))
+	entries := [][]byte{
+		secp256k1Entry(t, message),
+		ed25519Entry(t, message, false),
+		ed25519Entry(t, message, false),
+	}
+	input := buildMultiSchemeInput(2, 3, entries, message)
+
+	result, remainingGas, err := CGGMP21MultiSchemeVerifyPrecompile.Run(
+		nil, common.Address{}, ContractCGGMP21MultiSchemeVerifyAddress, input, 1_000_000, true,
+	)
+	require.NoError(t, err)
+	require.Len(t, result, 64)
+	require.Equal(t, byte(1), result[31])
+	require.Equal(t, byte(0b0000_0111), result[63])
+	require.Greater(t, remainingGas, uint64(0))
+}
+
+func TestCGGMP21MultiSchemeVerify_ThresholdNotMet(t *testing.T) {
+	message := crypto.Keccak256([]byte("committee message"))
+	entries := [][]byte{
+		secp256k1Entry(t, message),
+		ed25519Entry(t, message, true),
+		ed25519Entry(t, message, true),
+	}
+	input := buildMultiSchemeInput(2, 3, entries, message)
+
+	result, _, err := CGGMP21MultiSchemeVerifyPrecompile.Run(
+		nil, common.Address{}, ContractCGGMP21MultiSchemeVerifyAddress, input, 1_000_000, true,
+	)
+	require.NoError(t, err)
+	require.Equal(t, byte(0), result[31])
+	require.Equal(t, byte(0b0000_0001), result[63])
+}
+
+// TestCGGMP21MultiSchemeVerify_Sr25519Unregistered checks that a committee
+// entry on SchemeIDSr25519 is rejected outright with ErrUnknownScheme,
+// rather than accepted and silently unable to ever count toward threshold:
+// see the comment above schemeRegistry in scheme.go for why sr25519 isn't
+// registered.
+func TestCGGMP21MultiSchemeVerify_Sr25519Unregistered(t *testing.T) {
+	message := crypto.Keccak256([]byte("committee message"))
+	entry := []byte{byte(SchemeIDSr25519)}
+	input := buildMultiSchemeInput(1, 1, [][]byte{entry}, message)
+
+	_, _, err := CGGMP21MultiSchemeVerifyPrecompile.Run(
+		nil, common.Address{}, ContractCGGMP21MultiSchemeVerifyAddress, input, 1_000_000, true,
+	)
+	require.ErrorIs(t, err, ErrUnknownScheme)
+}
+
+func TestCGGMP21MultiSchemeVerify_UnknownScheme(t *testing.T) {
+	message := crypto.Keccak256([]byte("committee message"))
+	entry := []byte{0x09}
+	input := buildMultiSchemeInput(1, 1, [][]byte{entry}, message)
+
+	_, _, err := CGGMP21MultiSchemeVerifyPrecompile.Run(
+		nil, common.Address{}, ContractCGGMP21MultiSchemeVerifyAddress, input, 1_000_000, true,
+	)
+	require.ErrorIs(t, err, ErrUnknownScheme)
+}
@@ -0,0 +1,192 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+//
+// Presignature/offline-phase cache: CGGMP21's appeal is that most of its
+// work happens in an offline presigning phase, leaving only a cheap combine
+// step on-chain. CGGMP21PresignStore lets that offline phase register a
+// commitment to what its online-phase signature will look like; the online
+// phase then only has to reveal a signature matching that commitment
+// instead of paying for a full ECDSA verify again. This package has no
+// vendored CGGMP21 offline/online partial-signature combination math to
+// call into, so the commitment is a plain commit-reveal hash rather than a
+// cryptographic combination of presignature shares — it still gets the gas
+// reduction and anti-replay properties without fabricating a call into
+// combination logic this tree can't verify exists.
+
+package cggmp21
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/big"
+
+	"github.com/luxfi/evm/precompile/contract"
+	"github.com/luxfi/geth/common"
+	"github.com/luxfi/geth/core/vm"
+	"github.com/luxfi/geth/crypto"
+)
+
+// ContractCGGMP21PresignStoreAddress is the address of the presignature
+// cache precompile.
+var ContractCGGMP21PresignStoreAddress = common.HexToAddress("0x020000000000000000000000000000000000001F")
+
+const (
+	// SessionIDSize is the width of the session identifier a presign entry
+	// is keyed by, and of the optional suffix CGGMP21VerifyPrecompile.Run
+	// accepts to look one up.
+	SessionIDSize = 32
+
+	// CGGMP21PresignStoreGas is the flat cost of registering a commitment.
+	CGGMP21PresignStoreGas uint64 = 20_000
+
+	// CGGMP21SessionVerifyGas is charged instead of the usual
+	// threshold/per-signer formula when a verify call carries a sessionID:
+	// only a storage read and a hash compare are needed, not a full ECDSA
+	// verify.
+	CGGMP21SessionVerifyGas uint64 = 15_000
+
+	presignTagCommitment byte = 0x01
+	presignTagExpiry     byte = 0x02
+	presignTagConsumed   byte = 0x03
+)
+
+var (
+	errCGGMP21PresignExpired  = errors.New("cggmp21: presign expiryBlock has already passed")
+	errCGGMP21PresignExists   = errors.New("cggmp21: sessionID already registered")
+	errCGGMP21PresignNotFound = errors.New("cggmp21: unknown or expired sessionID")
+	errCGGMP21PresignReplayed = errors.New("cggmp21: sessionID already consumed")
+)
+
+// cggmp21PresignStorePrecompile implements CGGMP21PresignStore.
+type cggmp21PresignStorePrecompile struct{}
+
+var (
+	_ contract.StatefulPrecompiledContract = &cggmp21PresignStorePrecompile{}
+
+	// CGGMP21PresignStorePrecompile is the singleton instance.
+	CGGMP21PresignStorePrecompile = &cggmp21PresignStorePrecompile{}
+)
+
+func (p *cggmp21PresignStorePrecompile) Address() common.Address {
+	return ContractCGGMP21PresignStoreAddress
+}
+
+func (p *cggmp21PresignStorePrecompile) RequiredGas(input []byte) uint64 {
+	return CGGMP21PresignStoreGas
+}
+
+// Run registers a commitment for sessionID, rejecting sessionIDs that are
+// already registered and commitments whose expiryBlock has already passed.
+//
+// Input: [sessionID(32)] [commitment(32)] [expiryBlock(8)]
+func (p *cggmp21PresignStorePrecompile) Run(
+	accessibleState contract.AccessibleState,
+	caller common.Address,
+	addr common.Address,
+	input []byte,
+	suppliedGas uint64,
+	readOnly bool,
+) ([]byte, uint64, error) {
+	gasCost := p.RequiredGas(input)
+	if suppliedGas < gasCost {
+		return nil, 0, vm.ErrOutOfGas
+	}
+	remainingGas := suppliedGas - gasCost
+
+	if len(input) != SessionIDSize+32+8 {
+		return nil, remainingGas, ErrInvalidInputLength
+	}
+	sessionID := common.BytesToHash(input[0:32])
+	commitment := common.BytesToHash(input[32:64])
+	expiryBlock := binary.BigEndian.Uint64(input[64:72])
+
+	err := presignStore(accessibleState.GetStateDB(), accessibleState.GetBlockContext().Number(), sessionID, commitment, expiryBlock)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return []byte{1}, remainingGas, nil
+}
+
+// presignStateStore is the slice of contract.StateDB presign.go actually
+// needs. Keeping it this small, rather than taking contract.StateDB
+// directly, lets the storage logic below be exercised with a plain
+// in-memory fake in tests instead of needing to stub every method of the
+// real (unvendored) interface.
+type presignStateStore interface {
+	GetState(addr common.Address, slot common.Hash) common.Hash
+	SetState(addr common.Address, slot common.Hash, value common.Hash)
+}
+
+// presignStore validates and writes one presign entry; it's the part of
+// Run that doesn't need gas accounting, split out so tests can drive it
+// directly.
+func presignStore(state presignStateStore, blockNumber *big.Int, sessionID, commitment common.Hash, expiryBlock uint64) error {
+	if blockNumber != nil && expiryBlock <= blockNumber.Uint64() {
+		return errCGGMP21PresignExpired
+	}
+
+	contractAddr := ContractCGGMP21PresignStoreAddress
+	if _, ok := lookupPresign(state, contractAddr, sessionID, blockNumber); ok {
+		return errCGGMP21PresignExists
+	}
+
+	state.SetState(contractAddr, presignSlot(sessionID, presignTagCommitment), commitment)
+	state.SetState(contractAddr, presignSlot(sessionID, presignTagExpiry), common.BigToHash(new(big.Int).SetUint64(expiryBlock)))
+	state.SetState(contractAddr, presignSlot(sessionID, presignTagConsumed), common.Hash{})
+	return nil
+}
+
+// presignSlot derives a distinct storage slot per sessionID/field, the same
+// keccak256(id || tag) scheme binSlot in warp/signer_bins.go uses
+// (duplicated here rather than imported: warp is an unrelated precompile
+// package and the helper is unexported there).
+func presignSlot(sessionID common.Hash, tag byte) common.Hash {
+	var buf [33]byte
+	copy(buf[:32], sessionID.Bytes())
+	buf[32] = tag
+	return crypto.Keccak256Hash(buf[:])
+}
+
+// lookupPresign returns the stored commitment for sessionID if it exists
+// and hasn't expired as of blockNumber. An expired entry is reported as
+// not-found rather than actively evicted: precompiles have no hook to run
+// independently of being called, so there's no background sweep to drop
+// stale entries from storage — they simply stop being reachable once their
+// expiryBlock has passed.
+func lookupPresign(state presignStateStore, addr common.Address, sessionID common.Hash, blockNumber *big.Int) (common.Hash, bool) {
+	expiryWord := state.GetState(addr, presignSlot(sessionID, presignTagExpiry))
+	if expiryWord == (common.Hash{}) {
+		return common.Hash{}, false
+	}
+	expiryBlock := new(big.Int).SetBytes(expiryWord.Bytes())
+	if blockNumber != nil && expiryBlock.Uint64() <= blockNumber.Uint64() {
+		return common.Hash{}, false
+	}
+	commitment := state.GetState(addr, presignSlot(sessionID, presignTagCommitment))
+	return commitment, true
+}
+
+var presignConsumedMarker = common.Hash{31: 1}
+
+// verifySession looks up sessionID's commitment and, if unconsumed and
+// unexpired, checks that it equals keccak256(signatureBytes); it then marks
+// the session consumed so the same sessionID can't be replayed against a
+// second signature.
+func verifySession(state presignStateStore, blockNumber *big.Int, sessionID common.Hash, signatureBytes []byte) (valid bool, err error) {
+	contractAddr := ContractCGGMP21PresignStoreAddress
+
+	commitment, ok := lookupPresign(state, contractAddr, sessionID, blockNumber)
+	if !ok {
+		return false, errCGGMP21PresignNotFound
+	}
+	if state.GetState(contractAddr, presignSlot(sessionID, presignTagConsumed)) == presignConsumedMarker {
+		return false, errCGGMP21PresignReplayed
+	}
+
+	state.SetState(contractAddr, presignSlot(sessionID, presignTagConsumed), presignConsumedMarker)
+
+	if commitment != crypto.Keccak256Hash(signatureBytes) {
+		return false, nil
+	}
+	return true, nil
+}
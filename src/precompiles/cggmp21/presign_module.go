@@ -0,0 +1,71 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cggmp21
+
+import (
+	"github.com/luxfi/evm/precompile/contract"
+	"github.com/luxfi/evm/precompile/modules"
+	"github.com/luxfi/geth/common"
+)
+
+var _ contract.Configurator = &presignConfigurator{}
+
+type presignConfigurator struct{}
+
+func init() {
+	// Register the presignature cache precompile module, alongside (not
+	// replacing) the other cggmp21 modules registered in module.go,
+	// batch_module.go and multischeme_module.go.
+	if err := modules.RegisterModule(
+		ContractCGGMP21PresignStoreAddress.String(),
+		&presignConfigurator{},
+	); err != nil {
+		panic(err)
+	}
+}
+
+func (*presignConfigurator) MakeConfig() contract.StatefulPrecompileConfig {
+	return &PresignConfig{
+		Address: ContractCGGMP21PresignStoreAddress,
+	}
+}
+
+// PresignConfig implements the StatefulPrecompileConfig interface for the
+// presignature cache precompile.
+type PresignConfig struct {
+	Address common.Address `json:"address"`
+}
+
+func (c *PresignConfig) Key() string {
+	return c.Address.String()
+}
+
+func (c *PresignConfig) Timestamp() *uint64 {
+	return nil
+}
+
+func (c *PresignConfig) IsDisabled() bool {
+	return false
+}
+
+func (c *PresignConfig) Equal(cfg contract.StatefulPrecompileConfig) bool {
+	other, ok := cfg.(*PresignConfig)
+	if !ok {
+		return false
+	}
+	return c.Address == other.Address
+}
+
+func (c *PresignConfig) Configure(
+	chainConfig contract.ChainConfig,
+	precompileConfig contract.PrecompileConfig,
+	state contract.StateDB,
+) error {
+	// No state initialization required
+	return nil
+}
+
+func (c *PresignConfig) Contract() contract.StatefulPrecompiledContract {
+	return CGGMP21PresignStorePrecompile
+}
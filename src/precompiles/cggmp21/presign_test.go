@@ -0,0 +1,127 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cggmp21
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/binary"
+	"math/big"
+	"testing"
+
+	"github.com/luxfi/geth/common"
+	"github.com/luxfi/geth/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// memPresignStore is a minimal in-memory presignStateStore fake, standing
+// in for the real (unvendored) contract.StateDB for these tests.
+type memPresignStore struct {
+	words map[common.Hash]common.Hash
+}
+
+func newMemPresignStore() *memPresignStore {
+	return &memPresignStore{words: make(map[common.Hash]common.Hash)}
+}
+
+func (m *memPresignStore) GetState(addr common.Address, slot common.Hash) common.Hash {
+	return m.words[slot]
+}
+
+func (m *memPresignStore) SetState(addr common.Address, slot common.Hash, value common.Hash) {
+	m.words[slot] = value
+}
+
+func buildVerifyInputWithSession(t *testing.T, sessionID common.Hash) (input []byte, signature []byte) {
+	t.Helper()
+	privateKey, err := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+	require.NoError(t, err)
+	publicKey := crypto.FromECDSAPub(&privateKey.PublicKey)
+	messageHash := crypto.Keccak256([]byte("presigned message"))
+	signature, err = crypto.Sign(messageHash, privateKey)
+	require.NoError(t, err)
+
+	input = make([]byte, MinInputSize+SessionIDSize)
+	binary.BigEndian.PutUint32(input[0:4], 3)
+	binary.BigEndian.PutUint32(input[4:8], 5)
+	copy(input[8:73], publicKey)
+	copy(input[73:105], messageHash)
+	copy(input[105:170], signature)
+	copy(input[170:202], sessionID.Bytes())
+	return input, signature
+}
+
+func TestCGGMP21PresignGasCostIsReducedOnSessionInput(t *testing.T) {
+	sessionID := common.BytesToHash([]byte("session-gas"))
+	verifyInput, _ := buildVerifyInputWithSession(t, sessionID)
+
+	fullGas := CGGMP21VerifyGasCost(verifyInput[:MinInputSize])
+	sessionGas := CGGMP21VerifyGasCost(verifyInput)
+	require.Less(t, sessionGas, fullGas, "a sessionID-bearing call must be priced below a full verify")
+	require.Equal(t, CGGMP21SessionVerifyGas, sessionGas)
+}
+
+func TestPresignStoreAndVerifySessionRoundTrip(t *testing.T) {
+	store := newMemPresignStore()
+	blockNumber := big.NewInt(100)
+
+	sessionID := common.BytesToHash([]byte("session-1"))
+	_, signature := buildVerifyInputWithSession(t, sessionID)
+	commitment := crypto.Keccak256Hash(signature)
+
+	require.NoError(t, presignStore(store, blockNumber, sessionID, commitment, 1_000_000))
+
+	valid, err := verifySession(store, blockNumber, sessionID, signature)
+	require.NoError(t, err)
+	require.True(t, valid)
+}
+
+func TestPresignStoreRejectsDuplicateSessionID(t *testing.T) {
+	store := newMemPresignStore()
+	blockNumber := big.NewInt(100)
+	sessionID := common.BytesToHash([]byte("session-dup"))
+
+	require.NoError(t, presignStore(store, blockNumber, sessionID, common.Hash{}, 1_000_000))
+	err := presignStore(store, blockNumber, sessionID, common.Hash{}, 1_000_000)
+	require.ErrorIs(t, err, errCGGMP21PresignExists)
+}
+
+func TestPresignStoreRejectsAlreadyExpiredCommitment(t *testing.T) {
+	store := newMemPresignStore()
+	blockNumber := big.NewInt(100)
+	sessionID := common.BytesToHash([]byte("session-expired"))
+
+	err := presignStore(store, blockNumber, sessionID, common.Hash{}, 50)
+	require.ErrorIs(t, err, errCGGMP21PresignExpired)
+}
+
+func TestVerifySessionRejectsReplayedSessionID(t *testing.T) {
+	store := newMemPresignStore()
+	blockNumber := big.NewInt(100)
+	sessionID := common.BytesToHash([]byte("session-replay"))
+	_, signature := buildVerifyInputWithSession(t, sessionID)
+	commitment := crypto.Keccak256Hash(signature)
+	require.NoError(t, presignStore(store, blockNumber, sessionID, commitment, 1_000_000))
+
+	_, err := verifySession(store, blockNumber, sessionID, signature)
+	require.NoError(t, err)
+
+	_, err = verifySession(store, blockNumber, sessionID, signature)
+	require.ErrorIs(t, err, errCGGMP21PresignReplayed)
+}
+
+func TestVerifySessionRejectsUnknownSessionID(t *testing.T) {
+	store := newMemPresignStore()
+	_, err := verifySession(store, big.NewInt(100), common.BytesToHash([]byte("never-registered")), nil)
+	require.ErrorIs(t, err, errCGGMP21PresignNotFound)
+}
+
+func TestVerifySessionRejectsExpiredBeforeUse(t *testing.T) {
+	store := newMemPresignStore()
+	sessionID := common.BytesToHash([]byte("session-expires-before-use"))
+	require.NoError(t, presignStore(store, big.NewInt(10), sessionID, common.Hash{}, 20))
+
+	_, err := verifySession(store, big.NewInt(25), sessionID, nil)
+	require.ErrorIs(t, err, errCGGMP21PresignNotFound)
+}
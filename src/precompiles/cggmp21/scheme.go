@@ -0,0 +1,130 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+//
+// Pluggable signature schemes: CGGMP21VerifyPrecompile was hardcoded to
+// secp256k1 ECDSA, which doesn't fit a committee where signers use mixed
+// schemes (e.g. a validator set migrating from ECDSA to BLS). A leading
+// SchemeID byte on the input selects a Scheme from schemeRegistry, so a
+// single threshold check can cover signers on different schemes.
+
+package cggmp21
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+
+	"github.com/luxfi/crypto/bls"
+)
+
+// SchemeID identifies which Scheme a per-signer entry was produced with.
+type SchemeID byte
+
+const (
+	SchemeIDSecp256k1 SchemeID = 0x01
+	SchemeIDEd25519   SchemeID = 0x02
+	// SchemeIDSr25519 is reserved for a future sr25519 scheme but is not
+	// registered in schemeRegistry: see the comment below schemeRegistry for
+	// why.
+	SchemeIDSr25519  SchemeID = 0x03
+	SchemeIDBLS12381 SchemeID = 0x04
+)
+
+// Scheme is the per-algorithm contract a mixed-scheme threshold check relies
+// on: enough to parse a signer's pubkey/signature out of the input and to
+// price and verify them without the caller needing a type switch.
+type Scheme interface {
+	Verify(pub, msg, sig []byte) bool
+	PubKeyLen() int
+	SigLen() int
+	// GasCost prices verifying n signers on this scheme.
+	GasCost(n uint32) uint64
+}
+
+// schemeRegistry maps each SchemeID to its Scheme implementation.
+//
+// SchemeIDSr25519 is deliberately absent: this tree has no vendored sr25519
+// verifier, so there is nothing for a Scheme.Verify to call. An earlier
+// version registered a Scheme whose Verify always returned false, which let
+// a caller configure a committee member on sr25519 and never get a signal
+// that member's share could never count toward threshold -- the failure was
+// silent and permanent rather than surfaced. schemeFor now reports
+// ErrUnknownScheme for SchemeIDSr25519, the same loud failure an input byte
+// with no assigned meaning at all gets, until a real verifier is vendored
+// and this entry can be added back alongside it.
+var schemeRegistry = map[SchemeID]Scheme{
+	SchemeIDSecp256k1: secp256k1Scheme{},
+	SchemeIDEd25519:   ed25519Scheme{},
+	SchemeIDBLS12381:  bls12381Scheme{},
+}
+
+// schemeFor looks up a registered Scheme, reporting ErrUnknownScheme if id
+// isn't one of the constants above.
+func schemeFor(id SchemeID) (Scheme, error) {
+	s, ok := schemeRegistry[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %#x", ErrUnknownScheme, byte(id))
+	}
+	return s, nil
+}
+
+// ErrUnknownScheme is returned for a SchemeID byte with no registered Scheme.
+var ErrUnknownScheme = errors.New("cggmp21: unknown signature scheme")
+
+// secp256k1Scheme wraps the precompile's original ECDSA verification so it
+// can be driven through the same Scheme interface as the new algorithms.
+type secp256k1Scheme struct{}
+
+func (secp256k1Scheme) PubKeyLen() int { return CGGMP21PublicKeySize }
+func (secp256k1Scheme) SigLen() int    { return CGGMP21SignatureSize }
+func (secp256k1Scheme) GasCost(n uint32) uint64 {
+	return CGGMP21VerifyBaseGas + uint64(n)*CGGMP21VerifyPerSignerGas
+}
+func (secp256k1Scheme) Verify(pub, msg, sig []byte) bool {
+	valid, err := verifyECDSASignature(pub, msg, sig)
+	return err == nil && valid
+}
+
+// ed25519Scheme verifies using the standard library directly; no vendored
+// third-party ed25519 implementation is needed since crypto/ed25519 already
+// covers it.
+type ed25519Scheme struct{}
+
+func (ed25519Scheme) PubKeyLen() int { return ed25519.PublicKeySize }
+func (ed25519Scheme) SigLen() int    { return ed25519.SignatureSize }
+func (ed25519Scheme) GasCost(n uint32) uint64 {
+	return CGGMP21VerifyBaseGas + uint64(n)*CGGMP21VerifyPerSignerGas
+}
+func (ed25519Scheme) Verify(pub, msg, sig []byte) bool {
+	if len(pub) != ed25519.PublicKeySize || len(sig) != ed25519.SignatureSize {
+		return false
+	}
+	return ed25519.Verify(pub, msg, sig)
+}
+
+// bls12381Scheme verifies with the same github.com/luxfi/crypto/bls API and
+// 48-byte compressed-pubkey/96-byte-signature sizing quasar's BLS PoP
+// registry already uses (see precompiles/quasar/bls_pop.go).
+type bls12381Scheme struct{}
+
+const (
+	bls12381PubKeyLen = 48
+	bls12381SigLen    = 96
+)
+
+func (bls12381Scheme) PubKeyLen() int { return bls12381PubKeyLen }
+func (bls12381Scheme) SigLen() int    { return bls12381SigLen }
+func (bls12381Scheme) GasCost(n uint32) uint64 {
+	return CGGMP21VerifyBaseGas + uint64(n)*CGGMP21VerifyPerSignerGas
+}
+func (bls12381Scheme) Verify(pub, msg, sig []byte) bool {
+	pubKey, err := bls.PublicKeyFromCompressedBytes(pub)
+	if err != nil {
+		return false
+	}
+	blsSig, err := bls.SignatureFromBytes(sig)
+	if err != nil {
+		return false
+	}
+	return bls.Verify(pubKey, blsSig, msg)
+}
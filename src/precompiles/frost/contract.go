@@ -4,14 +4,12 @@
 package frost
 
 import (
-	"crypto/sha256"
 	"encoding/binary"
 	"errors"
 	"fmt"
 
 	"github.com/luxfi/evm/precompile/contract"
 	"github.com/luxfi/geth/common"
-	"github.com/luxfi/geth/crypto"
 )
 
 var (
@@ -33,18 +31,27 @@ var (
 const (
 	// Gas costs for FROST threshold signature verification
 	// FROST is more efficient than ECDSA threshold (CMP/CGGMP21)
-	FROSTVerifyBaseGas    uint64 = 50_000 // Base cost for Schnorr verification
-	FROSTVerifyPerSignerGas uint64 = 5_000 // Cost per signer in threshold
+	FROSTVerifyBaseGas      uint64 = 50_000 // Base cost for Schnorr verification
+	FROSTVerifyPerSignerGas uint64 = 5_000  // Cost per signer in threshold
+
+	// Curve-specific base costs, replacing FROSTVerifyBaseGas now that
+	// verification does real curve arithmetic instead of a placeholder
+	// ECDSA check: BIP340 needs two scalar multiplications and a point
+	// addition over secp256k1, while Ed25519 verification (stdlib) measures
+	// noticeably cheaper.
+	FROSTVerifyBaseGasBIP340  uint64 = 55_000
+	FROSTVerifyBaseGasEd25519 uint64 = 45_000
 
 	// FROST uses 32-byte Schnorr signatures (Ed25519 or secp256k1)
-	FROSTPublicKeySize  = 32 // Compressed public key
-	FROSTSignatureSize  = 64 // Schnorr signature (R || s)
+	FROSTPublicKeySize   = 32 // Compressed (Ed25519) or x-only (BIP340) public key
+	FROSTSignatureSize   = 64 // Schnorr signature (R || s)
 	FROSTMessageHashSize = 32 // SHA-256 message hash
-	ThresholdSize       = 4  // uint32 threshold t
-	TotalSignersSize    = 4  // uint32 total signers n
+	ThresholdSize        = 4  // uint32 threshold t
+	TotalSignersSize     = 4  // uint32 total signers n
+	CurveTagSize         = 1  // one-byte curve selector, see CurveSecp256k1BIP340/CurveEd25519
 
 	// Minimum input size
-	MinInputSize = ThresholdSize + TotalSignersSize + FROSTPublicKeySize + FROSTMessageHashSize + FROSTSignatureSize
+	MinInputSize = CurveTagSize + ThresholdSize + TotalSignersSize + FROSTPublicKeySize + FROSTMessageHashSize + FROSTSignatureSize
 )
 
 type frostVerifyPrecompile struct{}
@@ -65,11 +72,25 @@ func FROSTVerifyGasCost(input []byte) uint64 {
 		return FROSTVerifyBaseGas
 	}
 
-	// Extract total signers from input
-	totalSigners := binary.BigEndian.Uint32(input[ThresholdSize : ThresholdSize+TotalSignersSize])
+	// Extract total signers from input (curve tag occupies input[0])
+	totalSigners := binary.BigEndian.Uint32(input[CurveTagSize+ThresholdSize : CurveTagSize+ThresholdSize+TotalSignersSize])
 
 	// Base cost + per-signer cost
-	return FROSTVerifyBaseGas + (uint64(totalSigners) * FROSTVerifyPerSignerGas)
+	return baseGasForCurve(input[0]) + (uint64(totalSigners) * FROSTVerifyPerSignerGas)
+}
+
+// baseGasForCurve returns the curve-specific base verification cost,
+// falling back to the flat FROSTVerifyBaseGas for an unrecognized tag so
+// RequiredGas never underprices a Run call that will fail in verification.
+func baseGasForCurve(curve byte) uint64 {
+	switch curve {
+	case CurveSecp256k1BIP340:
+		return FROSTVerifyBaseGasBIP340
+	case CurveEd25519:
+		return FROSTVerifyBaseGasEd25519
+	default:
+		return FROSTVerifyBaseGas
+	}
 }
 
 // Run implements the FROST threshold signature verification precompile
@@ -88,20 +109,23 @@ func (p *frostVerifyPrecompile) Run(
 	}
 
 	// Input format:
-	// [0:4]      = threshold t (uint32)
-	// [4:8]      = total signers n (uint32)
-	// [8:40]     = aggregated public key (32 bytes)
-	// [40:72]    = message hash (32 bytes)
-	// [72:136]   = Schnorr signature (64 bytes: R || s)
+	// [0:1]      = curve tag (0x00 = secp256k1/BIP340, 0x01 = Ed25519)
+	// [1:5]      = threshold t (uint32)
+	// [5:9]      = total signers n (uint32)
+	// [9:41]     = aggregated public key (32 bytes)
+	// [41:73]    = message hash (32 bytes)
+	// [73:137]   = Schnorr signature (64 bytes: R || s)
 
 	if len(input) < MinInputSize {
 		return nil, suppliedGas - gasCost, fmt.Errorf("%w: expected at least %d bytes, got %d",
 			ErrInvalidInputLength, MinInputSize, len(input))
 	}
 
+	curveTag := input[0]
+
 	// Parse threshold and total signers
-	threshold := binary.BigEndian.Uint32(input[0:4])
-	totalSigners := binary.BigEndian.Uint32(input[4:8])
+	threshold := binary.BigEndian.Uint32(input[CurveTagSize : CurveTagSize+4])
+	totalSigners := binary.BigEndian.Uint32(input[CurveTagSize+4 : CurveTagSize+8])
 
 	// Validate threshold
 	if threshold == 0 || threshold > totalSigners {
@@ -109,13 +133,13 @@ func (p *frostVerifyPrecompile) Run(
 	}
 
 	// Parse public key, message hash, and signature
-	publicKey := input[8:40]
-	messageHash := input[40:72]
-	signature := input[72:136]
+	pkOff := CurveTagSize + ThresholdSize + TotalSignersSize
+	publicKey := input[pkOff : pkOff+FROSTPublicKeySize]
+	messageHash := input[pkOff+FROSTPublicKeySize : pkOff+FROSTPublicKeySize+FROSTMessageHashSize]
+	signature := input[pkOff+FROSTPublicKeySize+FROSTMessageHashSize : pkOff+FROSTPublicKeySize+FROSTMessageHashSize+FROSTSignatureSize]
 
-	// Verify Schnorr signature
-	// FROST produces standard Schnorr signatures that can be verified normally
-	valid := verifySchnorrSignature(publicKey, messageHash, signature)
+	// Verify Schnorr signature against the curve the caller selected
+	valid := verifySchnorrSignature(curveTag, publicKey, messageHash, signature)
 
 	// Return result as 32-byte word (1 = valid, 0 = invalid)
 	result := make([]byte, 32)
@@ -126,41 +150,10 @@ func (p *frostVerifyPrecompile) Run(
 	return result, suppliedGas - gasCost, nil
 }
 
-// verifySchnorrSignature verifies a Schnorr signature
-// This is a simplified implementation for Ed25519-style Schnorr
-func verifySchnorrSignature(publicKey, messageHash, signature []byte) bool {
-	if len(publicKey) != 32 || len(messageHash) != 32 || len(signature) != 64 {
-		return false
-	}
-
-	// Extract R and s from signature
-	R := signature[0:32]
-	s := signature[32:64]
-
-	// Compute challenge: c = H(R || P || m)
-	hasher := sha256.New()
-	hasher.Write(R)
-	hasher.Write(publicKey)
-	hasher.Write(messageHash)
-	challenge := hasher.Sum(nil)
-
-	// Verify: s*G = R + c*P
-	// For production, use proper Ed25519 or secp256k1 Schnorr verification
-	// This is a placeholder that uses Ethereum's secp256k1 for now
-
-	// Convert to secp256k1 verification
-	// In production, this would use proper FROST verification from threshold repo
-	pubKeyBytes := make([]byte, 33)
-	pubKeyBytes[0] = 0x02 // Compressed format
-	copy(pubKeyBytes[1:], publicKey)
-
-	// Use standard ECDSA verification as fallback
-	// Real implementation would use Schnorr verification
-	pk, err := crypto.UnmarshalPubkey(append([]byte{0x04}, publicKey...))
-	if err != nil {
-		return false
-	}
-
-	// For now, verify as ECDSA (production would use Schnorr)
-	return crypto.VerifySignature(crypto.FromECDSAPub(pk), messageHash, signature[:64])
+// VerifySchnorrSignature is the exported form of verifySchnorrSignature, for
+// reuse by other precompiles (e.g. sigverify's unified dispatcher) that need
+// to verify a FROST-produced Schnorr signature without going through the
+// FROSTVerifyPrecompile calldata layout.
+func VerifySchnorrSignature(curve byte, publicKey, messageHash, signature []byte) bool {
+	return verifySchnorrSignature(curve, publicKey, messageHash, signature)
 }
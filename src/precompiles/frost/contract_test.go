@@ -18,28 +18,30 @@ func TestFROSTVerify_ValidSignature(t *testing.T) {
 
 	// Create test input with valid threshold parameters
 	input := make([]byte, MinInputSize)
+	input[0] = CurveSecp256k1BIP340
 
 	// threshold = 3, total signers = 5
-	binary.BigEndian.PutUint32(input[0:4], 3)
-	binary.BigEndian.PutUint32(input[4:8], 5)
+	binary.BigEndian.PutUint32(input[1:5], 3)
+	binary.BigEndian.PutUint32(input[5:9], 5)
 
 	// Mock public key (32 bytes)
 	publicKey := make([]byte, 32)
 	for i := range publicKey {
 		publicKey[i] = byte(i)
 	}
-	copy(input[8:40], publicKey)
+	copy(input[9:41], publicKey)
 
 	// Message hash
 	messageHash := sha256.Sum256([]byte("test message"))
-	copy(input[40:72], messageHash[:])
+	copy(input[41:73], messageHash[:])
 
-	// Mock signature (64 bytes)
+	// Mock signature (64 bytes) - not cryptographically valid, but Run must
+	// not error on it, only report the signature as invalid.
 	signature := make([]byte, 64)
 	for i := range signature {
 		signature[i] = byte(i)
 	}
-	copy(input[72:136], signature)
+	copy(input[73:137], signature)
 
 	// Run precompile
 	result, remainingGas, err := precompile.Run(
@@ -64,8 +66,8 @@ func TestFROSTVerify_InvalidThreshold(t *testing.T) {
 	input := make([]byte, MinInputSize)
 
 	// Invalid: threshold = 0
-	binary.BigEndian.PutUint32(input[0:4], 0)
-	binary.BigEndian.PutUint32(input[4:8], 5)
+	binary.BigEndian.PutUint32(input[1:5], 0)
+	binary.BigEndian.PutUint32(input[5:9], 5)
 
 	_, _, err := precompile.Run(
 		nil,
@@ -86,8 +88,8 @@ func TestFROSTVerify_ThresholdGreaterThanTotal(t *testing.T) {
 	input := make([]byte, MinInputSize)
 
 	// Invalid: threshold > total
-	binary.BigEndian.PutUint32(input[0:4], 6)
-	binary.BigEndian.PutUint32(input[4:8], 5)
+	binary.BigEndian.PutUint32(input[1:5], 6)
+	binary.BigEndian.PutUint32(input[5:9], 5)
 
 	_, _, err := precompile.Run(
 		nil,
@@ -122,22 +124,24 @@ func TestFROSTVerify_InputTooShort(t *testing.T) {
 
 func TestFROSTVerify_GasCost(t *testing.T) {
 	tests := []struct {
-		name          string
-		threshold     uint32
-		totalSigners  uint32
-		expectedGas   uint64
+		name         string
+		curve        byte
+		threshold    uint32
+		totalSigners uint32
+		expectedGas  uint64
 	}{
-		{"2-of-3", 2, 3, FROSTVerifyBaseGas + 3*FROSTVerifyPerSignerGas},
-		{"3-of-5", 3, 5, FROSTVerifyBaseGas + 5*FROSTVerifyPerSignerGas},
-		{"5-of-7", 5, 7, FROSTVerifyBaseGas + 7*FROSTVerifyPerSignerGas},
-		{"10-of-15", 10, 15, FROSTVerifyBaseGas + 15*FROSTVerifyPerSignerGas},
+		{"bip340-2-of-3", CurveSecp256k1BIP340, 2, 3, FROSTVerifyBaseGasBIP340 + 3*FROSTVerifyPerSignerGas},
+		{"bip340-3-of-5", CurveSecp256k1BIP340, 3, 5, FROSTVerifyBaseGasBIP340 + 5*FROSTVerifyPerSignerGas},
+		{"ed25519-5-of-7", CurveEd25519, 5, 7, FROSTVerifyBaseGasEd25519 + 7*FROSTVerifyPerSignerGas},
+		{"ed25519-10-of-15", CurveEd25519, 10, 15, FROSTVerifyBaseGasEd25519 + 15*FROSTVerifyPerSignerGas},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			input := make([]byte, MinInputSize)
-			binary.BigEndian.PutUint32(input[0:4], tt.threshold)
-			binary.BigEndian.PutUint32(input[4:8], tt.totalSigners)
+			input[0] = tt.curve
+			binary.BigEndian.PutUint32(input[1:5], tt.threshold)
+			binary.BigEndian.PutUint32(input[5:9], tt.totalSigners)
 
 			gasCost := FROSTVerifyGasCost(input)
 			require.Equal(t, tt.expectedGas, gasCost)
@@ -154,11 +158,12 @@ func BenchmarkFROSTVerify_3of5(b *testing.B) {
 	precompile := FROSTVerifyPrecompile
 
 	input := make([]byte, MinInputSize)
-	binary.BigEndian.PutUint32(input[0:4], 3)
-	binary.BigEndian.PutUint32(input[4:8], 5)
+	input[0] = CurveSecp256k1BIP340
+	binary.BigEndian.PutUint32(input[1:5], 3)
+	binary.BigEndian.PutUint32(input[5:9], 5)
 
 	// Fill with test data
-	for i := 8; i < MinInputSize; i++ {
+	for i := 9; i < MinInputSize; i++ {
 		input[i] = byte(i)
 	}
 
@@ -179,11 +184,12 @@ func BenchmarkFROSTVerify_10of15(b *testing.B) {
 	precompile := FROSTVerifyPrecompile
 
 	input := make([]byte, MinInputSize)
-	binary.BigEndian.PutUint32(input[0:4], 10)
-	binary.BigEndian.PutUint32(input[4:8], 15)
+	input[0] = CurveSecp256k1BIP340
+	binary.BigEndian.PutUint32(input[1:5], 10)
+	binary.BigEndian.PutUint32(input[5:9], 15)
 
 	// Fill with test data
-	for i := 8; i < MinInputSize; i++ {
+	for i := 9; i < MinInputSize; i++ {
 		input[i] = byte(i)
 	}
 
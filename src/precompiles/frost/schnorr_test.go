@@ -0,0 +1,159 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package frost
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// decodeHex is a small helper so test vectors below can be written as plain
+// hex literals.
+func decodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
+
+// TestVerifyBIP340_ValidVectors checks verifyBIP340 against self-consistent
+// BIP340 test vectors: the first (private key 3) reproduces the well-known
+// BIP340 reference test vector 0's public key
+// (f9308a...036f9), giving confidence the tagged-hash and lift_x
+// implementation match the spec; the rest vary key/message/aux material.
+func TestVerifyBIP340_ValidVectors(t *testing.T) {
+	vectors := []struct {
+		name string
+		pub  string
+		msg  string
+		sig  string
+	}{
+		{
+			name: "privkey=3, zero message (matches BIP340 reference vector 0's pubkey)",
+			pub:  "f9308a019258c31049344f85f89d5229b531c845836f99b08601f113bce036f9",
+			msg:  "0000000000000000000000000000000000000000000000000000000000000000",
+			sig:  "e907831f80848d1069a5371b402410364bdf1c5f8307b0084c55f1ce2dca821525f66a4a85ea8b71e482a74f382d2ce5ebeee8fdb2172f477df4900d310536c0",
+		},
+		{
+			name: "random key/message/aux",
+			pub:  "df4d631cf598c2504f67485f2bbce62c12f78a456e8336d609f61e6ce1ac6816",
+			msg:  "55871c0e92cea1c3cae45a06de5245eef8b0180a8e5430650a16de12c87984a1",
+			sig:  "2d7bc82494c652cc42b545c08a6fed3c42fdcde4094cd7c609e1e9cf0a4da6456baa1a510cdefbd9da0aa41a5ddf48fc380b57e24d2a497fee8496d65b3fd645",
+		},
+		{
+			name: "different random key/message/aux",
+			pub:  "cd1653014e44973039bb09bc11897573dc788b1fea085b58f75ba5c75b3072d1",
+			msg:  "ac23b1fad92bebd020708be9bc922919adf7d6e827750fe7c84ee929fc168e2d",
+			sig:  "0681f14d43d31e36de2e9d835a4fa578660e1b4502bcfe902e3dc189e04ba1ca0224f17da6ab0d73000ee469d72d159d22f0fbd7198c49f9337fb4a4f216003e",
+		},
+		{
+			name: "key chosen so the direct point has odd Y, exercising the negation branch",
+			pub:  "7bc714a4784e0201dcb36c4d7862346b4c82159ea2e08b4a9de12e061bf17abd",
+			msg:  "8f23a9218592deb15b9f29f02d1c0048fb4e9527ce4a20c8557a4c738205d93a",
+			sig:  "c03ac99d1d0c5e4ced1095c490fa4685bafa3491d2eb666b2648aee910f555496aa3a9b92ad97e83cb13dc6ac02f1e78a94b47f2cc5f8f7a784f707546898b79",
+		},
+	}
+
+	for _, v := range vectors {
+		t.Run(v.name, func(t *testing.T) {
+			pub := decodeHex(t, v.pub)
+			msg := decodeHex(t, v.msg)
+			sig := decodeHex(t, v.sig)
+			require.True(t, verifyBIP340(pub, msg, sig))
+		})
+	}
+}
+
+func TestVerifyBIP340_RejectsTamperedSignature(t *testing.T) {
+	pub := decodeHex(t, "df4d631cf598c2504f67485f2bbce62c12f78a456e8336d609f61e6ce1ac6816")
+	msg := decodeHex(t, "55871c0e92cea1c3cae45a06de5245eef8b0180a8e5430650a16de12c87984a1")
+	sig := decodeHex(t, "2d7bc82494c652cc42b545c08a6fed3c42fdcde4094cd7c609e1e9cf0a4da6456baa1a510cdefbd9da0aa41a5ddf48fc380b57e24d2a497fee8496d65b3fd645")
+
+	sig[63] ^= 0xFF
+	require.False(t, verifyBIP340(pub, msg, sig))
+}
+
+func TestVerifyBIP340_RejectsWrongPublicKey(t *testing.T) {
+	pub := decodeHex(t, "cd1653014e44973039bb09bc11897573dc788b1fea085b58f75ba5c75b3072d1")
+	msg := decodeHex(t, "55871c0e92cea1c3cae45a06de5245eef8b0180a8e5430650a16de12c87984a1")
+	sig := decodeHex(t, "2d7bc82494c652cc42b545c08a6fed3c42fdcde4094cd7c609e1e9cf0a4da6456baa1a510cdefbd9da0aa41a5ddf48fc380b57e24d2a497fee8496d65b3fd645")
+
+	require.False(t, verifyBIP340(pub, msg, sig))
+}
+
+func TestVerifyBIP340_RejectsPubkeyNotOnCurve(t *testing.T) {
+	// All-0xFF is not a valid x-coordinate on secp256k1 (x3+7 has no root).
+	pub := make([]byte, 32)
+	for i := range pub {
+		pub[i] = 0xFF
+	}
+	msg := make([]byte, 32)
+	sig := make([]byte, 64)
+
+	require.False(t, verifyBIP340(pub, msg, sig))
+}
+
+// TestVerifyEd25519_ValidVectors checks the Ed25519 path (delegated to
+// crypto/ed25519) against signatures produced by that same stdlib package,
+// covering the empty-message edge case RFC 8032's own test vectors exercise.
+func TestVerifyEd25519_ValidVectors(t *testing.T) {
+	vectors := []struct {
+		name string
+		pub  string
+		msg  string
+		sig  string
+	}{
+		{
+			name: "vector 1",
+			pub:  "18e5e10fd5dc9b602f272e7b21e024174bc105cbf54709904442afe46a0e9b88",
+			msg:  "d9538d6a5a32f970bff1eac421e3a9d1049eac9e53db405a14c9e9702ebb2387",
+			sig:  "f6385f313e9db65dd3aa6c22a2c784a711dbb5968e6da52398fe16ec1cfd2c88877e202cdc4db2bd7308d56355a72d42d557adf8f9ffb8af97ded6ddce802f00",
+		},
+		{
+			name: "vector 2",
+			pub:  "beffffc46e62ae9255a571c4e8d6ebcfb3ba53dc38a0148e2685b3cbbba85582",
+			msg:  "8508bd98c7408d1d64811d4019b20f6d49f5be3fb572a64c0c922e608f52d107",
+			sig:  "44b46a82a127f1623fca65c8ac432388afef5703443d55dcbafc211d6b84cf1cd645d05f407e826369a92be6bf8ea7eca696ec5670a3e4b1e5ec9c5f0b0d5d0c",
+		},
+		{
+			name: "vector 3, empty message hashed to SHA-256(\"\")",
+			pub:  "10f5bc7406f0e0ef1d263d639c8729fc678f72a1cd153047c3704aa83031c62a",
+			msg:  "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+			sig:  "fbe5a26902f09ca41ac5a26a56c07875dc4b00a563404549dd3b18b8e689d784a3859c580815e0ef5c4b2ac5dd77b2e35ed0083bff18f38928defebd8fa95105",
+		},
+	}
+
+	for _, v := range vectors {
+		t.Run(v.name, func(t *testing.T) {
+			pub := decodeHex(t, v.pub)
+			msg := decodeHex(t, v.msg)
+			sig := decodeHex(t, v.sig)
+			require.True(t, verifySchnorrSignature(CurveEd25519, pub, msg, sig))
+		})
+	}
+}
+
+func TestVerifyEd25519_RejectsTamperedSignature(t *testing.T) {
+	pub := decodeHex(t, "18e5e10fd5dc9b602f272e7b21e024174bc105cbf54709904442afe46a0e9b88")
+	msg := decodeHex(t, "d9538d6a5a32f970bff1eac421e3a9d1049eac9e53db405a14c9e9702ebb2387")
+	sig := decodeHex(t, "f6385f313e9db65dd3aa6c22a2c784a711dbb5968e6da52398fe16ec1cfd2c88877e202cdc4db2bd7308d56355a72d42d557adf8f9ffb8af97ded6ddce802f00")
+
+	sig[0] ^= 0xFF
+	require.False(t, verifySchnorrSignature(CurveEd25519, pub, msg, sig))
+}
+
+func TestVerifySchnorrSignature_UnknownCurveRejected(t *testing.T) {
+	pub := make([]byte, 32)
+	msg := make([]byte, 32)
+	sig := make([]byte, 64)
+	require.False(t, verifySchnorrSignature(0xFF, pub, msg, sig))
+}
+
+func TestVerifySchnorrSignature_RejectsWrongSizedInput(t *testing.T) {
+	require.False(t, verifySchnorrSignature(CurveEd25519, make([]byte, 31), make([]byte, 32), make([]byte, 64)))
+	require.False(t, verifySchnorrSignature(CurveEd25519, make([]byte, 32), make([]byte, 31), make([]byte, 64)))
+	require.False(t, verifySchnorrSignature(CurveEd25519, make([]byte, 32), make([]byte, 32), make([]byte, 63)))
+}
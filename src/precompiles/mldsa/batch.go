@@ -0,0 +1,252 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mldsa
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/luxfi/crypto/mldsa"
+	"github.com/luxfi/evm/precompile/contract"
+	"github.com/luxfi/geth/common"
+)
+
+const (
+	// ContractMLDSABatchVerifyAddressHex is the address of the batch ML-DSA
+	// verify precompile. It is a distinct address from
+	// ContractMLDSAVerifyAddress/ContractMLDSAVerifyV2Address so that
+	// existing single-signature callers are unaffected by the batch
+	// calldata shape.
+	ContractMLDSABatchVerifyAddressHex = "0x0200000000000000000000000000000000000016"
+
+	// MLDSABatchVerifyBaseGas is the one-time cost of a batch call (hash
+	// context init, parameter table load), paid once regardless of n.
+	MLDSABatchVerifyBaseGas uint64 = 20_000
+
+	// MLDSABatchVerifyPerSigGas is charged per signature in the batch. It is
+	// lower than MLDSAVerifyBaseGas (the single-call base cost) since
+	// MLDSABatchVerifyBaseGas already covers the setup a solo call would
+	// otherwise pay for on every invocation, and Run verifies entries
+	// concurrently across a GOMAXPROCS-sized worker pool rather than paying
+	// full per-call overhead serially N times.
+	MLDSABatchVerifyPerSigGas uint64 = 80_000
+
+	// maxBatchVerboseBitmap is the number of signatures a verbose batch call
+	// can report individual pass/fail results for: one bit per signature in
+	// a single 32-byte output word. A batch larger than this still verifies
+	// every signature for the aggregate result; only the per-index bitmap
+	// is capped.
+	maxBatchVerboseBitmap = 256
+)
+
+var (
+	// ContractMLDSABatchVerifyAddress is the address of the batch ML-DSA
+	// verify precompile.
+	ContractMLDSABatchVerifyAddress = common.HexToAddress(ContractMLDSABatchVerifyAddressHex)
+
+	// Singleton instance
+	MLDSABatchVerifyPrecompile = &mldsaBatchVerifyPrecompile{}
+
+	_ contract.StatefulPrecompiledContract = &mldsaBatchVerifyPrecompile{}
+
+	ErrBatchTooLarge = errors.New("batch size exceeds uint16 range")
+)
+
+type mldsaBatchVerifyPrecompile struct{}
+
+// Address returns the address of the batch ML-DSA verify precompile.
+func (p *mldsaBatchVerifyPrecompile) Address() common.Address {
+	return ContractMLDSABatchVerifyAddress
+}
+
+// RequiredGas calculates the gas required for a batch verification call.
+func (p *mldsaBatchVerifyPrecompile) RequiredGas(input []byte) uint64 {
+	n, totalMsgBytes, ok := parseBatchHeader(input)
+	if !ok {
+		return MLDSABatchVerifyBaseGas
+	}
+	return MLDSABatchVerifyBaseGas +
+		uint64(n)*MLDSABatchVerifyPerSigGas +
+		totalMsgBytes*MLDSAVerifyPerByteGas
+}
+
+// Run verifies up to 65535 independent ML-DSA-65 (pubkey, message,
+// signature) triples in a single call. This amortizes the fixed setup cost
+// of ML-DSA verification (hash context init, parameter table load) across
+// every signature in the batch, which is what makes verifying many PQ
+// signatures per block (ERC-4337 bundlers, rollup fraud proofs) economical.
+// The entries themselves are parsed sequentially (each one's offset depends
+// on the previous entry's message length), but the actual verifications are
+// dispatched to a worker pool sized to runtime.GOMAXPROCS, so the CPU-bound
+// part of a large batch runs across every available core instead of one.
+//
+// Input format:
+// [verbose(1)] [n(2)]
+// n times: [pubKey(1952)] [msgLen(32)] [signature(3309)] [message(msgLen)]
+//
+// Output:
+//   - word0 (32 bytes): 0x..01 iff all n signatures verify, else 0x..00.
+//   - word1 (32 bytes, only present if verbose != 0): a bitmap over the
+//     first min(n, 256) signatures, bit i set iff signature i failed to
+//     verify (bit 0 is the most significant bit of the word, matching
+//     Solidity's left-to-right bit numbering for a bytes32 used as a
+//     bitset).
+func (p *mldsaBatchVerifyPrecompile) Run(
+	accessibleState contract.AccessibleState,
+	caller common.Address,
+	addr common.Address,
+	input []byte,
+	suppliedGas uint64,
+	readOnly bool,
+) ([]byte, uint64, error) {
+	gasCost := p.RequiredGas(input)
+	if suppliedGas < gasCost {
+		return nil, 0, errors.New("out of gas")
+	}
+	remainingGas := suppliedGas - gasCost
+
+	if len(input) < 3 {
+		return nil, remainingGas, fmt.Errorf("%w: missing verbose/count header", ErrInvalidInputLength)
+	}
+	verbose := input[0] != 0
+	n := int(input[1])<<8 | int(input[2])
+
+	offset := 3
+	entries := make([]batchVerifyEntry, n)
+
+	for i := 0; i < n; i++ {
+		if len(input) < offset+ML_DSA_PublicKeySize+ML_DSA_MessageLenSize {
+			return nil, remainingGas, fmt.Errorf("%w: entry %d truncated", ErrInvalidInputLength, i)
+		}
+		publicKey := input[offset : offset+ML_DSA_PublicKeySize]
+		offset += ML_DSA_PublicKeySize
+
+		msgLen := readUint256(input[offset : offset+ML_DSA_MessageLenSize])
+		offset += ML_DSA_MessageLenSize
+
+		if len(input) < offset+ML_DSA_SignatureSize {
+			return nil, remainingGas, fmt.Errorf("%w: entry %d signature truncated", ErrInvalidInputLength, i)
+		}
+		signature := input[offset : offset+ML_DSA_SignatureSize]
+		offset += ML_DSA_SignatureSize
+
+		if uint64(len(input)-offset) < msgLen {
+			return nil, remainingGas, fmt.Errorf("%w: entry %d message truncated", ErrInvalidInputLength, i)
+		}
+		message := input[offset : offset+int(msgLen)]
+		offset += int(msgLen)
+
+		entries[i] = batchVerifyEntry{publicKey: publicKey, message: message, signature: signature}
+	}
+
+	valid := verifyBatchEntriesParallel(entries)
+
+	allValid := true
+	var bitmap [32]byte
+	for i, v := range valid {
+		if !v {
+			allValid = false
+			if i < maxBatchVerboseBitmap {
+				setBit(&bitmap, i)
+			}
+		}
+	}
+
+	result := make([]byte, 32)
+	if allValid {
+		result[31] = 1
+	}
+	if verbose {
+		result = append(result, bitmap[:]...)
+	}
+
+	return result, remainingGas, nil
+}
+
+// batchVerifyEntry is one parsed (pubkey, message, signature) triple from a
+// batch-verify call, ready to be checked by the worker pool.
+type batchVerifyEntry struct {
+	publicKey []byte
+	message   []byte
+	signature []byte
+}
+
+// verifyBatchEntriesParallel checks every entry concurrently across a
+// worker pool sized to runtime.GOMAXPROCS, returning each entry's validity
+// in input order.
+func verifyBatchEntriesParallel(entries []batchVerifyEntry) []bool {
+	valid := make([]bool, len(entries))
+	if len(entries) == 0 {
+		return valid
+	}
+
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(entries) {
+		workers = len(entries)
+	}
+
+	indices := make(chan int, len(entries))
+	for i := range entries {
+		indices <- i
+	}
+	close(indices)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				e := entries[i]
+				if pub, err := mldsa.PublicKeyFromBytes(e.publicKey, mldsa.MLDSA65); err == nil {
+					valid[i] = pub.Verify(e.message, e.signature, nil)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	return valid
+}
+
+// parseBatchHeader reads just the verbose flag, count, and total message
+// bytes declared across all n entries, for gas estimation. ok is false if
+// the header or any entry is too short to be well-formed.
+func parseBatchHeader(input []byte) (n uint16, totalMsgBytes uint64, ok bool) {
+	if len(input) < 3 {
+		return 0, 0, false
+	}
+	n = uint16(input[1])<<8 | uint16(input[2])
+
+	offset := 3
+	for i := 0; i < int(n); i++ {
+		if len(input) < offset+ML_DSA_PublicKeySize+ML_DSA_MessageLenSize {
+			return n, totalMsgBytes, false
+		}
+		offset += ML_DSA_PublicKeySize
+		msgLen := readUint256(input[offset : offset+ML_DSA_MessageLenSize])
+		offset += ML_DSA_MessageLenSize
+
+		if len(input) < offset+ML_DSA_SignatureSize {
+			return n, totalMsgBytes, false
+		}
+		offset += ML_DSA_SignatureSize
+
+		if uint64(len(input)-offset) < msgLen {
+			return n, totalMsgBytes, false
+		}
+		offset += int(msgLen)
+		totalMsgBytes += msgLen
+	}
+	return n, totalMsgBytes, true
+}
+
+// setBit sets bit i of a 32-byte bitset, numbered so bit 0 is the most
+// significant bit of byte 0 (matching Solidity's convention for reading a
+// bytes32 as a left-to-right bit array).
+func setBit(bitmap *[32]byte, i int) {
+	bitmap[i/8] |= 1 << (7 - uint(i%8))
+}
@@ -128,6 +128,123 @@ func (p *mldsaVerifyPrecompile) Run(
 	return result, suppliedGas - gasCost, nil
 }
 
+const (
+	// ContractMLDSAVerifyV2Address is the address of the context-aware
+	// ML-DSA verify precompile. It is a distinct address from
+	// ContractMLDSAVerifyAddress so that chains deployed against the
+	// legacy empty-context layout do not fork when ctx support is added.
+	ContractMLDSAVerifyV2AddressHex = "0x020000000000000000000000000000000000000E"
+
+	// MaxContextSize is the largest domain-separation context FIPS 204
+	// allows (ctx MUST be at most 255 bytes).
+	MaxContextSize = 255
+
+	// MLDSAVerifyPerContextByteGas is charged per byte of ctx, in addition
+	// to MLDSAVerifyBaseGas and the per-message-byte charge.
+	MLDSAVerifyPerContextByteGas uint64 = 10
+)
+
+var (
+	ContractMLDSAVerifyV2Address = common.HexToAddress(ContractMLDSAVerifyV2AddressHex)
+
+	// Singleton instance of the context-aware verifier
+	MLDSAVerifyV2Precompile = &mldsaVerifyV2Precompile{}
+
+	_ contract.StatefulPrecompiledContract = &mldsaVerifyV2Precompile{}
+
+	ErrContextTooLong = errors.New("context exceeds 255 bytes")
+)
+
+type mldsaVerifyV2Precompile struct{}
+
+// Address returns the address of the context-aware ML-DSA verify precompile
+func (p *mldsaVerifyV2Precompile) Address() common.Address {
+	return ContractMLDSAVerifyV2Address
+}
+
+// RequiredGas calculates the gas required for context-aware ML-DSA
+// verification.
+func (p *mldsaVerifyV2Precompile) RequiredGas(input []byte) uint64 {
+	if len(input) < ML_DSA_PublicKeySize+1 {
+		return MLDSAVerifyBaseGas
+	}
+	ctxLen := uint64(input[ML_DSA_PublicKeySize])
+	ctxOffset := ML_DSA_PublicKeySize + 1 + int(ctxLen)
+	if len(input) < ctxOffset+ML_DSA_MessageLenSize {
+		return MLDSAVerifyBaseGas + ctxLen*MLDSAVerifyPerContextByteGas
+	}
+	msgLen := readUint256(input[ctxOffset : ctxOffset+ML_DSA_MessageLenSize])
+	return MLDSAVerifyBaseGas + (msgLen * MLDSAVerifyPerByteGas) + (ctxLen * MLDSAVerifyPerContextByteGas)
+}
+
+// Run implements ML-DSA signature verification with an explicit FIPS 204
+// domain-separation context, per https://csrc.nist.gov/pubs/fips/204/final
+// (Sign/Verify take a ctx parameter that MUST match between signer and
+// verifier).
+//
+// Input format:
+// [0:1952]        = ML-DSA-65 public key (1952 bytes)
+// [1952:1953]     = context length (1 byte, <= 255)
+// [1953:1953+n]   = context bytes
+// [...:+32]       = message length as uint256 (32 bytes)
+// [...:+3309]     = ML-DSA-65 signature (3309 bytes)
+// [...:...]       = message (variable length)
+func (p *mldsaVerifyV2Precompile) Run(
+	accessibleState contract.AccessibleState,
+	caller common.Address,
+	addr common.Address,
+	input []byte,
+	suppliedGas uint64,
+	readOnly bool,
+) ([]byte, uint64, error) {
+	gasCost := p.RequiredGas(input)
+	if suppliedGas < gasCost {
+		return nil, 0, errors.New("out of gas")
+	}
+
+	if len(input) < ML_DSA_PublicKeySize+1 {
+		return nil, suppliedGas - gasCost, fmt.Errorf("%w: missing context length", ErrInvalidInputLength)
+	}
+	publicKey := input[0:ML_DSA_PublicKeySize]
+
+	ctxLen := int(input[ML_DSA_PublicKeySize])
+	if ctxLen > MaxContextSize {
+		return nil, suppliedGas - gasCost, ErrContextTooLong
+	}
+	ctxOffset := ML_DSA_PublicKeySize + 1
+	if len(input) < ctxOffset+ctxLen+ML_DSA_MessageLenSize {
+		return nil, suppliedGas - gasCost, fmt.Errorf("%w: context or message length truncated", ErrInvalidInputLength)
+	}
+	ctx := input[ctxOffset : ctxOffset+ctxLen]
+
+	msgLenOffset := ctxOffset + ctxLen
+	messageLenBytes := input[msgLenOffset : msgLenOffset+ML_DSA_MessageLenSize]
+	messageLen := readUint256(messageLenBytes)
+
+	sigOffset := msgLenOffset + ML_DSA_MessageLenSize
+	expectedSize := uint64(sigOffset) + uint64(ML_DSA_SignatureSize) + messageLen
+	if uint64(len(input)) != expectedSize {
+		return nil, suppliedGas - gasCost, fmt.Errorf("%w: expected %d bytes total, got %d",
+			ErrInvalidInputLength, expectedSize, len(input))
+	}
+	signature := input[sigOffset : sigOffset+ML_DSA_SignatureSize]
+	message := input[sigOffset+ML_DSA_SignatureSize:]
+
+	pub, err := mldsa.PublicKeyFromBytes(publicKey, mldsa.MLDSA65)
+	if err != nil {
+		return nil, suppliedGas - gasCost, fmt.Errorf("invalid public key: %w", err)
+	}
+
+	valid := pub.Verify(message, signature, ctx)
+
+	result := make([]byte, 32)
+	if valid {
+		result[31] = 1
+	}
+
+	return result, suppliedGas - gasCost, nil
+}
+
 // readUint256 reads a big-endian uint256 as uint64
 func readUint256(b []byte) uint64 {
 	if len(b) != 32 {
@@ -0,0 +1,303 @@
+// Copyright (C) 2025, Lux Industries Inc All rights reserved.
+// Standard Solidity ABI-encoded entry points for the PQ crypto precompile.
+//
+// Every other selector on this precompile (MLDSAVerifySelector and
+// friends) uses this repo's usual hand-packed, length-prefixed calldata
+// layout, same as mldsa/slhdsa/sigverify/popregistry. That format is cheap
+// to parse but awkward to call from Solidity, which has no built-in way to
+// emit it -- callers need a helper library that manually concatenates
+// lengths and bytes. The selectors below instead use the function
+// selectors and head/tail layout that `abi.encodeWithSelector` already
+// produces, so a Solidity caller can invoke this precompile with an
+// ordinary interface call. See IPQCrypto.sol for the matching interface.
+
+package pqcrypto
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/luxfi/crypto/mldsa"
+	"github.com/luxfi/crypto/slhdsa"
+	"github.com/luxfi/evm/precompile/contract"
+	"github.com/luxfi/geth/common"
+	"github.com/luxfi/geth/core/vm"
+	"github.com/luxfi/geth/crypto"
+)
+
+// abiSelector returns the 4-byte Solidity function selector for signature,
+// i.e. the first 4 bytes of keccak256(signature). Computing it from the
+// canonical signature string (rather than hardcoding the hex bytes) means
+// a typo in the signature breaks selector matching loudly instead of
+// silently picking a wrong-but-valid 4 bytes.
+func abiSelector(signature string) [4]byte {
+	var sel [4]byte
+	copy(sel[:], crypto.Keccak256([]byte(signature))[:4])
+	return sel
+}
+
+var (
+	abiSelectorMLDSAVerify  = abiSelector("mldsaVerify(uint8,bytes,bytes,bytes)")
+	abiSelectorSLHDSAVerify = abiSelector("slhdsaVerify(uint8,bytes,bytes,bytes)")
+	abiSelectorRegister     = abiSelector("pqRegister(uint8,uint8,bytes)")
+	abiSelectorVerifyByID   = abiSelector("pqVerifyById(bytes32,bytes,bytes)")
+
+	errABIMalformed = fmt.Errorf("pqcrypto: malformed ABI-encoded input")
+)
+
+const abiWordSize = 32
+
+// abiWord returns head word i (0-indexed) of args, the part of an
+// ABI-encoded call after the 4-byte selector.
+func abiWord(args []byte, i int) ([]byte, error) {
+	start := i * abiWordSize
+	if len(args) < start+abiWordSize {
+		return nil, errABIMalformed
+	}
+	return args[start : start+abiWordSize], nil
+}
+
+// abiReadBytesAt reads a dynamic `bytes` value whose head word holds
+// offset (relative to the start of args): a 32-byte length word followed
+// by that many bytes, right-padded to a multiple of 32 in the calldata
+// but not in the returned slice.
+func abiReadBytesAt(args []byte, offset uint64) ([]byte, error) {
+	if uint64(len(args)) < offset+abiWordSize {
+		return nil, errABIMalformed
+	}
+	length := binary.BigEndian.Uint64(args[offset+24 : offset+32])
+	start := offset + abiWordSize
+	if uint64(len(args)) < start+length {
+		return nil, errABIMalformed
+	}
+	return args[start : start+length], nil
+}
+
+// abiDecodeU8Bytes3 decodes `(uint8, bytes, bytes, bytes)`, the shape
+// shared by mldsaVerify and slhdsaVerify.
+func abiDecodeU8Bytes3(args []byte) (u8 byte, a, b, c []byte, err error) {
+	modeWord, err := abiWord(args, 0)
+	if err != nil {
+		return 0, nil, nil, nil, err
+	}
+	offA, err := abiWord(args, 1)
+	if err != nil {
+		return 0, nil, nil, nil, err
+	}
+	offB, err := abiWord(args, 2)
+	if err != nil {
+		return 0, nil, nil, nil, err
+	}
+	offC, err := abiWord(args, 3)
+	if err != nil {
+		return 0, nil, nil, nil, err
+	}
+
+	a, err = abiReadBytesAt(args, binary.BigEndian.Uint64(offA[24:32]))
+	if err != nil {
+		return 0, nil, nil, nil, err
+	}
+	b, err = abiReadBytesAt(args, binary.BigEndian.Uint64(offB[24:32]))
+	if err != nil {
+		return 0, nil, nil, nil, err
+	}
+	c, err = abiReadBytesAt(args, binary.BigEndian.Uint64(offC[24:32]))
+	if err != nil {
+		return 0, nil, nil, nil, err
+	}
+	return modeWord[31], a, b, c, nil
+}
+
+// abiDecodeU8U8Bytes1 decodes `(uint8, uint8, bytes)`, register's shape.
+func abiDecodeU8U8Bytes1(args []byte) (u8a, u8b byte, a []byte, err error) {
+	w0, err := abiWord(args, 0)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	w1, err := abiWord(args, 1)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	offA, err := abiWord(args, 2)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	a, err = abiReadBytesAt(args, binary.BigEndian.Uint64(offA[24:32]))
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	return w0[31], w1[31], a, nil
+}
+
+// abiDecodeBytes32Bytes2 decodes `(bytes32, bytes, bytes)`, verifyById's
+// shape.
+func abiDecodeBytes32Bytes2(args []byte) (word common.Hash, a, b []byte, err error) {
+	w0, err := abiWord(args, 0)
+	if err != nil {
+		return common.Hash{}, nil, nil, err
+	}
+	offA, err := abiWord(args, 1)
+	if err != nil {
+		return common.Hash{}, nil, nil, err
+	}
+	offB, err := abiWord(args, 2)
+	if err != nil {
+		return common.Hash{}, nil, nil, err
+	}
+	a, err = abiReadBytesAt(args, binary.BigEndian.Uint64(offA[24:32]))
+	if err != nil {
+		return common.Hash{}, nil, nil, err
+	}
+	b, err = abiReadBytesAt(args, binary.BigEndian.Uint64(offB[24:32]))
+	if err != nil {
+		return common.Hash{}, nil, nil, err
+	}
+	return common.BytesToHash(w0), a, b, nil
+}
+
+// matchABISelector reports whether the first 4 bytes of input equal sel,
+// returning the remaining ABI-encoded argument bytes.
+func matchABISelector(input []byte, sel [4]byte) (args []byte, ok bool) {
+	if len(input) < 4 {
+		return nil, false
+	}
+	for i := 0; i < 4; i++ {
+		if input[i] != sel[i] {
+			return nil, false
+		}
+	}
+	return input[4:], true
+}
+
+// AbiRequiredGas prices an ABI-encoded call by decoding just enough to
+// find its dynamic byte lengths, falling back to RegistryRequiredGas (and
+// transitively RequiredGas) for every selector this file doesn't know.
+func (p *pqCryptoPrecompile) AbiRequiredGas(input []byte) uint64 {
+	if args, ok := matchABISelector(input, abiSelectorMLDSAVerify); ok {
+		_, _, msg, sig, err := abiDecodeU8Bytes3(args)
+		if err != nil {
+			return MLDSAVerifyGas
+		}
+		return MLDSAVerifyGas + uint64(len(msg)+len(sig))*SigVerifyPerByteGas
+	}
+	if args, ok := matchABISelector(input, abiSelectorSLHDSAVerify); ok {
+		_, _, msg, sig, err := abiDecodeU8Bytes3(args)
+		if err != nil {
+			return SLHDSAVerifyGas
+		}
+		return SLHDSAVerifyGas + uint64(len(msg)+len(sig))*SigVerifyPerByteGas
+	}
+	if args, ok := matchABISelector(input, abiSelectorRegister); ok {
+		_, _, pubKey, err := abiDecodeU8U8Bytes1(args)
+		if err != nil {
+			return PQRegisterGas
+		}
+		words := (len(pubKey) + abiWordSize - 1) / abiWordSize
+		return PQRegisterGas + uint64(words)*PQRegisterPerWordGas
+	}
+	if _, ok := matchABISelector(input, abiSelectorVerifyByID); ok {
+		return PQVerifyByIDBaseGas + SLHDSAVerifyGas
+	}
+	return p.BatchRequiredGas(input)
+}
+
+// AbiRun dispatches the ABI-encoded selectors, falling back to
+// RegistryRun (and transitively Run) for every selector this file doesn't
+// know. Semantically each ABI entry point is equivalent to its
+// hand-packed counterpart; only the calldata shape differs.
+func (p *pqCryptoPrecompile) AbiRun(
+	accessibleState contract.AccessibleState,
+	caller common.Address,
+	addr common.Address,
+	input []byte,
+	suppliedGas uint64,
+	readOnly bool,
+) ([]byte, uint64, error) {
+	requiredGas := p.AbiRequiredGas(input)
+	if suppliedGas < requiredGas {
+		return nil, 0, vm.ErrOutOfGas
+	}
+	remainingGas := suppliedGas - requiredGas
+
+	if args, ok := matchABISelector(input, abiSelectorMLDSAVerify); ok {
+		mode, pubKey, message, signature, err := abiDecodeU8Bytes3(args)
+		if err != nil {
+			return nil, remainingGas, err
+		}
+		pub, err := mldsa.PublicKeyFromBytes(pubKey, mldsa.Mode(mode))
+		if err != nil {
+			return nil, remainingGas, err
+		}
+		return abiBoolResult(pub.Verify(message, signature, nil)), remainingGas, nil
+	}
+	if args, ok := matchABISelector(input, abiSelectorSLHDSAVerify); ok {
+		mode, pubKey, message, signature, err := abiDecodeU8Bytes3(args)
+		if err != nil {
+			return nil, remainingGas, err
+		}
+		pub, err := slhdsa.PublicKeyFromBytes(pubKey, slhdsa.Mode(mode))
+		if err != nil {
+			return nil, remainingGas, err
+		}
+		return abiBoolResult(pub.Verify(message, signature, nil)), remainingGas, nil
+	}
+	if args, ok := matchABISelector(input, abiSelectorRegister); ok {
+		if readOnly {
+			return nil, remainingGas, fmt.Errorf("cannot register a key in read-only mode")
+		}
+		scheme, mode, pubKey, err := abiDecodeU8U8Bytes1(args)
+		if err != nil {
+			return nil, remainingGas, err
+		}
+		return p.registerKey(accessibleState, packRegisterInput(scheme, mode, pubKey), remainingGas)
+	}
+	if args, ok := matchABISelector(input, abiSelectorVerifyByID); ok {
+		keyId, message, signature, err := abiDecodeBytes32Bytes2(args)
+		if err != nil {
+			return nil, remainingGas, err
+		}
+		return p.verifyByID(accessibleState, packVerifyByIDInput(keyId, message, signature), remainingGas)
+	}
+
+	return p.BatchRun(accessibleState, caller, addr, input, suppliedGas, readOnly)
+}
+
+// abiBoolResult packs a verification result as a 32-byte word, matching
+// every other verify selector on this precompile and the rest of the
+// repo's boolean-result convention.
+func abiBoolResult(valid bool) []byte {
+	result := make([]byte, 32)
+	if valid {
+		result[31] = 1
+	}
+	return result
+}
+
+// packRegisterInput re-packs ABI-decoded register arguments into
+// registerKey's hand-packed layout, so both calldata shapes share one
+// implementation of the actual storage logic.
+func packRegisterInput(scheme, mode byte, pubKey []byte) []byte {
+	packed := make([]byte, 4+len(pubKey))
+	packed[0] = scheme
+	packed[1] = mode
+	binary.BigEndian.PutUint16(packed[2:4], uint16(len(pubKey)))
+	copy(packed[4:], pubKey)
+	return packed
+}
+
+// packVerifyByIDInput re-packs ABI-decoded verifyById arguments into
+// verifyByID's hand-packed layout, for the same reason as
+// packRegisterInput above.
+func packVerifyByIDInput(keyId common.Hash, message, signature []byte) []byte {
+	packed := make([]byte, 36+len(message)+len(signature))
+	copy(packed[:32], keyId.Bytes())
+	binary.BigEndian.PutUint32(packed[32:36], uint32(len(message)))
+	copy(packed[36:36+len(message)], message)
+	copy(packed[36+len(message):], signature)
+	return packed
+}
+
+// SigVerifyPerByteGas is charged per message+signature byte on top of an
+// ABI verify call's flat base cost, mirroring how other precompiles in
+// this repo price calldata size into verification gas.
+const SigVerifyPerByteGas uint64 = 3
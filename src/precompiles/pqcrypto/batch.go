@@ -0,0 +1,222 @@
+// Copyright (C) 2025, Lux Industries Inc All rights reserved.
+// Batched ML-DSA/SLH-DSA verification: amortizes decoding overhead across N
+// independent signatures in one call instead of N separate ones.
+
+package pqcrypto
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/luxfi/crypto/mldsa"
+	"github.com/luxfi/crypto/slhdsa"
+	"github.com/luxfi/evm/precompile/contract"
+	"github.com/luxfi/geth/common"
+	"github.com/luxfi/geth/core/vm"
+)
+
+const (
+	// PQBatchVerifySelector verifies every entry in the batch and returns a
+	// bitmap so the caller can see exactly which entries failed.
+	PQBatchVerifySelector = "pqbv_batch_verify"
+
+	// PQBatchVerifyStrictSelector runs the same batch but reverts the
+	// whole call if any entry fails, for callers (e.g. light-client
+	// header verification) that only ever want all-or-nothing semantics
+	// and would otherwise have to inspect the bitmap themselves.
+	PQBatchVerifyStrictSelector = "pqbs_batch_verify_strict"
+
+	PQBatchBaseGas uint64 = 5_000
+
+	// PQBatchPerByteGas covers the amortized cost of decoding each entry's
+	// pubkey/signature/message out of the packed batch format.
+	PQBatchPerByteGas uint64 = 1
+
+	// maxPQBatchEntries bounds a batch to what a little-endian, one-byte-
+	// per-8-entries bitmap can name in 32 bytes.
+	maxPQBatchEntries = 256
+)
+
+var errPQBatchTooLarge = fmt.Errorf("pqcrypto: batch verify entry count exceeds %d", maxPQBatchEntries)
+
+// pqBatchEntry is one parsed (alg, mode, pubkey, signature, message) tuple
+// from a batch verify call.
+type pqBatchEntry struct {
+	alg       RegistryScheme
+	mode      byte
+	pubKey    []byte
+	signature []byte
+	message   []byte
+}
+
+// parsePQBatch parses the packed `[count(2)] {entry}*count` format shared
+// by PQBatchVerifySelector and PQBatchVerifyStrictSelector, where each
+// entry is `[alg(1)] [mode(1)] [pubkey_len(2)] [pubkey] [sig_len(3)] [sig]
+// [msg_len(3)] [msg]`. It also returns the total number of pubkey+sig+msg
+// bytes across all entries, for gas pricing.
+func parsePQBatch(input []byte) (entries []pqBatchEntry, totalBytes uint64, err error) {
+	if len(input) < 2 {
+		return nil, 0, errInvalidInput
+	}
+	count := int(binary.BigEndian.Uint16(input[:2]))
+	if count > maxPQBatchEntries {
+		return nil, 0, errPQBatchTooLarge
+	}
+
+	entries = make([]pqBatchEntry, count)
+	offset := 2
+	for i := 0; i < count; i++ {
+		if len(input) < offset+4 {
+			return nil, 0, errInvalidInput
+		}
+		alg := RegistryScheme(input[offset])
+		mode := input[offset+1]
+		pubKeyLen := int(binary.BigEndian.Uint16(input[offset+2 : offset+4]))
+		offset += 4
+		if len(input) < offset+pubKeyLen+3 {
+			return nil, 0, errInvalidInput
+		}
+		pubKey := input[offset : offset+pubKeyLen]
+		offset += pubKeyLen
+
+		sigLen := int(input[offset])<<16 | int(input[offset+1])<<8 | int(input[offset+2])
+		offset += 3
+		if len(input) < offset+sigLen+3 {
+			return nil, 0, errInvalidInput
+		}
+		sig := input[offset : offset+sigLen]
+		offset += sigLen
+
+		msgLen := int(input[offset])<<16 | int(input[offset+1])<<8 | int(input[offset+2])
+		offset += 3
+		if len(input) < offset+msgLen {
+			return nil, 0, errInvalidInput
+		}
+		msg := input[offset : offset+msgLen]
+		offset += msgLen
+
+		entries[i] = pqBatchEntry{alg: alg, mode: mode, pubKey: pubKey, signature: sig, message: msg}
+		totalBytes += uint64(pubKeyLen + sigLen + msgLen)
+	}
+	if offset != len(input) {
+		return nil, 0, errInvalidInput
+	}
+	return entries, totalBytes, nil
+}
+
+// pqBatchEntryGas is a single entry's own full verify cost: the repo has no
+// access to the ML-DSA/SLH-DSA libraries' internal lattice/hash-tree
+// representations, so unlike the request's "random linear combination"
+// batching trick, this precompile still runs one real Verify per entry
+// (see verifyPQBatch) and is priced accordingly rather than claiming an
+// unearned discount -- the same honest-fallback approach already taken by
+// quasar's RingtailBatchPerSigGas (see ringtail_batch.go).
+func pqBatchEntryGas(alg RegistryScheme) uint64 {
+	switch alg {
+	case RegistrySchemeMLDSA:
+		return MLDSAVerifyGas
+	case RegistrySchemeSLHDSA:
+		return SLHDSAVerifyGas
+	default:
+		// Unrecognized alg byte: price at the more expensive scheme so
+		// RequiredGas, which cannot itself reject the call, never
+		// undercharges it.
+		return SLHDSAVerifyGas
+	}
+}
+
+func pqBatchRequiredGas(input []byte) uint64 {
+	entries, totalBytes, err := parsePQBatch(input)
+	if err != nil {
+		return PQBatchBaseGas
+	}
+	gas := PQBatchBaseGas + totalBytes*PQBatchPerByteGas
+	for _, e := range entries {
+		gas += pqBatchEntryGas(e.alg)
+	}
+	return gas
+}
+
+// BatchRequiredGas extends PrehashRequiredGas with the two batch-verify
+// selectors, falling back to PrehashRequiredGas (and transitively
+// RegistryRequiredGas/ExtendedRequiredGas/RequiredGas) for everything else.
+func (p *pqCryptoPrecompile) BatchRequiredGas(input []byte) uint64 {
+	if len(input) < 4 {
+		return 0
+	}
+	selector := string(input[:4])
+	data := input[4:]
+
+	switch selector {
+	case PQBatchVerifySelector[:4], PQBatchVerifyStrictSelector[:4]:
+		return pqBatchRequiredGas(data)
+	default:
+		return p.PrehashRequiredGas(input)
+	}
+}
+
+// BatchRun extends PrehashRun with the two batch-verify selectors, falling
+// back to PrehashRun (and transitively RegistryRun/ExtendedRun/Run) for
+// everything else.
+func (p *pqCryptoPrecompile) BatchRun(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if len(input) < 4 {
+		return nil, suppliedGas, errInvalidInput
+	}
+	selector := string(input[:4])
+	switch selector {
+	case PQBatchVerifySelector[:4], PQBatchVerifyStrictSelector[:4]:
+	default:
+		return p.PrehashRun(accessibleState, caller, addr, input, suppliedGas, readOnly)
+	}
+
+	requiredGas := p.BatchRequiredGas(input)
+	if suppliedGas < requiredGas {
+		return nil, 0, vm.ErrOutOfGas
+	}
+	remainingGas = suppliedGas - requiredGas
+	data := input[4:]
+
+	entries, _, err := parsePQBatch(data)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	bitmap, allValid := verifyPQBatch(entries)
+	if selector == PQBatchVerifyStrictSelector[:4] && !allValid {
+		return nil, remainingGas, fmt.Errorf("pqcrypto: batch verify failed: not every signature was valid")
+	}
+	return bitmap, remainingGas, nil
+}
+
+// verifyPQBatch independently verifies every entry and packs the results
+// into a little-endian bitmap: bit i of byte i/8 (bit 0 the byte's
+// low-order bit) is set when entry i verified. This is the inverse
+// polarity of quasar's ringtail_batch.go, which records a failure bitmap;
+// this precompile reports "valid/invalid" per the originating request, so
+// an all-1s bitmap (not all-0s) means every signature checked out.
+func verifyPQBatch(entries []pqBatchEntry) (bitmap []byte, allValid bool) {
+	bitmap = make([]byte, (len(entries)+7)/8)
+	allValid = true
+	for i, e := range entries {
+		valid := verifyPQBatchEntry(e)
+		if valid {
+			bitmap[i/8] |= 1 << uint(i%8)
+		} else {
+			allValid = false
+		}
+	}
+	return bitmap, allValid
+}
+
+func verifyPQBatchEntry(e pqBatchEntry) bool {
+	switch e.alg {
+	case RegistrySchemeMLDSA:
+		pubKey, err := mldsa.PublicKeyFromBytes(e.pubKey, mldsa.Mode(e.mode))
+		return err == nil && pubKey.Verify(e.message, e.signature, nil)
+	case RegistrySchemeSLHDSA:
+		pubKey, err := slhdsa.PublicKeyFromBytes(e.pubKey, slhdsa.Mode(e.mode))
+		return err == nil && pubKey.Verify(e.message, e.signature, nil)
+	default:
+		return false
+	}
+}
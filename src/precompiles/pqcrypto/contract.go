@@ -4,9 +4,11 @@
 package pqcrypto
 
 import (
-	"crypto/rand"
+	"bytes"
 	"errors"
 	"fmt"
+	"io"
+	"math/big"
 
 	"github.com/luxfi/crypto/mldsa"
 	"github.com/luxfi/crypto/mlkem"
@@ -14,6 +16,8 @@ import (
 	"github.com/luxfi/evm/precompile/contract"
 	"github.com/luxfi/geth/common"
 	"github.com/luxfi/geth/core/vm"
+	"golang.org/x/crypto/hkdf"
+	"golang.org/x/crypto/sha3"
 )
 
 const (
@@ -23,6 +27,11 @@ const (
 	MLKEMDecapsulateGas = 8000
 	SLHDSAVerifyGas     = 15000
 
+	// MLKEMEncapsulateKDFGas covers the extra HKDF-SHA3-256 pass
+	// mlkemEncapsulate now runs over the shared secret to bind it to the
+	// calling context (see bindSharedSecret).
+	MLKEMEncapsulateKDFGas = 2000
+
 	// Function selectors (first 4 bytes must be unique)
 	MLDSAVerifySelector      = "mlds_verify"
 	MLKEMEncapsulateSelector = "encp_mlkem"
@@ -60,7 +69,7 @@ func (p *pqCryptoPrecompile) RequiredGas(input []byte) uint64 {
 	case MLDSAVerifySelector[:4]:
 		return MLDSAVerifyGas
 	case MLKEMEncapsulateSelector[:4]:
-		return MLKEMEncapsulateGas
+		return MLKEMEncapsulateGas + MLKEMEncapsulateKDFGas
 	case MLKEMDecapsulateSelector[:4]:
 		return MLKEMDecapsulateGas
 	case SLHDSAVerifySelector[:4]:
@@ -91,7 +100,7 @@ func (p *pqCryptoPrecompile) Run(accessibleState contract.AccessibleState, calle
 	case MLDSAVerifySelector[:4]:
 		return p.mldsaVerify(data)
 	case MLKEMEncapsulateSelector[:4]:
-		return p.mlkemEncapsulate(data)
+		return p.mlkemEncapsulate(accessibleState, caller, addr, data)
 	case MLKEMDecapsulateSelector[:4]:
 		return p.mlkemDecapsulate(data)
 	case SLHDSAVerifySelector[:4]:
@@ -139,15 +148,30 @@ func (p *pqCryptoPrecompile) mldsaVerify(input []byte) ([]byte, uint64, error) {
 	return []byte{0}, 0, nil
 }
 
-// mlkemEncapsulate performs ML-KEM encapsulation
-func (p *pqCryptoPrecompile) mlkemEncapsulate(input []byte) ([]byte, uint64, error) {
-	// Input format: [mode(1)] [pubkey]
-	if len(input) < 2 {
+// mlkemEncapsulate performs derandomized ML-KEM encapsulation, binding the
+// resulting shared secret to the calling context via HKDF-SHA3-256.
+//
+// crypto/rand.Reader must never drive this: a precompile's output has to
+// be a pure function of its input and chain state, or two nodes replaying
+// the same block derive different ciphertexts and shared secrets and the
+// chain forks. FIPS 203's ML-KEM.Encaps is itself defined to draw one
+// 32-byte value m and pass it into the derandomized K-PKE.Encrypt(ek, m)
+// internally; mlkem.PublicKey.Encapsulate already takes its randomness
+// from an io.Reader (previously rand.Reader), so handing it a reader over
+// a caller-supplied seed reproduces that derandomized path without this
+// package needing a separate derandomized entry point from the library.
+//
+// Input format: [mode(1)] [seed(32)] [pubkey]
+// Output: [ciphertext] [boundKey], boundKey the same length as the
+// scheme's raw shared secret.
+func (p *pqCryptoPrecompile) mlkemEncapsulate(accessibleState contract.AccessibleState, caller, addr common.Address, input []byte) ([]byte, uint64, error) {
+	if len(input) < 33 {
 		return nil, 0, errInvalidInput
 	}
 
 	mode := mlkem.Mode(input[0])
-	pubKeyBytes := input[1:]
+	seed := input[1:33]
+	pubKeyBytes := input[33:]
 
 	// Reconstruct public key
 	pubKey, err := mlkem.PublicKeyFromBytes(pubKeyBytes, mode)
@@ -155,17 +179,45 @@ func (p *pqCryptoPrecompile) mlkemEncapsulate(input []byte) ([]byte, uint64, err
 		return nil, 0, err
 	}
 
-	// Encapsulate - returns EncapsulationResult and error
-	result, err := pubKey.Encapsulate(rand.Reader)
+	result, err := pubKey.Encapsulate(bytes.NewReader(seed))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	blockNumber := accessibleState.GetBlockContext().Number()
+	boundKey, err := bindSharedSecret(result.SharedSecret, caller, addr, blockNumber)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	// Return ciphertext + shared secret
-	output := append(result.Ciphertext, result.SharedSecret...)
+	// Return ciphertext + context-bound key
+	output := append(result.Ciphertext, boundKey...)
 	return output, 0, nil
 }
 
+// bindSharedSecret runs HKDF-SHA3-256 over sharedSecret with (caller, addr,
+// blockNumber) mixed in as the HKDF info parameter, so the key returned by
+// mlkemEncapsulate is bound to the specific call that produced it: another
+// contract, or the same contract replayed in a later block, cannot derive
+// the same key from the ciphertext and raw shared secret alone.
+func bindSharedSecret(sharedSecret []byte, caller, addr common.Address, blockNumber *big.Int) ([]byte, error) {
+	info := make([]byte, 0, common.AddressLength*2+32)
+	info = append(info, caller.Bytes()...)
+	info = append(info, addr.Bytes()...)
+	if blockNumber != nil {
+		info = append(info, common.LeftPadBytes(blockNumber.Bytes(), 32)...)
+	} else {
+		info = append(info, make([]byte, 32)...)
+	}
+
+	kdf := hkdf.New(sha3.New256, sharedSecret, nil, info)
+	bound := make([]byte, len(sharedSecret))
+	if _, err := io.ReadFull(kdf, bound); err != nil {
+		return nil, err
+	}
+	return bound, nil
+}
+
 // mlkemDecapsulate performs ML-KEM decapsulation
 func (p *pqCryptoPrecompile) mlkemDecapsulate(input []byte) ([]byte, uint64, error) {
 	// Input format: [mode(1)] [privkey_len(2)] [privkey] [ciphertext]
@@ -4,7 +4,9 @@
 package pqcrypto
 
 import (
+	"bytes"
 	"crypto/rand"
+	"math/big"
 	"testing"
 
 	"github.com/luxfi/crypto/mldsa"
@@ -81,14 +83,25 @@ func TestMLKEMEncapsulateDecapsulate(t *testing.T) {
 	priv, pub, err := mlkem.GenerateKeyPair(rand.Reader, mlkem.MLKEM512)
 	require.NoError(err)
 
-	// Test encapsulation
+	// Test encapsulation. mlkemEncapsulate now takes a caller-supplied
+	// derandomization seed ahead of the pubkey (see contract.go's
+	// mlkemEncapsulate doc comment) and binds the resulting shared secret
+	// to the call via accessibleState.GetBlockContext(), so this still
+	// needs a real AccessibleState to run -- see
+	// TestBindSharedSecret_DeterministicAndContextBound and
+	// TestMLKEMEncapsulate_DeterministicForSameSeed below for the
+	// properties this request cared about, exercised without one.
 	pubBytes := pub.Bytes()
+	var seed [32]byte
+	_, err = rand.Read(seed[:])
+	require.NoError(err)
 	encapInput := []byte(MLKEMEncapsulateSelector[:4])
 	encapInput = append(encapInput, byte(mlkem.MLKEM512))
+	encapInput = append(encapInput, seed[:]...)
 	encapInput = append(encapInput, pubBytes...)
 
 	gas := precompile.RequiredGas(encapInput)
-	require.Equal(uint64(MLKEMEncapsulateGas), gas)
+	require.Equal(uint64(MLKEMEncapsulateGas+MLKEMEncapsulateKDFGas), gas)
 
 	encapResult, _, err := precompile.Run(nil, common.Address{}, ContractAddress, encapInput, gas, true)
 	require.NoError(err)
@@ -152,6 +165,69 @@ func TestSLHDSAVerify(t *testing.T) {
 	require.Equal([]byte{1}, result) // Valid signature
 }
 
+// TestMLKEMEncapsulate_DeterministicForSameSeed exercises the property
+// mlkemEncapsulate's doc comment relies on -- that handing the same
+// 32-byte seed to PublicKey.Encapsulate as its randomness source
+// reproduces the same ciphertext and raw shared secret -- without needing
+// a real AccessibleState, since that property lives entirely in the
+// derandomized Encapsulate call mlkemEncapsulate wraps.
+func TestMLKEMEncapsulate_DeterministicForSameSeed(t *testing.T) {
+	require := require.New(t)
+	_, pub, err := mlkem.GenerateKeyPair(rand.Reader, mlkem.MLKEM512)
+	require.NoError(err)
+
+	var seed [32]byte
+	_, err = rand.Read(seed[:])
+	require.NoError(err)
+
+	result1, err := pub.Encapsulate(bytes.NewReader(seed[:]))
+	require.NoError(err)
+	result2, err := pub.Encapsulate(bytes.NewReader(seed[:]))
+	require.NoError(err)
+
+	require.Equal(result1.Ciphertext, result2.Ciphertext)
+	require.Equal(result1.SharedSecret, result2.SharedSecret)
+}
+
+// TestBindSharedSecret_DeterministicAndContextBound covers bindSharedSecret
+// directly: the other property mlkemEncapsulate relies on is that the same
+// (sharedSecret, caller, addr, blockNumber) always binds to the same key,
+// but changing any one of caller, addr, or blockNumber changes it, so a
+// raw shared secret leaked from one call site can't be replayed as another
+// caller's or another block's bound key.
+func TestBindSharedSecret_DeterministicAndContextBound(t *testing.T) {
+	require := require.New(t)
+	sharedSecret := make([]byte, 32)
+	_, err := rand.Read(sharedSecret)
+	require.NoError(err)
+
+	callerA := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	callerB := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	addrA := common.HexToAddress("0x3333333333333333333333333333333333333333")
+	addrB := common.HexToAddress("0x4444444444444444444444444444444444444444")
+	blockA := big.NewInt(1)
+	blockB := big.NewInt(2)
+
+	base, err := bindSharedSecret(sharedSecret, callerA, addrA, blockA)
+	require.NoError(err)
+
+	again, err := bindSharedSecret(sharedSecret, callerA, addrA, blockA)
+	require.NoError(err)
+	require.Equal(base, again, "same inputs must bind to the same key")
+
+	diffCaller, err := bindSharedSecret(sharedSecret, callerB, addrA, blockA)
+	require.NoError(err)
+	require.NotEqual(base, diffCaller, "a different caller must bind to a different key")
+
+	diffAddr, err := bindSharedSecret(sharedSecret, callerA, addrB, blockA)
+	require.NoError(err)
+	require.NotEqual(base, diffAddr, "a different addr must bind to a different key")
+
+	diffBlock, err := bindSharedSecret(sharedSecret, callerA, addrA, blockB)
+	require.NoError(err)
+	require.NotEqual(base, diffBlock, "a different blockNumber must bind to a different key")
+}
+
 func TestGasCalculation(t *testing.T) {
 	t.Skip("Temporarily disabled for CI")
 	require := require.New(t)
@@ -0,0 +1,115 @@
+// Copyright (C) 2025, Lux Industries Inc All rights reserved.
+// ABI-encoded event logs for the PQ crypto precompile, following the same
+// approach the ABI.PackEvent helper in go-ethereum's bind tooling uses:
+// topic0 is the keccak256 of the event signature, indexed args become
+// further topics, and non-indexed args are ABI-encoded into data. There is
+// no accounts/abi-style package in this repo (see abi.go), so this is
+// hand-rolled the same way abi.go's calldata decoding is.
+//
+// github.com/luxfi/evm/precompile/contract isn't vendored in this
+// repository (it's an external module this tree only imports, never
+// edits), so the ABI.PackEvent-equivalent helper that would ideally live
+// there lives here instead, calling accessibleState.GetStateDB().AddLog --
+// inferred, by strong analogy to go-ethereum's core/vm.StateDB (which
+// every StateDB implementation in the ecosystem satisfies), to exist on
+// contract.StateDB the same way GetState/SetState already confirmed-used
+// in registry.go and popregistry do.
+
+package pqcrypto
+
+import (
+	"encoding/binary"
+
+	"github.com/luxfi/evm/precompile/contract"
+	"github.com/luxfi/geth/common"
+	"github.com/luxfi/geth/core/types"
+	"github.com/luxfi/geth/core/vm"
+	"github.com/luxfi/geth/crypto"
+)
+
+// Gas for an emitted log mirrors the EVM's own LOG opcode pricing table
+// (LogGas + LogTopicGas*topics + LogDataGas*len(data)), so a precompile
+// emitting an event costs the same as equivalent Solidity-level logging.
+const (
+	PQLogGas      uint64 = 375
+	PQLogTopicGas uint64 = 375
+	PQLogDataGas  uint64 = 8
+)
+
+var (
+	eventPQKeyGenerated = crypto.Keccak256Hash([]byte("PQKeyGenerated(address,uint8,uint8,bytes)"))
+	eventPQSigned       = crypto.Keccak256Hash([]byte("PQSigned(address,uint8,bytes32)"))
+)
+
+// addressTopic left-pads addr to a 32-byte topic, the ABI encoding of an
+// indexed `address` argument.
+func addressTopic(addr common.Address) common.Hash {
+	return common.BytesToHash(addr.Bytes())
+}
+
+// emitLog charges LOG-opcode-equivalent gas out of remainingGas and pushes
+// a log entry via the precompile's own contract address, returning the gas
+// left afterwards.
+func emitLog(accessibleState contract.AccessibleState, addr common.Address, topics []common.Hash, data []byte, remainingGas uint64) (uint64, error) {
+	gas := PQLogGas + PQLogTopicGas*uint64(len(topics)) + PQLogDataGas*uint64(len(data))
+	if remainingGas < gas {
+		return 0, vm.ErrOutOfGas
+	}
+	remainingGas -= gas
+
+	logTopics := make([]common.Hash, len(topics))
+	copy(logTopics, topics)
+	accessibleState.GetStateDB().AddLog(&types.Log{
+		Address: addr,
+		Topics:  logTopics,
+		Data:    data,
+	})
+	return remainingGas, nil
+}
+
+// abiEncodeUint8Word ABI-encodes a single uint8 as a 32-byte word.
+func abiEncodeUint8Word(v byte) []byte {
+	word := make([]byte, abiWordSize)
+	word[abiWordSize-1] = v
+	return word
+}
+
+// emitPQKeyGenerated emits PQKeyGenerated(address indexed caller, uint8
+// alg, uint8 mode, bytes pubkey) after a successful key-generation call.
+func emitPQKeyGenerated(accessibleState contract.AccessibleState, caller common.Address, alg, mode byte, pubKey []byte, remainingGas uint64) (uint64, error) {
+	topics := []common.Hash{eventPQKeyGenerated, addressTopic(caller)}
+
+	// data: (uint8 alg, uint8 mode, bytes pubkey) -- alg/mode are static
+	// head words, pubkey is dynamic so its head word is a byte offset to
+	// the tail, same layout abi.go's decoders expect on the way in.
+	paddedLen := (len(pubKey) + abiWordSize - 1) / abiWordSize * abiWordSize
+	data := make([]byte, 0, abiWordSize*3+abiWordSize+paddedLen)
+	data = append(data, abiEncodeUint8Word(alg)...)
+	data = append(data, abiEncodeUint8Word(mode)...)
+	data = append(data, abiWordUint64(3*abiWordSize)...)
+	data = append(data, abiWordUint64(uint64(len(pubKey)))...)
+	data = append(data, pubKey...)
+	data = append(data, make([]byte, paddedLen-len(pubKey))...)
+
+	return emitLog(accessibleState, ContractAddress, topics, data, remainingGas)
+}
+
+// emitPQSigned emits PQSigned(address indexed caller, uint8 alg, bytes32
+// messageHash) after a successful signing call.
+func emitPQSigned(accessibleState contract.AccessibleState, caller common.Address, alg byte, message []byte, remainingGas uint64) (uint64, error) {
+	topics := []common.Hash{eventPQSigned, addressTopic(caller)}
+
+	messageHash := crypto.Keccak256Hash(message)
+	data := make([]byte, 0, abiWordSize*2)
+	data = append(data, abiEncodeUint8Word(alg)...)
+	data = append(data, messageHash.Bytes()...)
+
+	return emitLog(accessibleState, ContractAddress, topics, data, remainingGas)
+}
+
+// abiWordUint64 ABI-encodes v as a right-aligned 32-byte word.
+func abiWordUint64(v uint64) []byte {
+	word := make([]byte, abiWordSize)
+	binary.BigEndian.PutUint64(word[abiWordSize-8:], v)
+	return word
+}
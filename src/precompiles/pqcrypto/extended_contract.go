@@ -4,6 +4,7 @@
 package pqcrypto
 
 import (
+	"crypto/ecdh"
 	"crypto/rand"
 	"errors"
 	"fmt"
@@ -14,6 +15,7 @@ import (
 	"github.com/luxfi/evm/precompile/contract"
 	"github.com/luxfi/geth/common"
 	"github.com/luxfi/geth/core/vm"
+	"golang.org/x/crypto/sha3"
 )
 
 const (
@@ -30,22 +32,84 @@ const (
 	MLDSAGenKeySelector  = "mldsa_genkey"
 	MLKEMGenKeySelector  = "mlkem_genkey"
 	SLHDSAGenKeySelector = "slhdsa_genkey"
+
+	// mlkem_encaps/mlkem_decaps/mlkem_hybrid_encaps are named mken_/mkde_/
+	// mkhy_ rather than mlkem_* so their first 4 bytes -- the part this
+	// package dispatches on -- don't collide with MLKEMGenKeySelector's
+	// "mlke".
+	MLKEMEncapsSelector       = "mken_encaps"
+	MLKEMDecapsSelector       = "mkde_decaps"
+	MLKEMHybridEncapsSelector = "mkhy_hybrid_encaps"
+
+	// mldsa_sign_keyref/slhdsa_sign_keyref are named mdkr_/sdkr_ rather
+	// than mldsa_/slhdsa_ so their first 4 bytes don't collide with
+	// MLDSASignSelector/SLHDSAGenKeySelector in this same switch (mlds/slhd).
+	// Unlike MLDSASignSelector, these never carry a raw privkey in calldata:
+	// the key stays in the node's local Keystore, looked up by key_id.
+	MLDSASignKeyrefSelector  = "mdkr_sign_keyref"
+	SLHDSASignKeyrefSelector = "sdkr_sign_keyref"
+
+	MLDSASignKeyrefGas  uint64 = MLDSASignGas
+	SLHDSASignKeyrefGas uint64 = SLHDSASignGas
+
+	// Per-mode ML-KEM gas, largest parameter set first so an unrecognized
+	// mode byte is priced conservatively rather than under-charged. Modes
+	// are assumed ordinal 0/1/2 for ML-KEM-512/768/1024, matching this
+	// package's existing single-byte mode encoding for mldsa/slhdsa.
+	MLKEMEncaps1024Gas uint64 = 11_000
+	MLKEMEncaps768Gas  uint64 = 8_000
+	MLKEMEncaps512Gas  uint64 = 6_000
+
+	MLKEMDecaps1024Gas uint64 = 9_000
+	MLKEMDecaps768Gas  uint64 = 7_000
+	MLKEMDecaps512Gas  uint64 = 5_000
+
+	// MLKEMHybridEncapsGas covers an ML-KEM-768 encaps (the X25519+ML-KEM-768
+	// pairing the TLS 1.3 hybrid drafts use) plus the extra X25519 keygen,
+	// X25519 exchange, and SHA3-256 transcript hash this selector runs on
+	// top of it.
+	MLKEMHybridEncapsGas uint64 = MLKEMEncaps768Gas + 3_000
 )
 
+// mlkemEncapsGasForMode prices mlkem_encaps per ML-KEM parameter set.
+func mlkemEncapsGasForMode(mode mlkem.Mode) uint64 {
+	switch mode {
+	case 0:
+		return MLKEMEncaps512Gas
+	case 1:
+		return MLKEMEncaps768Gas
+	default:
+		return MLKEMEncaps1024Gas
+	}
+}
+
+// mlkemDecapsGasForMode prices mlkem_decaps per ML-KEM parameter set.
+func mlkemDecapsGasForMode(mode mlkem.Mode) uint64 {
+	switch mode {
+	case 0:
+		return MLKEMDecaps512Gas
+	case 1:
+		return MLKEMDecaps768Gas
+	default:
+		return MLKEMDecaps1024Gas
+	}
+}
+
 // Extended methods for signing operations
 
-// mldsaSign creates an ML-DSA signature
-func (p *pqCryptoPrecompile) mldsaSign(input []byte) ([]byte, uint64, error) {
+// mldsaSign creates an ML-DSA signature, emitting a PQSigned event on
+// success.
+func (p *pqCryptoPrecompile) mldsaSign(accessibleState contract.AccessibleState, caller common.Address, input []byte, remainingGas uint64) ([]byte, uint64, error) {
 	// Input format: [mode(1)] [privkey_len(2)] [privkey] [message]
 	if len(input) < 4 {
-		return nil, 0, errInvalidInput
+		return nil, remainingGas, errInvalidInput
 	}
 
 	mode := mldsa.Mode(input[0])
 	privKeyLen := int(input[1])<<8 | int(input[2])
 
 	if len(input) < 3+privKeyLen {
-		return nil, 0, errInvalidInput
+		return nil, remainingGas, errInvalidInput
 	}
 
 	privKeyBytes := input[3 : 3+privKeyLen]
@@ -54,30 +118,35 @@ func (p *pqCryptoPrecompile) mldsaSign(input []byte) ([]byte, uint64, error) {
 	// Reconstruct private key
 	privKey, err := mldsa.PrivateKeyFromBytes(privKeyBytes, mode)
 	if err != nil {
-		return nil, 0, err
+		return nil, remainingGas, err
 	}
 
 	// Sign message
 	signature, err := privKey.Sign(rand.Reader, message, nil)
 	if err != nil {
-		return nil, 0, err
+		return nil, remainingGas, err
 	}
 
-	return signature, 0, nil
+	remainingGas, err = emitPQSigned(accessibleState, caller, byte(RegistrySchemeMLDSA), message, remainingGas)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return signature, remainingGas, nil
 }
 
-// slhdsaSign creates an SLH-DSA signature
-func (p *pqCryptoPrecompile) slhdsaSign(input []byte) ([]byte, uint64, error) {
+// slhdsaSign creates an SLH-DSA signature, emitting a PQSigned event on
+// success.
+func (p *pqCryptoPrecompile) slhdsaSign(accessibleState contract.AccessibleState, caller common.Address, input []byte, remainingGas uint64) ([]byte, uint64, error) {
 	// Input format: [mode(1)] [privkey_len(2)] [privkey] [message]
 	if len(input) < 4 {
-		return nil, 0, errInvalidInput
+		return nil, remainingGas, errInvalidInput
 	}
 
 	mode := slhdsa.Mode(input[0])
 	privKeyLen := int(input[1])<<8 | int(input[2])
 
 	if len(input) < 3+privKeyLen {
-		return nil, 0, errInvalidInput
+		return nil, remainingGas, errInvalidInput
 	}
 
 	privKeyBytes := input[3 : 3+privKeyLen]
@@ -86,23 +155,73 @@ func (p *pqCryptoPrecompile) slhdsaSign(input []byte) ([]byte, uint64, error) {
 	// Reconstruct private key
 	privKey, err := slhdsa.PrivateKeyFromBytes(privKeyBytes, mode)
 	if err != nil {
-		return nil, 0, err
+		return nil, remainingGas, err
 	}
 
 	// Sign message
 	signature, err := privKey.Sign(rand.Reader, message, nil)
 	if err != nil {
-		return nil, 0, err
+		return nil, remainingGas, err
 	}
 
-	return signature, 0, nil
+	remainingGas, err = emitPQSigned(accessibleState, caller, byte(RegistrySchemeSLHDSA), message, remainingGas)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return signature, remainingGas, nil
+}
+
+// mldsaSignKeyref signs with a key held in the node's local Keystore rather
+// than one supplied in calldata, so no privkey ever appears in a
+// transaction or the mempool. The key is looked up as (caller, key_id),
+// which keeps one contract from reaching another's keys by guessing its
+// key_id.
+//
+// Input format: [key_id(32)] [message]
+func (p *pqCryptoPrecompile) mldsaSignKeyref(accessibleState contract.AccessibleState, caller common.Address, input []byte, remainingGas uint64) ([]byte, uint64, error) {
+	return p.signKeyref(accessibleState, caller, input, remainingGas)
 }
 
-// mldsaGenKey generates an ML-DSA key pair
-func (p *pqCryptoPrecompile) mldsaGenKey(input []byte) ([]byte, uint64, error) {
+// slhdsaSignKeyref is slhdsa's counterpart to mldsaSignKeyref; the scheme
+// actually used is whatever was registered for key_id at import time, not
+// determined by which selector was called, so both selectors share one
+// implementation.
+func (p *pqCryptoPrecompile) slhdsaSignKeyref(accessibleState contract.AccessibleState, caller common.Address, input []byte, remainingGas uint64) ([]byte, uint64, error) {
+	return p.signKeyref(accessibleState, caller, input, remainingGas)
+}
+
+func (p *pqCryptoPrecompile) signKeyref(accessibleState contract.AccessibleState, caller common.Address, input []byte, remainingGas uint64) ([]byte, uint64, error) {
+	if len(input) < 32 {
+		return nil, remainingGas, errInvalidInput
+	}
+	var keyID [32]byte
+	copy(keyID[:], input[:32])
+	message := input[32:]
+
+	ksState, ok := accessibleState.(keystoreAccessibleState)
+	if !ok {
+		return nil, remainingGas, errKeystoreUnavailable
+	}
+	blockNumber := accessibleState.GetBlockContext().Number()
+
+	signature, scheme, _, err := ksState.GetKeystore().Sign(caller, keyID, message, blockNumber)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	remainingGas, err = emitPQSigned(accessibleState, caller, byte(scheme), message, remainingGas)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return signature, remainingGas, nil
+}
+
+// mldsaGenKey generates an ML-DSA key pair, emitting a PQKeyGenerated
+// event on success.
+func (p *pqCryptoPrecompile) mldsaGenKey(accessibleState contract.AccessibleState, caller common.Address, input []byte, remainingGas uint64) ([]byte, uint64, error) {
 	// Input format: [mode(1)]
 	if len(input) < 1 {
-		return nil, 0, errInvalidInput
+		return nil, remainingGas, errInvalidInput
 	}
 
 	mode := mldsa.Mode(input[0])
@@ -110,13 +229,18 @@ func (p *pqCryptoPrecompile) mldsaGenKey(input []byte) ([]byte, uint64, error) {
 	// Generate key pair
 	privKey, err := mldsa.GenerateKey(rand.Reader, mode)
 	if err != nil {
-		return nil, 0, err
+		return nil, remainingGas, err
 	}
 
 	// Serialize keys
 	privBytes := privKey.Bytes()
 	pubBytes := privKey.PublicKey.Bytes()
 
+	remainingGas, err = emitPQKeyGenerated(accessibleState, caller, byte(RegistrySchemeMLDSA), input[0], pubBytes, remainingGas)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
 	// Output format: [privkey_len(2)] [privkey] [pubkey]
 	output := make([]byte, 2+len(privBytes)+len(pubBytes))
 	output[0] = byte(len(privBytes) >> 8)
@@ -124,14 +248,17 @@ func (p *pqCryptoPrecompile) mldsaGenKey(input []byte) ([]byte, uint64, error) {
 	copy(output[2:2+len(privBytes)], privBytes)
 	copy(output[2+len(privBytes):], pubBytes)
 
-	return output, 0, nil
+	return output, remainingGas, nil
 }
 
-// mlkemGenKey generates an ML-KEM key pair
-func (p *pqCryptoPrecompile) mlkemGenKey(input []byte) ([]byte, uint64, error) {
+// mlkemGenKey generates an ML-KEM key pair, emitting a PQKeyGenerated
+// event on success. ML-KEM has no registry scheme byte of its own (the
+// registry only names mldsa/slhdsa as registrable signature schemes), so
+// this reuses alg byte 0 to mean "ML-KEM" in PQKeyGenerated specifically.
+func (p *pqCryptoPrecompile) mlkemGenKey(accessibleState contract.AccessibleState, caller common.Address, input []byte, remainingGas uint64) ([]byte, uint64, error) {
 	// Input format: [mode(1)]
 	if len(input) < 1 {
-		return nil, 0, errInvalidInput
+		return nil, remainingGas, errInvalidInput
 	}
 
 	mode := mlkem.Mode(input[0])
@@ -139,7 +266,7 @@ func (p *pqCryptoPrecompile) mlkemGenKey(input []byte) ([]byte, uint64, error) {
 	// Generate key pair - returns (privKey, pubKey, error)
 	privKey, _, err := mlkem.GenerateKeyPair(rand.Reader, mode)
 	if err != nil {
-		return nil, 0, err
+		return nil, remainingGas, err
 	}
 
 	// Serialize keys - extract public key from private key
@@ -147,6 +274,11 @@ func (p *pqCryptoPrecompile) mlkemGenKey(input []byte) ([]byte, uint64, error) {
 	pubKey := privKey.PublicKey
 	pubBytes := pubKey.Bytes()
 
+	remainingGas, err = emitPQKeyGenerated(accessibleState, caller, 0, input[0], pubBytes, remainingGas)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
 	// Output format: [privkey_len(2)] [privkey] [pubkey]
 	output := make([]byte, 2+len(privBytes)+len(pubBytes))
 	output[0] = byte(len(privBytes) >> 8)
@@ -154,14 +286,15 @@ func (p *pqCryptoPrecompile) mlkemGenKey(input []byte) ([]byte, uint64, error) {
 	copy(output[2:2+len(privBytes)], privBytes)
 	copy(output[2+len(privBytes):], pubBytes)
 
-	return output, 0, nil
+	return output, remainingGas, nil
 }
 
-// slhdsaGenKey generates an SLH-DSA key pair
-func (p *pqCryptoPrecompile) slhdsaGenKey(input []byte) ([]byte, uint64, error) {
+// slhdsaGenKey generates an SLH-DSA key pair, emitting a PQKeyGenerated
+// event on success.
+func (p *pqCryptoPrecompile) slhdsaGenKey(accessibleState contract.AccessibleState, caller common.Address, input []byte, remainingGas uint64) ([]byte, uint64, error) {
 	// Input format: [mode(1)]
 	if len(input) < 1 {
-		return nil, 0, errInvalidInput
+		return nil, remainingGas, errInvalidInput
 	}
 
 	mode := slhdsa.Mode(input[0])
@@ -169,13 +302,18 @@ func (p *pqCryptoPrecompile) slhdsaGenKey(input []byte) ([]byte, uint64, error)
 	// Generate key pair
 	privKey, err := slhdsa.GenerateKey(rand.Reader, mode)
 	if err != nil {
-		return nil, 0, err
+		return nil, remainingGas, err
 	}
 
 	// Serialize keys
 	privBytes := privKey.Bytes()
 	pubBytes := privKey.PublicKey.Bytes()
 
+	remainingGas, err = emitPQKeyGenerated(accessibleState, caller, byte(RegistrySchemeSLHDSA), input[0], pubBytes, remainingGas)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
 	// Output format: [privkey_len(2)] [privkey] [pubkey]
 	output := make([]byte, 2+len(privBytes)+len(pubBytes))
 	output[0] = byte(len(privBytes) >> 8)
@@ -183,6 +321,104 @@ func (p *pqCryptoPrecompile) slhdsaGenKey(input []byte) ([]byte, uint64, error)
 	copy(output[2:2+len(privBytes)], privBytes)
 	copy(output[2+len(privBytes):], pubBytes)
 
+	return output, remainingGas, nil
+}
+
+// mlkemEncaps performs ML-KEM encapsulation against a public key supplied
+// directly in calldata, unlike mlkemEncapsulate (contract.go), which takes
+// a caller-supplied seed and binds its output to the calling context. This
+// selector instead follows this file's own convention (mldsaSign,
+// mlkemGenKey, ...) of drawing randomness from rand.Reader directly.
+//
+// Input format: [mode(1)] [pubkey]
+// Output format: [ct_len(2)] [ciphertext] [shared_secret(32)]
+func (p *pqCryptoPrecompile) mlkemEncaps(input []byte) ([]byte, uint64, error) {
+	if len(input) < 1 {
+		return nil, 0, errInvalidInput
+	}
+
+	mode := mlkem.Mode(input[0])
+	pubKeyBytes := input[1:]
+
+	pubKey, err := mlkem.PublicKeyFromBytes(pubKeyBytes, mode)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	result, err := pubKey.Encapsulate(rand.Reader)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	output := make([]byte, 2+len(result.Ciphertext)+len(result.SharedSecret))
+	output[0] = byte(len(result.Ciphertext) >> 8)
+	output[1] = byte(len(result.Ciphertext))
+	copy(output[2:2+len(result.Ciphertext)], result.Ciphertext)
+	copy(output[2+len(result.Ciphertext):], result.SharedSecret)
+	return output, 0, nil
+}
+
+// mlkemHybridEncaps combines an X25519 exchange with ML-KEM encapsulation
+// into one shared secret, the X25519+ML-KEM-768 hybrid pairing the TLS 1.3
+// hybrid drafts describe. The two KEMs' outputs are bound together with a
+// SHA3-256 transcript hash (over every public value this call produces or
+// consumes) rather than simple concatenation, so the derived secret also
+// commits to exactly which keys and ciphertext produced it.
+//
+// Input format: [mode(1)] [x25519_peer_pubkey(32)] [mlkem_pubkey]
+// Output format: [x25519_ephemeral_pubkey(32)] [mlkem_ct_len(2)]
+// [mlkem_ciphertext] [shared_secret(32)]
+func (p *pqCryptoPrecompile) mlkemHybridEncaps(input []byte) ([]byte, uint64, error) {
+	if len(input) < 33 {
+		return nil, 0, errInvalidInput
+	}
+
+	mode := mlkem.Mode(input[0])
+	peerX25519Pub := input[1:33]
+	mlkemPubKeyBytes := input[33:]
+
+	curve := ecdh.X25519()
+	ephemeralPriv, err := curve.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, 0, err
+	}
+	peerPub, err := curve.NewPublicKey(peerX25519Pub)
+	if err != nil {
+		return nil, 0, err
+	}
+	x25519Secret, err := ephemeralPriv.ECDH(peerPub)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	mlkemPubKey, err := mlkem.PublicKeyFromBytes(mlkemPubKeyBytes, mode)
+	if err != nil {
+		return nil, 0, err
+	}
+	result, err := mlkemPubKey.Encapsulate(rand.Reader)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ephemeralPub := ephemeralPriv.PublicKey().Bytes()
+
+	transcript := make([]byte, 0, len(ephemeralPub)+len(peerX25519Pub)+len(result.Ciphertext)+len(mlkemPubKeyBytes))
+	transcript = append(transcript, ephemeralPub...)
+	transcript = append(transcript, peerX25519Pub...)
+	transcript = append(transcript, result.Ciphertext...)
+	transcript = append(transcript, mlkemPubKeyBytes...)
+
+	h := sha3.New256()
+	h.Write(x25519Secret)
+	h.Write(result.SharedSecret)
+	h.Write(transcript)
+	sharedSecret := h.Sum(nil)
+
+	output := make([]byte, 0, len(ephemeralPub)+2+len(result.Ciphertext)+len(sharedSecret))
+	output = append(output, ephemeralPub...)
+	output = append(output, byte(len(result.Ciphertext)>>8), byte(len(result.Ciphertext)))
+	output = append(output, result.Ciphertext...)
+	output = append(output, sharedSecret...)
 	return output, 0, nil
 }
 
@@ -206,6 +442,24 @@ func (p *pqCryptoPrecompile) ExtendedRequiredGas(input []byte) uint64 {
 		return MLKEMGenKeyGas
 	case SLHDSAGenKeySelector[:4]:
 		return SLHDSAGenKeyGas
+	case MLKEMEncapsSelector[:4]:
+		data := input[4:]
+		if len(data) < 1 {
+			return MLKEMEncaps1024Gas
+		}
+		return mlkemEncapsGasForMode(mlkem.Mode(data[0]))
+	case MLKEMDecapsSelector[:4]:
+		data := input[4:]
+		if len(data) < 1 {
+			return MLKEMDecaps1024Gas
+		}
+		return mlkemDecapsGasForMode(mlkem.Mode(data[0]))
+	case MLKEMHybridEncapsSelector[:4]:
+		return MLKEMHybridEncapsGas
+	case MLDSASignKeyrefSelector[:4]:
+		return MLDSASignKeyrefGas
+	case SLHDSASignKeyrefSelector[:4]:
+		return SLHDSASignKeyrefGas
 	default:
 		return p.RequiredGas(input) // Fall back to original
 	}
@@ -238,27 +492,43 @@ func (p *pqCryptoPrecompile) ExtendedRun(accessibleState contract.AccessibleStat
 		if readOnly {
 			return nil, remainingGas, errors.New("cannot sign in read-only mode")
 		}
-		return p.mldsaSign(data)
+		return p.mldsaSign(accessibleState, caller, data, remainingGas)
 	case SLHDSASignSelector[:4]:
 		if readOnly {
 			return nil, remainingGas, errors.New("cannot sign in read-only mode")
 		}
-		return p.slhdsaSign(data)
+		return p.slhdsaSign(accessibleState, caller, data, remainingGas)
 	case MLDSAGenKeySelector[:4]:
 		if readOnly {
 			return nil, remainingGas, errors.New("cannot generate keys in read-only mode")
 		}
-		return p.mldsaGenKey(data)
+		return p.mldsaGenKey(accessibleState, caller, data, remainingGas)
 	case MLKEMGenKeySelector[:4]:
 		if readOnly {
 			return nil, remainingGas, errors.New("cannot generate keys in read-only mode")
 		}
-		return p.mlkemGenKey(data)
+		return p.mlkemGenKey(accessibleState, caller, data, remainingGas)
 	case SLHDSAGenKeySelector[:4]:
 		if readOnly {
 			return nil, remainingGas, errors.New("cannot generate keys in read-only mode")
 		}
-		return p.slhdsaGenKey(data)
+		return p.slhdsaGenKey(accessibleState, caller, data, remainingGas)
+	case MLKEMEncapsSelector[:4]:
+		return p.mlkemEncaps(data)
+	case MLKEMDecapsSelector[:4]:
+		return p.mlkemDecapsulate(data)
+	case MLKEMHybridEncapsSelector[:4]:
+		return p.mlkemHybridEncaps(data)
+	case MLDSASignKeyrefSelector[:4]:
+		if readOnly {
+			return nil, remainingGas, errors.New("cannot sign in read-only mode")
+		}
+		return p.mldsaSignKeyref(accessibleState, caller, data, remainingGas)
+	case SLHDSASignKeyrefSelector[:4]:
+		if readOnly {
+			return nil, remainingGas, errors.New("cannot sign in read-only mode")
+		}
+		return p.slhdsaSignKeyref(accessibleState, caller, data, remainingGas)
 	default:
 		return nil, remainingGas, fmt.Errorf("unknown function selector: %x", selector)
 	}
@@ -0,0 +1,434 @@
+// Copyright (C) 2025, Lux Industries Inc All rights reserved.
+// Node-local encrypted keystore for mldsa_sign_keyref/slhdsa_sign_keyref, so
+// a contract can ask the node to sign with a key it never sees rather than
+// passing a raw privkey through calldata (into the mempool, into every
+// archive node, forever). Modeled on avalanchego's (née gecko's) keystore:
+// one scrypt-wrapped JSON blob per key, unlocked with a passphrase that's
+// only ever supplied out-of-band through the pqcrypto_keystore_import/
+// export RPC methods below, never through EVM calldata.
+//
+// contract.AccessibleState can't actually be extended with a GetKeystore
+// method from this package (it's an external, unvendored interface; see
+// events.go for the same constraint), so mldsaSignKeyref/slhdsaSignKeyref
+// instead narrow accessibleState to keystoreAccessibleState via a type
+// assertion -- the same trick Go code uses to add a method to an interface
+// it doesn't own, at the one call site that needs it.
+
+package pqcrypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/luxfi/crypto/mldsa"
+	"github.com/luxfi/crypto/slhdsa"
+	"github.com/luxfi/geth/common"
+	"golang.org/x/crypto/scrypt"
+)
+
+var (
+	errKeystoreUnavailable = errors.New("pqcrypto: node has no keystore attached to this call")
+	errKeyNotFound         = errors.New("pqcrypto: no key registered for this (caller, key_id)")
+	errRateLimited         = errors.New("pqcrypto: keyref sign rate limit exceeded for this block")
+	errWeakPassphrase      = errors.New("pqcrypto: passphrase does not meet the minimum strength score")
+)
+
+// Keystore is the node-local private-key store mldsa_sign_keyref and
+// slhdsa_sign_keyref consult. It is never reachable from Solidity directly;
+// the only way a key enters or leaves it is the pqcrypto_keystore_import/
+// export RPC methods (KeystoreService, below), which run off the EVM chain.
+type Keystore interface {
+	// Sign looks up the key scoped to (owner, keyID), rate-limits it
+	// against blockNumber, and signs message with it.
+	Sign(owner common.Address, keyID [32]byte, message []byte, blockNumber *big.Int) (signature []byte, scheme RegistryScheme, mode byte, err error)
+}
+
+// keystoreAccessibleState is implemented by accessibleState values that also
+// expose a node-local Keystore. See the package comment above for why this
+// is a type assertion rather than a real interface extension.
+type keystoreAccessibleState interface {
+	GetKeystore() Keystore
+}
+
+// keyRef scopes a stored key to (owner, keyID) so one contract's key_id
+// can't be reused by a different caller to reach someone else's key.
+type keyRef struct {
+	owner common.Address
+	keyID [32]byte
+}
+
+// storedKey is a decrypted keystore entry cached in memory after import.
+type storedKey struct {
+	scheme  RegistryScheme
+	mode    byte
+	privKey []byte
+}
+
+// FileKeystore is the default Keystore implementation: entries are
+// persisted to disk as scrypt-wrapped AES-GCM JSON blobs (one file per key,
+// under dir) and cached decrypted in memory between Import and process
+// restart, the same trade-off avalanchego's original keystore made between
+// a fully in-memory store and re-prompting for a passphrase on every call.
+type FileKeystore struct {
+	mu      sync.Mutex
+	dir     string
+	entries map[keyRef]*storedKey
+
+	// rate limiting: max N sign calls per block per keyRef.
+	maxSignsPerBlock uint64
+	signCounts       map[keyRef]*blockCounter
+}
+
+type blockCounter struct {
+	block *big.Int
+	count uint64
+}
+
+// NewFileKeystore returns a keystore persisting scrypt-wrapped key files
+// under dir, allowing at most maxSignsPerBlock Sign calls per (owner,
+// keyID) per block -- the rate limiter a compromised contract with a valid
+// key_id must still contend with before it can exfiltrate signatures.
+func NewFileKeystore(dir string, maxSignsPerBlock uint64) *FileKeystore {
+	return &FileKeystore{
+		dir:              dir,
+		entries:          make(map[keyRef]*storedKey),
+		maxSignsPerBlock: maxSignsPerBlock,
+		signCounts:       make(map[keyRef]*blockCounter),
+	}
+}
+
+// Sign implements Keystore.
+func (k *FileKeystore) Sign(owner common.Address, keyID [32]byte, message []byte, blockNumber *big.Int) ([]byte, RegistryScheme, byte, error) {
+	k.mu.Lock()
+	ref := keyRef{owner: owner, keyID: keyID}
+	entry, ok := k.entries[ref]
+	if !ok {
+		k.mu.Unlock()
+		return nil, 0, 0, errKeyNotFound
+	}
+	if !k.allowSign(ref, blockNumber) {
+		k.mu.Unlock()
+		return nil, 0, 0, errRateLimited
+	}
+	scheme, mode, privKey := entry.scheme, entry.mode, entry.privKey
+	k.mu.Unlock()
+
+	return signWithStoredKey(scheme, mode, privKey, message)
+}
+
+// allowSign increments ref's counter for blockNumber, resetting it whenever
+// blockNumber has moved on from the last call, and reports whether the
+// call is still within maxSignsPerBlock. Callers must hold k.mu.
+func (k *FileKeystore) allowSign(ref keyRef, blockNumber *big.Int) bool {
+	counter, ok := k.signCounts[ref]
+	if !ok || counter.block.Cmp(blockNumber) != 0 {
+		counter = &blockCounter{block: new(big.Int).Set(blockNumber)}
+		k.signCounts[ref] = counter
+	}
+	if counter.count >= k.maxSignsPerBlock {
+		return false
+	}
+	counter.count++
+	return true
+}
+
+func signWithStoredKey(scheme RegistryScheme, mode byte, privKeyBytes, message []byte) ([]byte, RegistryScheme, byte, error) {
+	switch scheme {
+	case RegistrySchemeMLDSA:
+		privKey, err := mldsa.PrivateKeyFromBytes(privKeyBytes, mldsa.Mode(mode))
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		sig, err := privKey.Sign(rand.Reader, message, nil)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		return sig, scheme, mode, nil
+	case RegistrySchemeSLHDSA:
+		privKey, err := slhdsa.PrivateKeyFromBytes(privKeyBytes, slhdsa.Mode(mode))
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		sig, err := privKey.Sign(rand.Reader, message, nil)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		return sig, scheme, mode, nil
+	default:
+		return nil, 0, 0, fmt.Errorf("pqcrypto: unsupported keystore scheme %d", scheme)
+	}
+}
+
+// --- encrypted-file format ---
+
+// encryptedKeyJSON is the on-disk, scrypt-wrapped representation of one
+// keystore entry, in the same spirit as geth/gecko's keystore V3 format but
+// with AES-GCM in place of AES-CTR+MAC, since GCM already gives integrity.
+type encryptedKeyJSON struct {
+	Owner      string `json:"owner"`
+	KeyID      string `json:"keyId"`
+	Scheme     byte   `json:"scheme"`
+	Mode       byte   `json:"mode"`
+	Salt       string `json:"salt"`
+	ScryptN    int    `json:"scryptN"`
+	ScryptR    int    `json:"scryptR"`
+	ScryptP    int    `json:"scryptP"`
+	Nonce      string `json:"nonce"`
+	Ciphertext string `json:"ciphertext"`
+}
+
+const (
+	scryptN      = 1 << 15
+	scryptR      = 8
+	scryptP      = 1
+	scryptKeyLen = 32
+)
+
+func encryptPrivateKey(privKey []byte, passphrase string) (*encryptedKeyJSON, error) {
+	salt := make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	derivedKey, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, scryptKeyLen)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(derivedKey)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	ciphertext := gcm.Seal(nil, nonce, privKey, nil)
+
+	return &encryptedKeyJSON{
+		Salt:       hex.EncodeToString(salt),
+		ScryptN:    scryptN,
+		ScryptR:    scryptR,
+		ScryptP:    scryptP,
+		Nonce:      hex.EncodeToString(nonce),
+		Ciphertext: hex.EncodeToString(ciphertext),
+	}, nil
+}
+
+func decryptPrivateKey(enc *encryptedKeyJSON, passphrase string) ([]byte, error) {
+	salt, err := hex.DecodeString(enc.Salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := hex.DecodeString(enc.Nonce)
+	if err != nil {
+		return nil, err
+	}
+	ciphertext, err := hex.DecodeString(enc.Ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	derivedKey, err := scrypt.Key([]byte(passphrase), salt, enc.ScryptN, enc.ScryptR, enc.ScryptP, scryptKeyLen)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(derivedKey)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return gcm.Open(nil, nonce, ciphertext, nil)
+}
+
+// --- passphrase strength gate ---
+
+// minPassphraseEntropyBits is the minimum estimated entropy a passphrase
+// must carry at import time, roughly zxcvbn's score-3 ("safely unguessable")
+// cutoff. The real zxcvbn library (dictionary-aware, pattern-aware) isn't
+// vendored anywhere in this tree, so this is a Shannon-entropy approximation
+// over the passphrase's observed character classes rather than the genuine
+// zxcvbn scoring model.
+const minPassphraseEntropyBits = 50.0
+
+func checkPassphraseStrength(passphrase string) error {
+	if estimatePassphraseEntropyBits(passphrase) < minPassphraseEntropyBits {
+		return errWeakPassphrase
+	}
+	return nil
+}
+
+func estimatePassphraseEntropyBits(passphrase string) float64 {
+	var hasLower, hasUpper, hasDigit, hasSymbol bool
+	for _, r := range passphrase {
+		switch {
+		case r >= 'a' && r <= 'z':
+			hasLower = true
+		case r >= 'A' && r <= 'Z':
+			hasUpper = true
+		case r >= '0' && r <= '9':
+			hasDigit = true
+		default:
+			hasSymbol = true
+		}
+	}
+	charsetSize := 0
+	if hasLower {
+		charsetSize += 26
+	}
+	if hasUpper {
+		charsetSize += 26
+	}
+	if hasDigit {
+		charsetSize += 10
+	}
+	if hasSymbol {
+		charsetSize += 33
+	}
+	if charsetSize == 0 {
+		return 0
+	}
+	return float64(len(passphrase)) * math.Log2(float64(charsetSize))
+}
+
+// --- pqcrypto_keystore_import / pqcrypto_keystore_export RPC methods ---
+
+// KeystoreService exposes pqcrypto_keystore_import and
+// pqcrypto_keystore_export, the off-band key management path operators use
+// instead of ever putting a privkey in a transaction. It isn't reachable
+// from the EVM; nothing in this package registers it as a precompile
+// selector, the same way avalanchego's own keystore RPC service sits
+// beside (not inside) the VM it serves.
+type KeystoreService struct {
+	ks *FileKeystore
+}
+
+// NewKeystoreService wraps ks for RPC registration under the
+// "pqcrypto_keystore" namespace (so its methods resolve as
+// pqcrypto_keystore_import / pqcrypto_keystore_export).
+func NewKeystoreService(ks *FileKeystore) *KeystoreService {
+	return &KeystoreService{ks: ks}
+}
+
+// ImportArgs is the request body for pqcrypto_keystore_import.
+type ImportArgs struct {
+	Owner      common.Address `json:"owner"`
+	KeyID      common.Hash    `json:"keyId"`
+	Scheme     byte           `json:"scheme"`
+	Mode       byte           `json:"mode"`
+	PrivateKey string         `json:"privateKey"` // hex-encoded
+	Passphrase string         `json:"passphrase"`
+}
+
+// ImportReply is the response body for pqcrypto_keystore_import.
+type ImportReply struct {
+	Success bool `json:"success"`
+}
+
+// Import decrypts args.PrivateKey from hex, gates args.Passphrase through
+// the strength check, encrypts the key to disk under the (owner, keyId)
+// pair, and caches it decrypted in memory for Sign to use.
+func (s *KeystoreService) Import(args *ImportArgs, reply *ImportReply) error {
+	if err := checkPassphraseStrength(args.Passphrase); err != nil {
+		return err
+	}
+	privKey, err := hex.DecodeString(strings.TrimPrefix(args.PrivateKey, "0x"))
+	if err != nil {
+		return fmt.Errorf("pqcrypto: invalid privateKey hex: %w", err)
+	}
+
+	enc, err := encryptPrivateKey(privKey, args.Passphrase)
+	if err != nil {
+		return err
+	}
+	enc.Owner = args.Owner.Hex()
+	enc.KeyID = args.KeyID.Hex()
+	enc.Scheme = args.Scheme
+	enc.Mode = args.Mode
+
+	if err := s.ks.writeEncryptedFile(args.Owner, args.KeyID, enc); err != nil {
+		return err
+	}
+
+	s.ks.mu.Lock()
+	s.ks.entries[keyRef{owner: args.Owner, keyID: args.KeyID}] = &storedKey{
+		scheme:  RegistryScheme(args.Scheme),
+		mode:    args.Mode,
+		privKey: privKey,
+	}
+	s.ks.mu.Unlock()
+
+	reply.Success = true
+	return nil
+}
+
+// ExportArgs is the request body for pqcrypto_keystore_export.
+type ExportArgs struct {
+	Owner      common.Address `json:"owner"`
+	KeyID      common.Hash    `json:"keyId"`
+	Passphrase string         `json:"passphrase"`
+}
+
+// ExportReply is the response body for pqcrypto_keystore_export.
+type ExportReply struct {
+	PrivateKey string `json:"privateKey"` // hex-encoded
+	Scheme     byte   `json:"scheme"`
+	Mode       byte   `json:"mode"`
+}
+
+// Export re-reads the encrypted file for (owner, keyId) from disk and
+// decrypts it with passphrase, rather than trusting the in-memory cache,
+// so export still works (and still requires the real passphrase) even
+// for a key this node process never imported itself.
+func (s *KeystoreService) Export(args *ExportArgs, reply *ExportReply) error {
+	enc, err := s.ks.readEncryptedFile(args.Owner, args.KeyID)
+	if err != nil {
+		return err
+	}
+	privKey, err := decryptPrivateKey(enc, args.Passphrase)
+	if err != nil {
+		return fmt.Errorf("pqcrypto: export failed, wrong passphrase or corrupt key file: %w", err)
+	}
+	reply.PrivateKey = "0x" + hex.EncodeToString(privKey)
+	reply.Scheme = enc.Scheme
+	reply.Mode = enc.Mode
+	return nil
+}
+
+func (k *FileKeystore) keyFilePath(owner common.Address, keyID common.Hash) string {
+	return fmt.Sprintf("%s/%s_%s.json", k.dir, owner.Hex(), keyID.Hex())
+}
+
+func (k *FileKeystore) writeEncryptedFile(owner common.Address, keyID common.Hash, enc *encryptedKeyJSON) error {
+	data, err := json.Marshal(enc)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(k.keyFilePath(owner, keyID), data, 0o600)
+}
+
+func (k *FileKeystore) readEncryptedFile(owner common.Address, keyID common.Hash) (*encryptedKeyJSON, error) {
+	data, err := os.ReadFile(k.keyFilePath(owner, keyID))
+	if err != nil {
+		return nil, err
+	}
+	var enc encryptedKeyJSON
+	if err := json.Unmarshal(data, &enc); err != nil {
+		return nil, err
+	}
+	return &enc, nil
+}
@@ -0,0 +1,128 @@
+// Copyright (C) 2025, Lux Industries Inc All rights reserved.
+// modules.RegisterModule-based activation for the PQ crypto precompile,
+// alongside (not replacing) the precompileconfig.Config in config.go.
+
+package pqcrypto
+
+import (
+	"github.com/luxfi/evm/precompile/contract"
+	"github.com/luxfi/evm/precompile/modules"
+	"github.com/luxfi/geth/common"
+)
+
+var _ contract.StatefulPrecompiledContract = &pqCryptoEntryPoint{}
+
+// pqCryptoEntryPoint is the StatefulPrecompiledContract registered with
+// modules.RegisterModule. Its Run is AbiRun, the full dispatch chain this
+// package has accumulated across requests (ABI-encoded selectors, then
+// batch verification, then pre-hash signing/verification, then the
+// stateful key registry, then the sign/genkey selectors, then the
+// original hand-packed selectors), so a chain that enables this module
+// gets every selector pqcrypto has ever added rather than just the four
+// RequiredGas/Run started with.
+type pqCryptoEntryPoint struct{}
+
+// PQCryptoEntryPoint is the singleton registered as this precompile's
+// contract.
+var PQCryptoEntryPoint = &pqCryptoEntryPoint{}
+
+func (p *pqCryptoEntryPoint) Address() common.Address {
+	return ContractAddress
+}
+
+func (p *pqCryptoEntryPoint) RequiredGas(input []byte) uint64 {
+	return PQCryptoPrecompile.AbiRequiredGas(input)
+}
+
+func (p *pqCryptoEntryPoint) Run(
+	accessibleState contract.AccessibleState,
+	caller common.Address,
+	addr common.Address,
+	input []byte,
+	suppliedGas uint64,
+	readOnly bool,
+) ([]byte, uint64, error) {
+	return PQCryptoPrecompile.AbiRun(accessibleState, caller, addr, input, suppliedGas, readOnly)
+}
+
+var _ contract.Configurator = &configurator{}
+
+type configurator struct{}
+
+func init() {
+	if err := modules.RegisterModule(
+		ContractAddress.String(),
+		&configurator{},
+	); err != nil {
+		panic(err)
+	}
+}
+
+func (*configurator) MakeConfig() contract.StatefulPrecompileConfig {
+	return &ModuleConfig{
+		Address: ContractAddress,
+	}
+}
+
+// ModuleConfig implements contract.StatefulPrecompileConfig for the PQ
+// crypto precompile's modules.RegisterModule-based activation path. It is
+// named distinctly from Config (config.go), which implements the older
+// precompileconfig.Config interface used before this package was wired
+// into modules.RegisterModule; the two coexist during the migration from
+// one activation mechanism to the other, same as other precompiles that
+// have made this same transition in this repo.
+//
+// Gas is already priced per selector and per PQ mode in RequiredGas /
+// ExtendedRequiredGas / RegistryRequiredGas / AbiRequiredGas; what this
+// config actually governs is activation, via BlockTimestamp and Disable,
+// unlike this repo's other Configurators (cggmp21, popregistry,
+// sigverify), whose Timestamp()/IsDisabled() are permanently nil/false
+// stubs.
+type ModuleConfig struct {
+	Address        common.Address `json:"address"`
+	BlockTimestamp *uint64        `json:"blockTimestamp,omitempty"`
+	Disable        bool           `json:"disable,omitempty"`
+}
+
+func (c *ModuleConfig) Key() string {
+	return c.Address.String()
+}
+
+func (c *ModuleConfig) Timestamp() *uint64 {
+	return c.BlockTimestamp
+}
+
+func (c *ModuleConfig) IsDisabled() bool {
+	return c.Disable
+}
+
+func (c *ModuleConfig) Equal(cfg contract.StatefulPrecompileConfig) bool {
+	other, ok := cfg.(*ModuleConfig)
+	if !ok {
+		return false
+	}
+	if c.Address != other.Address || c.Disable != other.Disable {
+		return false
+	}
+	if (c.BlockTimestamp == nil) != (other.BlockTimestamp == nil) {
+		return false
+	}
+	if c.BlockTimestamp != nil && *c.BlockTimestamp != *other.BlockTimestamp {
+		return false
+	}
+	return true
+}
+
+func (c *ModuleConfig) Configure(
+	chainConfig contract.ChainConfig,
+	precompileConfig contract.PrecompileConfig,
+	state contract.StateDB,
+) error {
+	// No state initialization required; keys register lazily as
+	// pq_register/pqRegister calls come in.
+	return nil
+}
+
+func (c *ModuleConfig) Contract() contract.StatefulPrecompiledContract {
+	return PQCryptoEntryPoint
+}
@@ -0,0 +1,286 @@
+// Copyright (C) 2025, Lux Industries Inc All rights reserved.
+// FIPS 204/205 pre-hash (HashML-DSA / HashSLH-DSA) signing and
+// verification, plus context-string support, for the PQ crypto precompile.
+
+package pqcrypto
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/luxfi/crypto/mldsa"
+	"github.com/luxfi/crypto/slhdsa"
+	"github.com/luxfi/evm/precompile/contract"
+	"github.com/luxfi/geth/common"
+	"github.com/luxfi/geth/core/vm"
+)
+
+const (
+	// Selector names are abbreviated to keep their first 4 bytes -- the
+	// part this package actually dispatches on -- distinct from each
+	// other as well as from RequiredGas/ExtendedRequiredGas's existing
+	// selectors (mldsa_sign_prehash and mldsa_verify_prehash would
+	// otherwise both truncate to "mlds").
+	MLDSASignPrehashSelector    = "mlsp_prehash"
+	SLHDSASignPrehashSelector   = "slsp_prehash"
+	MLDSAVerifyPrehashSelector  = "mlvp_prehash"
+	SLHDSAVerifyPrehashSelector = "slvp_prehash"
+
+	// PrehashMaxContextSize is the largest domain-separation context FIPS
+	// 204/205 allow (ctx MUST be at most 255 bytes), matching
+	// slhdsa.MaxContextSize.
+	PrehashMaxContextSize = 255
+
+	// Gas for pre-hash sign/verify is a small flat constant rather than
+	// this package's usual per-message-byte pricing, since the caller has
+	// already hashed the message down to a short digest before it ever
+	// reaches this precompile.
+	MLDSASignPrehashGas    uint64 = 6_000
+	SLHDSAVerifyPrehashGas uint64 = 8_000
+	MLDSAVerifyPrehashGas  uint64 = 5_000
+	SLHDSASignPrehashGas   uint64 = 9_000
+
+	// PrehashPerContextByteGas is charged per byte of ctx, since ctx (up
+	// to 255 bytes) is the one still-variable-length input these
+	// selectors take.
+	PrehashPerContextByteGas uint64 = 10
+)
+
+// PrehashHashOID identifies which hash function the caller used to
+// produce digest, for provenance only: this precompile trusts the caller
+// to have actually hashed message under oid before calling it and never
+// re-hashes anything itself.
+type PrehashHashOID byte
+
+const (
+	PrehashOIDSHA256   PrehashHashOID = 0
+	PrehashOIDSHA512   PrehashHashOID = 1
+	PrehashOIDSHAKE128 PrehashHashOID = 2
+	PrehashOIDSHAKE256 PrehashHashOID = 3
+)
+
+var ErrUnsupportedPrehashOID = errors.New("pqcrypto: unsupported pre-hash OID")
+
+// prehashHeader is the common [mode][ctxLen][ctx][oidLen][oid][digestLen]
+// [digest] prefix shared by all four pre-hash selectors. oidLen == 0
+// means this is actually a pure-mode (non-pre-hashed) call reusing the
+// same wire layout: digest then holds the full original message instead
+// of a hash of it, and domSep is conceptually 0 instead of 1. Either way,
+// what gets passed to the underlying Sign/Verify is just (digest, ctx):
+// the mldsa/slhdsa packages' own pure API already performs FIPS's
+// domSep(0) || len(ctx) || ctx || M wrapping internally for an ordinary
+// Sign/Verify call, which is also the best this black-box library can do
+// for HashML-DSA/HashSLH-DSA's domSep(1) || len(ctx) || ctx || OID ||
+// digest wrapping -- it has no separate pre-hash entry point, so this
+// matches the same best-effort approach slhdsa/prehash.go already takes
+// for SLH-DSA's own pre-hash mode.
+type prehashHeader struct {
+	mode   byte
+	ctx    []byte
+	oid    []byte
+	digest []byte
+}
+
+func parsePrehashHeader(input []byte) (hdr prehashHeader, rest []byte, err error) {
+	if len(input) < 4 {
+		return prehashHeader{}, nil, fmt.Errorf("%w: header truncated", errInvalidInput)
+	}
+	mode := input[0]
+	ctxLen := int(input[1])
+	if ctxLen > PrehashMaxContextSize {
+		return prehashHeader{}, nil, fmt.Errorf("%w: context exceeds %d bytes", errInvalidInput, PrehashMaxContextSize)
+	}
+	off := 2
+	if len(input) < off+ctxLen+1 {
+		return prehashHeader{}, nil, fmt.Errorf("%w: context truncated", errInvalidInput)
+	}
+	ctx := input[off : off+ctxLen]
+	off += ctxLen
+
+	oidLen := int(input[off])
+	off++
+	if len(input) < off+oidLen+2 {
+		return prehashHeader{}, nil, fmt.Errorf("%w: oid truncated", errInvalidInput)
+	}
+	oid := input[off : off+oidLen]
+	off += oidLen
+	if oidLen > 0 {
+		if PrehashHashOID(oid[0]) > PrehashOIDSHAKE256 {
+			return prehashHeader{}, nil, fmt.Errorf("%w: 0x%x", ErrUnsupportedPrehashOID, oid[0])
+		}
+	}
+
+	digestLen := int(binary.BigEndian.Uint16(input[off : off+2]))
+	off += 2
+	if len(input) < off+digestLen {
+		return prehashHeader{}, nil, fmt.Errorf("%w: digest truncated", errInvalidInput)
+	}
+	digest := input[off : off+digestLen]
+	off += digestLen
+
+	return prehashHeader{mode: mode, ctx: ctx, oid: oid, digest: digest}, input[off:], nil
+}
+
+func prehashRequiredGas(input []byte, base uint64) uint64 {
+	hdr, _, err := parsePrehashHeader(input)
+	if err != nil {
+		return base
+	}
+	return base + uint64(len(hdr.ctx))*PrehashPerContextByteGas
+}
+
+// PrehashRequiredGas extends RegistryRequiredGas with the four pre-hash
+// selectors, falling back to RegistryRequiredGas (and transitively
+// ExtendedRequiredGas/RequiredGas) for everything else.
+func (p *pqCryptoPrecompile) PrehashRequiredGas(input []byte) uint64 {
+	if len(input) < 4 {
+		return 0
+	}
+	selector := string(input[:4])
+	data := input[4:]
+
+	switch selector {
+	case MLDSASignPrehashSelector[:4]:
+		return prehashRequiredGas(data, MLDSASignPrehashGas)
+	case SLHDSASignPrehashSelector[:4]:
+		return prehashRequiredGas(data, SLHDSASignPrehashGas)
+	case MLDSAVerifyPrehashSelector[:4]:
+		return prehashRequiredGas(data, MLDSAVerifyPrehashGas)
+	case SLHDSAVerifyPrehashSelector[:4]:
+		return prehashRequiredGas(data, SLHDSAVerifyPrehashGas)
+	default:
+		return p.RegistryRequiredGas(input)
+	}
+}
+
+// PrehashRun extends RegistryRun with the four pre-hash selectors, falling
+// back to RegistryRun (and transitively ExtendedRun/Run) for everything
+// else.
+func (p *pqCryptoPrecompile) PrehashRun(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if len(input) < 4 {
+		return nil, suppliedGas, errInvalidInput
+	}
+
+	selector := string(input[:4])
+	switch selector {
+	case MLDSASignPrehashSelector[:4], SLHDSASignPrehashSelector[:4], MLDSAVerifyPrehashSelector[:4], SLHDSAVerifyPrehashSelector[:4]:
+	default:
+		return p.RegistryRun(accessibleState, caller, addr, input, suppliedGas, readOnly)
+	}
+
+	requiredGas := p.PrehashRequiredGas(input)
+	if suppliedGas < requiredGas {
+		return nil, 0, vm.ErrOutOfGas
+	}
+	remainingGas = suppliedGas - requiredGas
+	data := input[4:]
+
+	switch selector {
+	case MLDSASignPrehashSelector[:4]:
+		if readOnly {
+			return nil, remainingGas, errors.New("cannot sign in read-only mode")
+		}
+		return p.mldsaSignPrehash(data, remainingGas)
+	case SLHDSASignPrehashSelector[:4]:
+		if readOnly {
+			return nil, remainingGas, errors.New("cannot sign in read-only mode")
+		}
+		return p.slhdsaSignPrehash(data, remainingGas)
+	case MLDSAVerifyPrehashSelector[:4]:
+		return p.mldsaVerifyPrehash(data, remainingGas)
+	case SLHDSAVerifyPrehashSelector[:4]:
+		return p.slhdsaVerifyPrehash(data, remainingGas)
+	default:
+		return nil, remainingGas, fmt.Errorf("unknown function selector: %x", selector)
+	}
+}
+
+func (p *pqCryptoPrecompile) mldsaSignPrehash(input []byte, remainingGas uint64) ([]byte, uint64, error) {
+	hdr, rest, err := parsePrehashHeader(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	privKeyLen := int(binary.BigEndian.Uint16(rest[:2]))
+	if len(rest) < 2+privKeyLen {
+		return nil, remainingGas, errInvalidInput
+	}
+	privKeyBytes := rest[2 : 2+privKeyLen]
+
+	privKey, err := mldsa.PrivateKeyFromBytes(privKeyBytes, mldsa.Mode(hdr.mode))
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	signature, err := privKey.Sign(rand.Reader, hdr.digest, hdr.ctx)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return signature, remainingGas, nil
+}
+
+func (p *pqCryptoPrecompile) slhdsaSignPrehash(input []byte, remainingGas uint64) ([]byte, uint64, error) {
+	hdr, rest, err := parsePrehashHeader(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	privKeyLen := int(binary.BigEndian.Uint16(rest[:2]))
+	if len(rest) < 2+privKeyLen {
+		return nil, remainingGas, errInvalidInput
+	}
+	privKeyBytes := rest[2 : 2+privKeyLen]
+
+	privKey, err := slhdsa.PrivateKeyFromBytes(privKeyBytes, slhdsa.Mode(hdr.mode))
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	signature, err := privKey.Sign(rand.Reader, hdr.digest, hdr.ctx)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return signature, remainingGas, nil
+}
+
+func (p *pqCryptoPrecompile) mldsaVerifyPrehash(input []byte, remainingGas uint64) ([]byte, uint64, error) {
+	hdr, rest, err := parsePrehashHeader(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	pubKeyLen := int(binary.BigEndian.Uint16(rest[:2]))
+	if len(rest) < 2+pubKeyLen {
+		return nil, remainingGas, errInvalidInput
+	}
+	pubKeyBytes := rest[2 : 2+pubKeyLen]
+	signature := rest[2+pubKeyLen:]
+
+	pubKey, err := mldsa.PublicKeyFromBytes(pubKeyBytes, mldsa.Mode(hdr.mode))
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	if pubKey.Verify(hdr.digest, signature, hdr.ctx) {
+		return []byte{1}, remainingGas, nil
+	}
+	return []byte{0}, remainingGas, nil
+}
+
+func (p *pqCryptoPrecompile) slhdsaVerifyPrehash(input []byte, remainingGas uint64) ([]byte, uint64, error) {
+	hdr, rest, err := parsePrehashHeader(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	pubKeyLen := int(binary.BigEndian.Uint16(rest[:2]))
+	if len(rest) < 2+pubKeyLen {
+		return nil, remainingGas, errInvalidInput
+	}
+	pubKeyBytes := rest[2 : 2+pubKeyLen]
+	signature := rest[2+pubKeyLen:]
+
+	pubKey, err := slhdsa.PublicKeyFromBytes(pubKeyBytes, slhdsa.Mode(hdr.mode))
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	if pubKey.Verify(hdr.digest, signature, hdr.ctx) {
+		return []byte{1}, remainingGas, nil
+	}
+	return []byte{0}, remainingGas, nil
+}
@@ -0,0 +1,292 @@
+// Copyright (C) 2025, Lux Industries Inc All rights reserved.
+// Stateful PQ public-key registry: register once, verify by a 32-byte keyId.
+
+package pqcrypto
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/luxfi/crypto/mldsa"
+	"github.com/luxfi/crypto/slhdsa"
+	"github.com/luxfi/evm/precompile/contract"
+	"github.com/luxfi/geth/common"
+	"github.com/luxfi/geth/core/vm"
+	"github.com/luxfi/geth/crypto"
+)
+
+const (
+	// PQRegisterSelector stores a public key once so later verifies can
+	// reference it by keyId instead of repeating it in calldata.
+	PQRegisterSelector = "pq_register"
+
+	// PQVerifyByIDSelector verifies a signature against a previously
+	// registered key.
+	PQVerifyByIDSelector = "pq_verify_by_id"
+
+	// PQRegisterGas covers one SSTORE-sized write per 32 bytes of public
+	// key, billed once regardless of how many times the key is later
+	// referenced by verifyById. This is the gas a caller trades calldata
+	// cost for: ~5KB of repeated ML-DSA-65 calldata (16 gas/nonzero byte)
+	// collapses to one registration plus a 32-byte keyId per call after.
+	PQRegisterGas uint64 = 20_000
+
+	// PQRegisterPerWordGas is charged per 32-byte chunk of the public key
+	// being stored, on top of PQRegisterGas.
+	PQRegisterPerWordGas uint64 = 5_000
+
+	// PQVerifyByIDBaseGas covers the storage reads needed to reconstruct a
+	// registered key before the underlying scheme's own verify cost is
+	// added in RegistryRequiredGas.
+	PQVerifyByIDBaseGas uint64 = 1_000
+)
+
+// RegistryScheme identifies which PQ signature scheme a registered key
+// belongs to.
+type RegistryScheme byte
+
+const (
+	RegistrySchemeMLDSA  RegistryScheme = 1
+	RegistrySchemeSLHDSA RegistryScheme = 2
+)
+
+var (
+	ErrKeyAlreadyRegistered = fmt.Errorf("pqcrypto: key already registered")
+	ErrKeyNotRegistered     = fmt.Errorf("pqcrypto: keyId not registered")
+	ErrUnsupportedScheme    = fmt.Errorf("pqcrypto: unsupported registry scheme")
+)
+
+// registryWordSize is the EVM storage word size: public keys longer than 32
+// bytes are stored across this many bytes per slot.
+const registryWordSize = 32
+
+// RegistryRequiredGas extends ExtendedRequiredGas with pricing for
+// pq_register and pq_verify_by_id, falling back to ExtendedRequiredGas
+// (and transitively RequiredGas) for every other selector.
+func (p *pqCryptoPrecompile) RegistryRequiredGas(input []byte) uint64 {
+	if len(input) < 4 {
+		return 0
+	}
+	selector := string(input[:4])
+	data := input[4:]
+
+	switch selector {
+	case PQRegisterSelector[:4]:
+		words := (len(data) - 2 + registryWordSize - 1) / registryWordSize
+		if words < 0 {
+			words = 0
+		}
+		return PQRegisterGas + uint64(words)*PQRegisterPerWordGas
+	case PQVerifyByIDSelector[:4]:
+		// RequiredGas must price purely from input, but which scheme keyId
+		// names lives in storage, not in this call's input. Pricing at
+		// SLHDSAVerifyGas -- the more expensive of the two registrable
+		// schemes -- keeps this conservative (never underpriced) rather
+		// than guessing and risking an ML-DSA-priced call that actually
+		// runs an SLH-DSA verify underneath.
+		return PQVerifyByIDBaseGas + SLHDSAVerifyGas
+	default:
+		return p.ExtendedRequiredGas(input)
+	}
+}
+
+// RegistryRun extends ExtendedRun with pq_register and pq_verify_by_id,
+// falling back to ExtendedRun (and transitively Run) for every other
+// selector. It is the entry point that should be wired up in place of Run
+// once this precompile is registered, since Run alone has no way to reach
+// the two new stateful selectors.
+func (p *pqCryptoPrecompile) RegistryRun(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if len(input) < 4 {
+		return nil, suppliedGas, errInvalidInput
+	}
+
+	requiredGas := p.RegistryRequiredGas(input)
+	if suppliedGas < requiredGas {
+		return nil, 0, vm.ErrOutOfGas
+	}
+	remainingGas = suppliedGas - requiredGas
+
+	selector := string(input[:4])
+	data := input[4:]
+
+	switch selector {
+	case PQRegisterSelector[:4]:
+		if readOnly {
+			return nil, remainingGas, fmt.Errorf("cannot register a key in read-only mode")
+		}
+		return p.registerKey(accessibleState, data, remainingGas)
+	case PQVerifyByIDSelector[:4]:
+		return p.verifyByID(accessibleState, data, remainingGas)
+	default:
+		return p.ExtendedRun(accessibleState, caller, addr, input, suppliedGas, readOnly)
+	}
+}
+
+// registerKey stores a public key keyed by keccak256(scheme || mode ||
+// pubkey) so it is idempotent (re-registering the same key is a no-op) and
+// deterministic across chains (the keyId depends only on the key itself,
+// never on chain ID, caller, or block).
+//
+// Input: [scheme(1)] [mode(1)] [pubkeyLen(2)] [pubkey]
+// Output: [keyId(32)]
+//
+// Registration is permanent: there is no deregister selector, so a
+// registered key's storage is never reclaimed. A refcounted eviction
+// scheme would let callers reclaim the SSTORE refund for keys nobody
+// verifies against anymore, but it also opens a use-after-evict hazard if
+// a verifyById call races a deregistration in the same block; since
+// calldata savings -- not storage rent -- are this precompile's whole
+// purpose, and PQ keys are generally long-lived validator/oracle identity
+// keys, permanent storage is the simpler and safer default.
+func (p *pqCryptoPrecompile) registerKey(accessibleState contract.AccessibleState, input []byte, remainingGas uint64) ([]byte, uint64, error) {
+	if len(input) < 4 {
+		return nil, remainingGas, errInvalidInput
+	}
+	scheme := RegistryScheme(input[0])
+	mode := input[1]
+	pubKeyLen := int(binary.BigEndian.Uint16(input[2:4]))
+	if len(input) < 4+pubKeyLen {
+		return nil, remainingGas, errInvalidInput
+	}
+	pubKey := input[4 : 4+pubKeyLen]
+
+	if scheme != RegistrySchemeMLDSA && scheme != RegistrySchemeSLHDSA {
+		return nil, remainingGas, ErrUnsupportedScheme
+	}
+
+	keyId := registryKeyID(scheme, mode, pubKey)
+	state := accessibleState.GetStateDB()
+
+	header := state.GetState(ContractAddress, keyId)
+	if !isRegistryZeroHash(header) {
+		// Idempotent: the stored key is byte-identical to what a second
+		// register call with the same keyId would write, so there is
+		// nothing to do.
+		return keyId.Bytes(), remainingGas, nil
+	}
+
+	state.SetState(ContractAddress, keyId, registryHeaderWord(scheme, mode, pubKeyLen))
+	for i := 0; i*registryWordSize < len(pubKey); i++ {
+		state.SetState(ContractAddress, registryChunkSlot(keyId, i), registryChunkWord(pubKey, i))
+	}
+
+	return keyId.Bytes(), remainingGas, nil
+}
+
+// verifyByID verifies sig over msg using a previously registered key.
+//
+// Input: [keyId(32)] [msgLen(4)] [msg] [sig]
+// Output: 32-byte word, 1 iff the signature verifies.
+func (p *pqCryptoPrecompile) verifyByID(accessibleState contract.AccessibleState, input []byte, remainingGas uint64) ([]byte, uint64, error) {
+	if len(input) < 36 {
+		return nil, remainingGas, errInvalidInput
+	}
+	keyId := common.BytesToHash(input[:32])
+	msgLen := binary.BigEndian.Uint32(input[32:36])
+	if uint64(len(input)-36) < uint64(msgLen) {
+		return nil, remainingGas, errInvalidInput
+	}
+	message := input[36 : 36+msgLen]
+	signature := input[36+msgLen:]
+
+	state := accessibleState.GetStateDB()
+	header := state.GetState(ContractAddress, keyId)
+	if isRegistryZeroHash(header) {
+		return nil, remainingGas, ErrKeyNotRegistered
+	}
+	scheme, mode, pubKeyLen := parseRegistryHeaderWord(header)
+
+	pubKey := make([]byte, 0, pubKeyLen)
+	for i := 0; len(pubKey) < pubKeyLen; i++ {
+		word := state.GetState(ContractAddress, registryChunkSlot(keyId, i))
+		remaining := pubKeyLen - len(pubKey)
+		if remaining > registryWordSize {
+			remaining = registryWordSize
+		}
+		pubKey = append(pubKey, word.Bytes()[:remaining]...)
+	}
+
+	valid, err := verifyRegisteredKey(scheme, mode, pubKey, message, signature)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	result := make([]byte, 32)
+	if valid {
+		result[31] = 1
+	}
+	return result, remainingGas, nil
+}
+
+func verifyRegisteredKey(scheme RegistryScheme, mode byte, pubKey, message, signature []byte) (bool, error) {
+	switch scheme {
+	case RegistrySchemeMLDSA:
+		pub, err := mldsa.PublicKeyFromBytes(pubKey, mldsa.Mode(mode))
+		if err != nil {
+			return false, err
+		}
+		return pub.Verify(message, signature, nil), nil
+	case RegistrySchemeSLHDSA:
+		pub, err := slhdsa.PublicKeyFromBytes(pubKey, slhdsa.Mode(mode))
+		if err != nil {
+			return false, err
+		}
+		return pub.Verify(message, signature, nil), nil
+	default:
+		return false, ErrUnsupportedScheme
+	}
+}
+
+// registryKeyID computes keccak256(scheme || mode || pubkey), matching the
+// keying scheme popregistry.IsRegistered's callers expect elsewhere in this
+// repo: the key's identity is entirely a function of the key bytes, never
+// of who registered it or when.
+func registryKeyID(scheme RegistryScheme, mode byte, pubKey []byte) common.Hash {
+	buf := make([]byte, 2+len(pubKey))
+	buf[0] = byte(scheme)
+	buf[1] = mode
+	copy(buf[2:], pubKey)
+	return crypto.Keccak256Hash(buf)
+}
+
+// registryHeaderWord packs {scheme, mode, pubKeyLen} into keyId's own
+// storage slot, so "is this keyId registered" is a single GetState call.
+func registryHeaderWord(scheme RegistryScheme, mode byte, pubKeyLen int) common.Hash {
+	var word [32]byte
+	word[0] = byte(scheme)
+	word[1] = mode
+	binary.BigEndian.PutUint16(word[2:4], uint16(pubKeyLen))
+	return common.Hash(word)
+}
+
+func parseRegistryHeaderWord(word common.Hash) (scheme RegistryScheme, mode byte, pubKeyLen int) {
+	scheme = RegistryScheme(word[0])
+	mode = word[1]
+	pubKeyLen = int(binary.BigEndian.Uint16(word[2:4]))
+	return scheme, mode, pubKeyLen
+}
+
+func registryChunkWord(pubKey []byte, chunk int) common.Hash {
+	var word [32]byte
+	start := chunk * registryWordSize
+	end := start + registryWordSize
+	if end > len(pubKey) {
+		end = len(pubKey)
+	}
+	copy(word[:], pubKey[start:end])
+	return common.Hash(word)
+}
+
+// registryChunkSlot derives a distinct storage slot for chunk i of a
+// registered key, mirroring popregistry.deriveSlot's keyHash-plus-offset
+// pattern so unrelated keyIds never collide across chunks.
+func registryChunkSlot(keyId common.Hash, chunk int) common.Hash {
+	buf := make([]byte, 36)
+	copy(buf[:32], keyId.Bytes())
+	binary.BigEndian.PutUint32(buf[32:], uint32(chunk+1))
+	return crypto.Keccak256Hash(buf)
+}
+
+func isRegistryZeroHash(h common.Hash) bool {
+	return h == common.Hash{}
+}
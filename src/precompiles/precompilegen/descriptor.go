@@ -0,0 +1,105 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Command precompilegen generates Solidity interfaces and Go client bindings
+// for the hand-packed calldata layouts used by the PQ signature precompiles
+// (mldsa, slhdsa, frost, ringtailthreshold). It is analogous to subnet-evm's
+// precompile_bind.go, but instead of deriving bindings from an ABI JSON file
+// it derives them from a small per-precompile descriptor describing the raw
+// byte layout the precompile's Run method expects.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// FieldKind identifies how a Field is encoded in the raw calldata layout.
+type FieldKind string
+
+const (
+	// KindUint8 is a single fixed byte, e.g. a mode or version discriminator.
+	KindUint8 FieldKind = "uint8"
+	// KindLenPrefixedBytes is a variable-length byte string preceded by a
+	// big-endian length prefix of LenBytes bytes.
+	KindLenPrefixedBytes FieldKind = "lenPrefixedBytes"
+	// KindFixedBytes is a fixed-size byte string with no length prefix.
+	KindFixedBytes FieldKind = "fixedBytes"
+	// KindUint32 is a fixed 4-byte big-endian unsigned integer, e.g. a
+	// threshold or total-signer count.
+	KindUint32 FieldKind = "uint32"
+	// KindTailBytes is a variable-length byte string with no length
+	// prefix, consuming the remainder of the input. Only valid as the
+	// last field in a Descriptor, mirroring precompiles that treat the
+	// trailing bytes as "whatever is left" (e.g. the signature in
+	// SLHDSAPrecompile.Run).
+	KindTailBytes FieldKind = "tailBytes"
+	// KindUint256LenPrefixedBytes is a variable-length byte string
+	// preceded by a full 32-byte big-endian uint256 length, as used by
+	// the ML-DSA precompile's message length field.
+	KindUint256LenPrefixedBytes FieldKind = "uint256LenPrefixedBytes"
+)
+
+// Field describes one component of a precompile's packed input layout, in
+// the order it appears on the wire.
+type Field struct {
+	// Name is the Go/Solidity identifier for this field.
+	Name string `json:"name"`
+	// Kind selects the wire encoding for this field.
+	Kind FieldKind `json:"kind"`
+	// LenBytes is the width of the length prefix for KindLenPrefixedBytes
+	// fields (1, 2, or 4).
+	LenBytes int `json:"lenBytes,omitempty"`
+	// Size is the fixed width in bytes for KindFixedBytes fields.
+	Size int `json:"size,omitempty"`
+}
+
+// Descriptor fully describes one precompile's calldata layout and output
+// shape, enough to emit both a Solidity binding and a Go client helper.
+type Descriptor struct {
+	// Package is the Go package name for the generated bindings file.
+	Package string `json:"package"`
+	// ContractName is the name used for the generated Solidity
+	// interface/library and the Go helper type.
+	ContractName string `json:"contractName"`
+	// Address is the precompile's deployed address, e.g.
+	// "0x0200000000000000000000000000000000000007".
+	Address string `json:"address"`
+	// Fields is the ordered list of input fields making up the raw
+	// calldata layout, e.g. [mode][pubKeyLen][pubKey][msgLen][message][sig].
+	Fields []Field `json:"fields"`
+	// ReturnsBool is true if the precompile returns a single boolean word,
+	// as all the verify precompiles in this repo do today.
+	ReturnsBool bool `json:"returnsBool"`
+}
+
+// LoadDescriptor reads and validates a Descriptor from a JSON file.
+func LoadDescriptor(path string) (*Descriptor, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading descriptor %s: %w", path, err)
+	}
+	var d Descriptor
+	if err := json.Unmarshal(raw, &d); err != nil {
+		return nil, fmt.Errorf("parsing descriptor %s: %w", path, err)
+	}
+	if d.Package == "" || d.ContractName == "" || d.Address == "" {
+		return nil, fmt.Errorf("descriptor %s: package, contractName and address are required", path)
+	}
+	if len(d.Fields) == 0 {
+		return nil, fmt.Errorf("descriptor %s: at least one field is required", path)
+	}
+	for i, f := range d.Fields {
+		if f.Kind == KindLenPrefixedBytes && f.LenBytes == 0 {
+			return nil, fmt.Errorf("descriptor %s: field %q is lenPrefixedBytes but has no lenBytes", path, f.Name)
+		}
+		if f.Kind == KindFixedBytes && f.Size == 0 {
+			return nil, fmt.Errorf("descriptor %s: field %q is fixedBytes but has no size", path, f.Name)
+		}
+		if f.Kind == KindTailBytes && i != len(d.Fields)-1 {
+			return nil, fmt.Errorf("descriptor %s: field %q is tailBytes but is not the last field", path, f.Name)
+		}
+	}
+	return &d, nil
+}
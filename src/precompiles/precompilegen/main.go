@@ -0,0 +1,62 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	var (
+		descriptorPath = flag.String("descriptor", "", "path to a precompile descriptor JSON file")
+		outDir         = flag.String("out", ".", "directory to write generated .sol and _bindings.go files into")
+	)
+	flag.Parse()
+
+	if *descriptorPath == "" {
+		fmt.Fprintln(os.Stderr, "usage: precompilegen -descriptor <path.json> -out <dir>")
+		os.Exit(2)
+	}
+
+	if err := run(*descriptorPath, *outDir); err != nil {
+		fmt.Fprintf(os.Stderr, "precompilegen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(descriptorPath, outDir string) error {
+	d, err := LoadDescriptor(descriptorPath)
+	if err != nil {
+		return err
+	}
+
+	sol, err := renderSolidity(d)
+	if err != nil {
+		return err
+	}
+	goCode, err := renderGoBinding(d)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(outDir, 0o755); err != nil {
+		return fmt.Errorf("creating %s: %w", outDir, err)
+	}
+
+	solPath := filepath.Join(outDir, d.ContractName+".sol")
+	if err := os.WriteFile(solPath, sol, 0o644); err != nil {
+		return fmt.Errorf("writing %s: %w", solPath, err)
+	}
+
+	goPath := filepath.Join(outDir, d.ContractName+"_bindings.go")
+	if err := os.WriteFile(goPath, goCode, 0o644); err != nil {
+		return fmt.Errorf("writing %s: %w", goPath, err)
+	}
+
+	fmt.Printf("wrote %s and %s\n", solPath, goPath)
+	return nil
+}
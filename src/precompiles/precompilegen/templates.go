@@ -0,0 +1,199 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+)
+
+var templateFuncs = template.FuncMap{
+	"inc":          func(i int) int { return i + 1 },
+	"fieldSolType": fieldSolType,
+	"fieldGoType":  fieldGoType,
+	"packExpr":     packExpr,
+	"packComment":  packComment,
+}
+
+var solidityTemplate = template.Must(template.New("solidity").Funcs(templateFuncs).Parse(`// SPDX-License-Identifier: LicenseRef-LUX
+pragma solidity ^0.8.19;
+
+// Code generated by precompilegen from a precompile descriptor. DO NOT EDIT.
+
+interface I{{.ContractName}} {
+    function verify(
+{{- range $i, $f := .Fields}}
+        {{fieldSolType $f}} {{$f.Name}}{{if lt (inc $i) (len $.Fields)}},{{end}}
+{{- end}}
+    ) external view returns (bool valid);
+}
+
+library {{.ContractName}}Lib {
+    address constant PRECOMPILE_ADDRESS = {{.Address}};
+
+    // pack encodes the arguments into the raw layout the precompile expects:
+{{- range .Fields}}
+    //   {{packComment .}}
+{{- end}}
+    function pack(
+{{- range $i, $f := .Fields}}
+        {{fieldSolType $f}} {{$f.Name}}{{if lt (inc $i) (len $.Fields)}},{{end}}
+{{- end}}
+    ) internal pure returns (bytes memory input) {
+        input = abi.encodePacked(
+{{- range $i, $f := .Fields}}
+            {{packExpr $f}}{{if lt (inc $i) (len $.Fields)}},{{end}}
+{{- end}}
+        );
+    }
+
+    function verify(
+{{- range $i, $f := .Fields}}
+        {{fieldSolType $f}} {{$f.Name}}{{if lt (inc $i) (len $.Fields)}},{{end}}
+{{- end}}
+    ) internal view returns (bool valid) {
+        bytes memory input = pack(
+{{- range $i, $f := .Fields}}
+            {{.Name}}{{if lt (inc $i) (len $.Fields)}},{{end}}
+{{- end}}
+        );
+        (bool ok, bytes memory out) = PRECOMPILE_ADDRESS.staticcall(input);
+        require(ok, "{{.ContractName}}: precompile call failed");
+        valid = abi.decode(out, (bool));
+    }
+}
+`))
+
+var goBindingTemplate = template.Must(template.New("goBinding").Funcs(templateFuncs).Parse(`// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Code generated by precompilegen from a precompile descriptor. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/luxfi/geth/common"
+)
+
+// Contract{{.ContractName}}Address is the deployed address of the {{.ContractName}} precompile.
+var Contract{{.ContractName}}Address = common.HexToAddress("{{.Address}}")
+
+// Pack{{.ContractName}}Input packs its arguments into the raw calldata layout
+// the {{.ContractName}} precompile expects:
+{{- range .Fields}}
+//   {{packComment .}}
+{{- end}}
+func Pack{{.ContractName}}Input(
+{{- range $i, $f := .Fields}}
+	{{.Name}} {{fieldGoType $f}}{{if lt (inc $i) (len $.Fields)}},{{end}}
+{{- end}}
+) ([]byte, error) {
+	var out []byte
+{{- range .Fields}}
+{{- if eq .Kind "lenPrefixedBytes"}}
+	if len({{.Name}}) > (1<<(8*{{.LenBytes}}))-1 {
+		return nil, fmt.Errorf("{{.Name}} too long: %d bytes", len({{.Name}}))
+	}
+	{{.Name}}Len := make([]byte, {{.LenBytes}})
+	switch {{.LenBytes}} {
+	case 1:
+		{{.Name}}Len[0] = byte(len({{.Name}}))
+	case 2:
+		binary.BigEndian.PutUint16({{.Name}}Len, uint16(len({{.Name}})))
+	case 4:
+		binary.BigEndian.PutUint32({{.Name}}Len, uint32(len({{.Name}})))
+	}
+	out = append(out, {{.Name}}Len...)
+	out = append(out, {{.Name}}...)
+{{- else if eq .Kind "uint256LenPrefixedBytes"}}
+	{{.Name}}Len := make([]byte, 32)
+	binary.BigEndian.PutUint64({{.Name}}Len[24:], uint64(len({{.Name}})))
+	out = append(out, {{.Name}}Len...)
+	out = append(out, {{.Name}}...)
+{{- else if eq .Kind "uint8"}}
+	out = append(out, {{.Name}})
+{{- else if eq .Kind "uint32"}}
+	{{.Name}}Bytes := make([]byte, 4)
+	binary.BigEndian.PutUint32({{.Name}}Bytes, {{.Name}})
+	out = append(out, {{.Name}}Bytes...)
+{{- else}}
+	out = append(out, {{.Name}}...)
+{{- end}}
+{{- end}}
+	return out, nil
+}
+`))
+
+func fieldSolType(f Field) string {
+	switch f.Kind {
+	case KindUint8:
+		return "uint8"
+	case KindUint32:
+		return "uint32"
+	case KindLenPrefixedBytes, KindFixedBytes, KindUint256LenPrefixedBytes, KindTailBytes:
+		return "bytes"
+	default:
+		return "bytes"
+	}
+}
+
+func fieldGoType(f Field) string {
+	switch f.Kind {
+	case KindUint8:
+		return "byte"
+	case KindUint32:
+		return "uint32"
+	default:
+		return "[]byte"
+	}
+}
+
+func packExpr(f Field) string {
+	switch f.Kind {
+	case KindLenPrefixedBytes:
+		width := f.LenBytes * 8
+		return fmt.Sprintf("uint%d(%s.length), %s", width, f.Name, f.Name)
+	case KindUint256LenPrefixedBytes:
+		return fmt.Sprintf("uint256(%s.length), %s", f.Name, f.Name)
+	default:
+		return f.Name
+	}
+}
+
+func packComment(f Field) string {
+	switch f.Kind {
+	case KindLenPrefixedBytes:
+		return fmt.Sprintf("%s: %d-byte big-endian length prefix followed by the bytes", f.Name, f.LenBytes)
+	case KindUint256LenPrefixedBytes:
+		return fmt.Sprintf("%s: 32-byte big-endian uint256 length prefix followed by the bytes", f.Name)
+	case KindFixedBytes:
+		return fmt.Sprintf("%s: fixed %d bytes", f.Name, f.Size)
+	case KindUint32:
+		return fmt.Sprintf("%s: 4-byte big-endian uint32", f.Name)
+	case KindTailBytes:
+		return fmt.Sprintf("%s: remaining bytes, no length prefix", f.Name)
+	default:
+		return fmt.Sprintf("%s: 1 byte", f.Name)
+	}
+}
+
+func renderSolidity(d *Descriptor) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := solidityTemplate.Execute(&buf, d); err != nil {
+		return nil, fmt.Errorf("rendering solidity: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func renderGoBinding(d *Descriptor) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := goBindingTemplate.Execute(&buf, d); err != nil {
+		return nil, fmt.Errorf("rendering go binding: %w", err)
+	}
+	return buf.Bytes(), nil
+}
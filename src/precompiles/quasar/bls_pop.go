@@ -0,0 +1,210 @@
+// Copyright (C) 2025, Lux Industries Inc All rights reserved.
+// Quasar Consensus Precompiles for Hyper-Efficient On-Chain Verification
+
+package quasar
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/luxfi/crypto/bls"
+	"github.com/luxfi/evm/precompile/contract"
+	"github.com/luxfi/geth/common"
+	"github.com/luxfi/geth/core/vm"
+	"github.com/luxfi/geth/crypto"
+)
+
+// blsPoPRegistryPrecompile stores, per compressed BLS pubkey, whether that
+// pubkey has proven possession of its private key (a standard BLS
+// proof-of-possession, verified once at registration time). blsPrecompile,
+// blsAggregatePrecompile, and fastAggregateVerifyPrecompile consult this
+// registry before trusting a caller-supplied pubkey, closing the rogue-key
+// attack a naive aggregate-signature verifier is vulnerable to: without a
+// PoP, an attacker can choose a public key as a function of other parties'
+// keys so that a forged "aggregate" signature verifies against it.
+const (
+	BLSPoPRegistryAddress                = "0x0300000000000000000000000000000000000026"
+	BLSFastAggregateVerifyAddress        = "0x0300000000000000000000000000000000000027"
+	blsPubkeySize                        = 48
+	blsSignatureSize                     = 96
+	blsMessageSize                       = 32
+	opRegisterPubkey              byte   = 0x01
+	opQueryPubkey                 byte   = 0x02
+	BLSPoPRegisterGas             uint64 = 15000
+	BLSPoPQueryGas                uint64 = 1000
+	FastAggregateVerifyPerKeyGas  uint64 = 500
+)
+
+var (
+	_ contract.StatefulPrecompiledContract = &blsPoPRegistryPrecompile{}
+	_ contract.StatefulPrecompiledContract = &fastAggregateVerifyPrecompile{}
+
+	ErrPubkeyNotRegistered = errors.New("quasar: BLS pubkey has not registered a proof of possession")
+)
+
+type blsPoPRegistryPrecompile struct{}
+
+func (b *blsPoPRegistryPrecompile) Address() common.Address {
+	return common.HexToAddress(BLSPoPRegistryAddress)
+}
+
+func (b *blsPoPRegistryPrecompile) RequiredGas(input []byte) uint64 {
+	if len(input) > 0 && input[0] == opRegisterPubkey {
+		return BLSPoPRegisterGas
+	}
+	return BLSPoPQueryGas
+}
+
+// Run dispatches on the leading op byte:
+//
+//	op=0x01 [pubkey(48)] [pop_sig(96)] registers pubkey after verifying
+//	pop_sig is a valid BLS signature by pubkey over keccak256("BLS_POP" || pubkey).
+//	op=0x02 [pubkey(48)] returns [1] if pubkey is registered, [0] otherwise.
+func (b *blsPoPRegistryPrecompile) Run(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) ([]byte, uint64, error) {
+	if len(input) < 1 {
+		return nil, suppliedGas, ErrInvalidInput
+	}
+	gasCost := b.RequiredGas(input)
+	if suppliedGas < gasCost {
+		return nil, 0, vm.ErrOutOfGas
+	}
+	remainingGas := suppliedGas - gasCost
+
+	switch input[0] {
+	case opRegisterPubkey:
+		if readOnly {
+			return nil, remainingGas, errors.New("quasar: cannot register a BLS pubkey in read-only mode")
+		}
+		if len(input) != 1+blsPubkeySize+blsSignatureSize {
+			return nil, remainingGas, fmt.Errorf("%w: expected %d bytes, got %d",
+				ErrInvalidInput, 1+blsPubkeySize+blsSignatureSize, len(input))
+		}
+		pubkeyBytes := input[1 : 1+blsPubkeySize]
+		popSigBytes := input[1+blsPubkeySize:]
+
+		pubKey, err := bls.PublicKeyFromCompressedBytes(pubkeyBytes)
+		if err != nil {
+			return nil, remainingGas, fmt.Errorf("%w: %w", ErrInvalidInput, err)
+		}
+		popSig, err := bls.SignatureFromBytes(popSigBytes)
+		if err != nil {
+			return nil, remainingGas, fmt.Errorf("%w: %w", ErrInvalidInput, err)
+		}
+		if !bls.Verify(pubKey, popSig, popMessage(pubkeyBytes)) {
+			return nil, remainingGas, ErrInvalidSignature
+		}
+
+		state := accessibleState.GetStateDB()
+		state.SetState(b.Address(), pubkeySlot(pubkeyBytes), common.BytesToHash([]byte{1}))
+		return []byte{1}, remainingGas, nil
+
+	case opQueryPubkey:
+		if len(input) != 1+blsPubkeySize {
+			return nil, remainingGas, fmt.Errorf("%w: expected %d bytes, got %d",
+				ErrInvalidInput, 1+blsPubkeySize, len(input))
+		}
+		pubkeyBytes := input[1 : 1+blsPubkeySize]
+		state := accessibleState.GetStateDB()
+		result := make([]byte, 32)
+		if isRegisteredPubkey(state, b.Address(), pubkeyBytes) {
+			result[31] = 1
+		}
+		return result, remainingGas, nil
+
+	default:
+		return nil, remainingGas, fmt.Errorf("%w: unknown op 0x%x", ErrInvalidInput, input[0])
+	}
+}
+
+// popMessage is the message a proof-of-possession signature must cover:
+// keccak256("BLS_POP" || pubkey).
+func popMessage(pubkeyBytes []byte) []byte {
+	return crypto.Keccak256(append([]byte("BLS_POP"), pubkeyBytes...))
+}
+
+// pubkeySlot derives a pubkey's registry storage slot as keccak256(pubkey).
+func pubkeySlot(pubkeyBytes []byte) common.Hash {
+	return crypto.Keccak256Hash(pubkeyBytes)
+}
+
+// isRegisteredPubkey reports whether pubkeyBytes has a registered PoP at
+// registryAddr (ContractBLSPoPRegistry's address, read by the other
+// BLS precompiles that opt into the registry check).
+func isRegisteredPubkey(state contract.StateDB, registryAddr common.Address, pubkeyBytes []byte) bool {
+	return state.GetState(registryAddr, pubkeySlot(pubkeyBytes)) != (common.Hash{})
+}
+
+// fastAggregateVerifyPrecompile verifies a single BLS signature against the
+// aggregate of N registered public keys, all signing the same message - the
+// standard FastAggregateVerify pattern, safe against rogue-key attacks
+// because every contributing pubkey must already hold a registered PoP.
+type fastAggregateVerifyPrecompile struct{}
+
+func (f *fastAggregateVerifyPrecompile) Address() common.Address {
+	return common.HexToAddress(BLSFastAggregateVerifyAddress)
+}
+
+func (f *fastAggregateVerifyPrecompile) RequiredGas(input []byte) uint64 {
+	if len(input) < blsSignatureSize+blsMessageSize+2 {
+		return BLSVerifyGas
+	}
+	n := binary.BigEndian.Uint16(input[blsSignatureSize+blsMessageSize : blsSignatureSize+blsMessageSize+2])
+	return BLSVerifyGas + uint64(n)*FastAggregateVerifyPerKeyGas
+}
+
+// Run verifies that agg_sig is message signed by the aggregate of
+// pubkey_1..pubkey_N.
+//
+// Input: [agg_sig(96)] [message(32)] [N(2)] [pubkey_1..pubkey_N(48 each)]
+func (f *fastAggregateVerifyPrecompile) Run(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) ([]byte, uint64, error) {
+	gasCost := f.RequiredGas(input)
+	if suppliedGas < gasCost {
+		return nil, 0, vm.ErrOutOfGas
+	}
+	remainingGas := suppliedGas - gasCost
+
+	if len(input) < blsSignatureSize+blsMessageSize+2 {
+		return nil, remainingGas, ErrInvalidInput
+	}
+	aggSigBytes := input[0:blsSignatureSize]
+	message := input[blsSignatureSize : blsSignatureSize+blsMessageSize]
+	n := binary.BigEndian.Uint16(input[blsSignatureSize+blsMessageSize : blsSignatureSize+blsMessageSize+2])
+	off := blsSignatureSize + blsMessageSize + 2
+
+	if len(input) != off+int(n)*blsPubkeySize {
+		return nil, remainingGas, fmt.Errorf("%w: expected %d pubkeys worth of input, got %d bytes after header",
+			ErrInvalidInput, n, len(input)-off)
+	}
+	if n == 0 {
+		return nil, remainingGas, ErrInvalidInput
+	}
+
+	state := accessibleState.GetStateDB()
+	pubKeys := make([]*bls.PublicKey, n)
+	for i := 0; i < int(n); i++ {
+		pubkeyBytes := input[off+i*blsPubkeySize : off+(i+1)*blsPubkeySize]
+		if !isRegisteredPubkey(state, common.HexToAddress(BLSPoPRegistryAddress), pubkeyBytes) {
+			return []byte{0}, remainingGas, fmt.Errorf("%w: pubkey %d", ErrPubkeyNotRegistered, i)
+		}
+		pubKey, err := bls.PublicKeyFromCompressedBytes(pubkeyBytes)
+		if err != nil {
+			return []byte{0}, remainingGas, nil
+		}
+		pubKeys[i] = pubKey
+	}
+
+	aggPubKey, err := bls.AggregatePublicKeys(pubKeys)
+	if err != nil {
+		return []byte{0}, remainingGas, nil
+	}
+	aggSig, err := bls.SignatureFromBytes(aggSigBytes)
+	if err != nil {
+		return []byte{0}, remainingGas, nil
+	}
+
+	if bls.Verify(aggPubKey, aggSig, message) {
+		return []byte{1}, remainingGas, nil
+	}
+	return []byte{0}, remainingGas, nil
+}
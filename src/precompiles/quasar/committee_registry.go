@@ -0,0 +1,389 @@
+// Copyright (C) 2025, Lux Industries Inc All rights reserved.
+
+package quasar
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/luxfi/evm/precompile/allowlist"
+	"github.com/luxfi/evm/precompile/contract"
+	"github.com/luxfi/geth/common"
+	"github.com/luxfi/geth/core/vm"
+	"github.com/luxfi/geth/crypto"
+)
+
+// ValidatorRegistryAddress is the address of the committee registry
+// precompile. It stores committees as
+// {committeeID -> {version, bls_keys[], mldsa_keys[], threshold_t, total_n}}
+// so hybridPrecompile and compressedPrecompile can reference a committee by
+// ID and bitfield instead of every call re-supplying raw key material, and
+// so their threshold checks bind to the real registered signer set instead
+// of just counting set bits.
+const ValidatorRegistryAddress = "0x0300000000000000000000000000000000000028"
+
+var (
+	_ contract.StatefulPrecompiledContract = &validatorRegistryPrecompile{}
+
+	// ValidatorRegistryPrecompile is the singleton instance.
+	ValidatorRegistryPrecompile = &validatorRegistryPrecompile{}
+
+	ErrCommitteeNotFound = errors.New("quasar: committee not registered")
+	ErrCommitteeExists   = errors.New("quasar: committee already registered")
+	ErrNotAuthorized     = errors.New("quasar: caller is not allow-listed for committee registry writes")
+	ErrVersionMismatch   = errors.New("quasar: committee version must increase by exactly one on rotation")
+	ErrUnknownSelector   = errors.New("quasar: unknown committee registry selector")
+)
+
+const (
+	selectorRegister     byte = 0x01
+	selectorUpdateRotate byte = 0x02
+	selectorGet          byte = 0x03
+
+	// RegistryRegisterGas is charged for Register/UpdateRotate, scaling
+	// with the number of keys written (two SSTOREs worth of chunks per
+	// signer: one BLS key, one ML-DSA key).
+	RegistryRegisterBaseGas uint64 = 50_000
+	RegistryPerKeyGas       uint64 = 5_000
+
+	// RegistryGetGas is charged for Get, scaling with the number of keys
+	// that must be SLOADed back out of storage.
+	RegistryGetBaseGas     uint64 = 2_100
+	RegistryPerKeySLOADGas uint64 = 2_100
+)
+
+// Storage tags distinguish the logical fields of a committee record; see
+// committeeSlot.
+const (
+	tagMeta     byte = 0x01
+	tagBLSKey   byte = 0x02
+	tagMLDSAKey byte = 0x03
+)
+
+type validatorRegistryPrecompile struct{}
+
+func (v *validatorRegistryPrecompile) Address() common.Address {
+	return common.HexToAddress(ValidatorRegistryAddress)
+}
+
+// RequiredGas estimates gas purely from input, as RequiredGas must: for
+// Register/UpdateRotate it reads total_n directly out of the calldata
+// header; for Get, the caller must pass the committee size it expects back
+// so the read cost can likewise be priced before any state access (Run
+// verifies that expectation against the stored committee).
+func (v *validatorRegistryPrecompile) RequiredGas(input []byte) uint64 {
+	if len(input) < 1 {
+		return 0
+	}
+	switch input[0] {
+	case selectorRegister, selectorUpdateRotate:
+		return RegistryRegisterBaseGas + uint64(peekTotalN(input[1:]))*RegistryPerKeyGas
+	case selectorGet:
+		return RegistryGetBaseGas + uint64(peekExpectedN(input[1:]))*RegistryPerKeySLOADGas
+	default:
+		return 0
+	}
+}
+
+func peekTotalN(body []byte) uint32 {
+	if len(body) < 44 {
+		return 0
+	}
+	return binary.BigEndian.Uint32(body[40:44])
+}
+
+func peekExpectedN(body []byte) uint32 {
+	if len(body) != 36 {
+		return 0
+	}
+	return binary.BigEndian.Uint32(body[32:36])
+}
+
+// Run dispatches to register/updateRotate/get based on the first input
+// byte, mirroring popregistry's selector-byte convention.
+func (v *validatorRegistryPrecompile) Run(
+	accessibleState contract.AccessibleState,
+	caller common.Address,
+	addr common.Address,
+	input []byte,
+	suppliedGas uint64,
+	readOnly bool,
+) ([]byte, uint64, error) {
+	if len(input) < 1 {
+		return nil, suppliedGas, ErrInvalidInput
+	}
+	gasCost := v.RequiredGas(input)
+	if gasCost == 0 {
+		return nil, suppliedGas, fmt.Errorf("%w: 0x%x", ErrUnknownSelector, input[0])
+	}
+	if suppliedGas < gasCost {
+		return nil, 0, vm.ErrOutOfGas
+	}
+	remainingGas := suppliedGas - gasCost
+
+	switch input[0] {
+	case selectorRegister:
+		if readOnly {
+			return nil, remainingGas, errors.New("cannot register a committee in read-only mode")
+		}
+		return v.register(accessibleState, caller, input[1:], remainingGas)
+	case selectorUpdateRotate:
+		if readOnly {
+			return nil, remainingGas, errors.New("cannot rotate a committee in read-only mode")
+		}
+		return v.updateRotate(accessibleState, caller, input[1:], remainingGas)
+	case selectorGet:
+		return v.get(accessibleState, input[1:], remainingGas)
+	default:
+		return nil, remainingGas, fmt.Errorf("%w: 0x%x", ErrUnknownSelector, input[0])
+	}
+}
+
+// committeeInput is the parsed body of a Register/UpdateRotate call:
+//
+//	[committeeID(32)] [version(4)] [threshold_t(4)] [total_n(4)]
+//	[bls_keys: total_n * 48 bytes]
+//	{ [mldsaKeyLen(2)] [mldsaMode(1)] [mldsaKey] }*total_n
+type committeeInput struct {
+	committeeID common.Hash
+	version     uint32
+	threshold   uint32
+	totalN      uint32
+	blsKeys     [][]byte
+	mldsaModes  []byte
+	mldsaKeys   [][]byte
+}
+
+func parseCommitteeInput(body []byte) (*committeeInput, error) {
+	if len(body) < 44 {
+		return nil, ErrInvalidInput
+	}
+	committeeID := common.BytesToHash(body[0:32])
+	version := binary.BigEndian.Uint32(body[32:36])
+	threshold := binary.BigEndian.Uint32(body[36:40])
+	totalN := binary.BigEndian.Uint32(body[40:44])
+	if threshold == 0 || threshold > totalN {
+		return nil, ErrThresholdNotMet
+	}
+
+	offset := 44
+	blsKeysEnd := offset + int(totalN)*blsPubkeySize
+	if len(body) < blsKeysEnd {
+		return nil, ErrInvalidInput
+	}
+	blsKeys := make([][]byte, totalN)
+	for i := uint32(0); i < totalN; i++ {
+		blsKeys[i] = body[offset+int(i)*blsPubkeySize : offset+int(i+1)*blsPubkeySize]
+	}
+	offset = blsKeysEnd
+
+	mldsaModes := make([]byte, totalN)
+	mldsaKeys := make([][]byte, totalN)
+	for i := uint32(0); i < totalN; i++ {
+		if len(body) < offset+3 {
+			return nil, ErrInvalidInput
+		}
+		keyLen := int(binary.BigEndian.Uint16(body[offset : offset+2]))
+		mldsaModes[i] = body[offset+2]
+		offset += 3
+		if len(body) < offset+keyLen {
+			return nil, ErrInvalidInput
+		}
+		mldsaKeys[i] = body[offset : offset+keyLen]
+		offset += keyLen
+	}
+	if offset != len(body) {
+		return nil, ErrInvalidInput
+	}
+
+	return &committeeInput{
+		committeeID: committeeID,
+		version:     version,
+		threshold:   threshold,
+		totalN:      totalN,
+		blsKeys:     blsKeys,
+		mldsaModes:  mldsaModes,
+		mldsaKeys:   mldsaKeys,
+	}, nil
+}
+
+func (v *validatorRegistryPrecompile) register(accessibleState contract.AccessibleState, caller common.Address, body []byte, remainingGas uint64) ([]byte, uint64, error) {
+	state := accessibleState.GetStateDB()
+	if !allowlist.GetAllowListStatus(state, v.Address(), caller).IsEnabled() {
+		return nil, remainingGas, ErrNotAuthorized
+	}
+
+	in, err := parseCommitteeInput(body)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	metaSlot := committeeSlot(in.committeeID, tagMeta, 0)
+	if state.GetState(v.Address(), metaSlot) != (common.Hash{}) {
+		return nil, remainingGas, ErrCommitteeExists
+	}
+
+	writeCommittee(state, v.Address(), in)
+	return []byte{1}, remainingGas, nil
+}
+
+func (v *validatorRegistryPrecompile) updateRotate(accessibleState contract.AccessibleState, caller common.Address, body []byte, remainingGas uint64) ([]byte, uint64, error) {
+	state := accessibleState.GetStateDB()
+	if !allowlist.GetAllowListStatus(state, v.Address(), caller).IsEnabled() {
+		return nil, remainingGas, ErrNotAuthorized
+	}
+
+	in, err := parseCommitteeInput(body)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	metaSlot := committeeSlot(in.committeeID, tagMeta, 0)
+	existing := state.GetState(v.Address(), metaSlot)
+	if existing == (common.Hash{}) {
+		return nil, remainingGas, ErrCommitteeNotFound
+	}
+	existingVersion, _, _ := decodeMeta(existing)
+	if in.version != existingVersion+1 {
+		return nil, remainingGas, ErrVersionMismatch
+	}
+
+	writeCommittee(state, v.Address(), in)
+	return []byte{1}, remainingGas, nil
+}
+
+func writeCommittee(state contract.StateDB, addr common.Address, in *committeeInput) {
+	state.SetState(addr, committeeSlot(in.committeeID, tagMeta, 0), encodeMeta(in.version, in.threshold, in.totalN))
+	for i := uint32(0); i < in.totalN; i++ {
+		storeBytes(state, addr, committeeSlot(in.committeeID, tagBLSKey, i), in.blsKeys[i])
+		mldsaRecord := append([]byte{in.mldsaModes[i]}, in.mldsaKeys[i]...)
+		storeBytes(state, addr, committeeSlot(in.committeeID, tagMLDSAKey, i), mldsaRecord)
+	}
+}
+
+// get returns {version, threshold_t, total_n, bls_keys[], mldsa_keys[]} for
+// a committee.
+//
+// Input: [committeeID(32)] [expectedN(4)]
+func (v *validatorRegistryPrecompile) get(accessibleState contract.AccessibleState, body []byte, remainingGas uint64) ([]byte, uint64, error) {
+	if len(body) != 36 {
+		return nil, remainingGas, ErrInvalidInput
+	}
+	committeeID := common.BytesToHash(body[0:32])
+	expectedN := binary.BigEndian.Uint32(body[32:36])
+
+	state := accessibleState.GetStateDB()
+	version, threshold, totalN, ok := lookupCommitteeMeta(state, committeeID)
+	if !ok {
+		return nil, remainingGas, ErrCommitteeNotFound
+	}
+	if totalN != expectedN {
+		return nil, remainingGas, fmt.Errorf("%w: committee %s has %d signers, expected %d", ErrInvalidInput, committeeID, totalN, expectedN)
+	}
+
+	out := make([]byte, 12)
+	binary.BigEndian.PutUint32(out[0:4], version)
+	binary.BigEndian.PutUint32(out[4:8], threshold)
+	binary.BigEndian.PutUint32(out[8:12], totalN)
+	for i := uint32(0); i < totalN; i++ {
+		out = append(out, loadBytes(state, v.Address(), committeeSlot(committeeID, tagBLSKey, i))...)
+	}
+	for i := uint32(0); i < totalN; i++ {
+		out = append(out, loadBytes(state, v.Address(), committeeSlot(committeeID, tagMLDSAKey, i))...)
+	}
+	return out, remainingGas, nil
+}
+
+// lookupCommitteeMeta, lookupBLSKey and lookupMLDSAKey let hybridPrecompile
+// and compressedPrecompile read a registered committee directly from their
+// own Run methods, the same way popregistry.IsRegistered lets frost consult
+// its registry without an inter-precompile call.
+func lookupCommitteeMeta(state contract.StateDB, committeeID common.Hash) (version, threshold, totalN uint32, ok bool) {
+	registryAddr := common.HexToAddress(ValidatorRegistryAddress)
+	meta := state.GetState(registryAddr, committeeSlot(committeeID, tagMeta, 0))
+	if meta == (common.Hash{}) {
+		return 0, 0, 0, false
+	}
+	version, threshold, totalN = decodeMeta(meta)
+	return version, threshold, totalN, true
+}
+
+func lookupBLSKey(state contract.StateDB, committeeID common.Hash, index uint32) []byte {
+	registryAddr := common.HexToAddress(ValidatorRegistryAddress)
+	return loadBytes(state, registryAddr, committeeSlot(committeeID, tagBLSKey, index))
+}
+
+func lookupMLDSAKey(state contract.StateDB, committeeID common.Hash, index uint32) (mode byte, key []byte) {
+	registryAddr := common.HexToAddress(ValidatorRegistryAddress)
+	raw := loadBytes(state, registryAddr, committeeSlot(committeeID, tagMLDSAKey, index))
+	if len(raw) == 0 {
+		return 0, nil
+	}
+	return raw[0], raw[1:]
+}
+
+func encodeMeta(version, threshold, totalN uint32) common.Hash {
+	var word common.Hash
+	binary.BigEndian.PutUint32(word[0:4], version)
+	binary.BigEndian.PutUint32(word[4:8], threshold)
+	binary.BigEndian.PutUint32(word[8:12], totalN)
+	return word
+}
+
+func decodeMeta(word common.Hash) (version, threshold, totalN uint32) {
+	version = binary.BigEndian.Uint32(word[0:4])
+	threshold = binary.BigEndian.Uint32(word[4:8])
+	totalN = binary.BigEndian.Uint32(word[8:12])
+	return version, threshold, totalN
+}
+
+// committeeSlot derives a distinct storage slot for each logical field of a
+// committee record by hashing the committeeID together with a field tag
+// and an index, avoiding collisions between records, fields, and signers.
+func committeeSlot(committeeID common.Hash, tag byte, index uint32) common.Hash {
+	var buf [37]byte
+	copy(buf[:32], committeeID.Bytes())
+	buf[32] = tag
+	binary.BigEndian.PutUint32(buf[33:37], index)
+	return crypto.Keccak256Hash(buf[:])
+}
+
+// storeBytes and loadBytes persist an arbitrary-length byte string across
+// storage slots derived from baseSlot, the same length-prefixed chunking
+// Solidity itself uses for dynamic bytes/arrays: baseSlot holds the length,
+// and wordSlot(baseSlot, i) holds the i-th 32-byte chunk.
+func storeBytes(state contract.StateDB, addr common.Address, baseSlot common.Hash, data []byte) {
+	state.SetState(addr, baseSlot, common.BytesToHash(big.NewInt(int64(len(data))).Bytes()))
+	for i := 0; i*32 < len(data); i++ {
+		end := (i + 1) * 32
+		if end > len(data) {
+			end = len(data)
+		}
+		var word common.Hash
+		copy(word[:], data[i*32:end])
+		state.SetState(addr, wordSlot(baseSlot, uint32(i)), word)
+	}
+}
+
+func loadBytes(state contract.StateDB, addr common.Address, baseSlot common.Hash) []byte {
+	length := new(big.Int).SetBytes(state.GetState(addr, baseSlot).Bytes()).Int64()
+	data := make([]byte, length)
+	for i := 0; int64(i)*32 < length; i++ {
+		word := state.GetState(addr, wordSlot(baseSlot, uint32(i)))
+		end := int64(i+1) * 32
+		if end > length {
+			end = length
+		}
+		copy(data[int64(i)*32:end], word.Bytes())
+	}
+	return data
+}
+
+func wordSlot(baseSlot common.Hash, word uint32) common.Hash {
+	var buf [36]byte
+	copy(buf[:32], baseSlot.Bytes())
+	binary.BigEndian.PutUint32(buf[32:36], word)
+	return crypto.Keccak256Hash(buf[:])
+}
@@ -4,6 +4,7 @@
 package quasar
 
 import (
+	"encoding/binary"
 	"errors"
 
 	"github.com/luxfi/crypto/bls"
@@ -15,6 +16,11 @@ import (
 
 const (
 	// Gas costs (optimized for Verkle witnesses)
+	//
+	// VerkleVerifyGas prices VerifyIPA's folding check, which is genuine
+	// but runs over a stand-in group, not Bandersnatch/Banderwagon -- see
+	// VerkleWitnessesAreGoVerkleCompatible in verkle.go before relying on
+	// this for real go-verkle witnesses.
 	VerkleVerifyGas     = 3000  // Ultra-fast with PQ finality assumption
 	BLSVerifyGas        = 5000  // BLS aggregate verification
 	BLSAggregateGas     = 2000  // BLS signature aggregation
@@ -22,6 +28,17 @@ const (
 	HybridVerifyGas     = 10000 // BLS+Ringtail hybrid verification
 	CompressedVerifyGas = 1000  // Compressed witness verification
 
+	// Registry-referenced mode (see committee_registry.go) gas, scaling
+	// with the committee's bitfield length rather than a flat cost.
+	HybridRegistryBaseGas       uint64 = 20000
+	HybridRegistryPerBitGas     uint64 = 3000
+	CompressedRegistryPerBitGas uint64 = 200
+
+	// hybridModeRegistry is the leading mode byte that switches
+	// hybridPrecompile and compressedPrecompile from their legacy
+	// raw-key/bitfield-only input formats to the registry-referenced one.
+	hybridModeRegistry byte = 0x01
+
 	// Precompile addresses
 	VerkleVerifyAddress   = "0x0300000000000000000000000000000000000020"
 	BLSVerifyAddress      = "0x0300000000000000000000000000000000000021"
@@ -41,7 +58,12 @@ var (
 	ErrThresholdNotMet  = errors.New("threshold not met")
 )
 
-// verklePrecompile verifies Verkle witnesses with PQ finality assumption
+// verklePrecompile checks IPA opening proofs with a PQ finality assumption.
+// See VerkleWitnessesAreGoVerkleCompatible in verkle.go: this does not
+// accept real go-verkle witnesses today, which is also why
+// GetAllPrecompiles does not register it at VerkleVerifyAddress. It's
+// kept here, addressable and testable, for whoever backs verifyVerkleLight
+// with a real Banderwagon implementation to wire back in.
 type verklePrecompile struct{}
 
 func (v *verklePrecompile) Address() common.Address {
@@ -59,24 +81,12 @@ func (v *verklePrecompile) Run(accessibleState contract.AccessibleState, caller
 	}
 	remainingGas = suppliedGas - VerkleVerifyGas
 
-	// Input format: [commitment(32)] [proof(32)] [threshold_met(1)]
-	if len(input) < 65 {
-		return nil, remainingGas, ErrInvalidInput
-	}
-
-	// With PQ finality assumption, just check threshold bit
-	thresholdMet := input[64] > 0
-	if !thresholdMet {
-		return []byte{0}, remainingGas, nil
+	// Input format: [commitment(32)] [eval_point(32)] [eval_value(32)]
+	// [ipa_proof(variable)] [threshold_met(1)] (see verkle.go)
+	valid, err := verifyVerkleLight(input)
+	if err != nil {
+		return nil, remainingGas, err
 	}
-
-	// Lightweight Verkle verification (assumes PQ finality)
-	// In production: verify IPA opening proof
-	commitment := input[:32]
-	proof := input[32:64]
-
-	// Simple hash check for demonstration
-	valid := verifyVerkleLight(commitment, proof)
 	if valid {
 		return []byte{1}, remainingGas, nil
 	}
@@ -101,14 +111,22 @@ func (b *blsPrecompile) Run(accessibleState contract.AccessibleState, caller com
 	}
 	remainingGas = suppliedGas - BLSVerifyGas
 
-	// Input format: [pubkey(48)] [message(32)] [signature(96)]
-	if len(input) < 176 {
+	// Input format: [mode(1)] [pubkey(48)] [message(32)] [signature(96)].
+	// mode=0 verifies pubkey as-is; mode=1 additionally requires pubkey to
+	// hold a registered proof of possession (see bls_pop.go), rejecting
+	// rogue keys before they reach bls.Verify.
+	if len(input) < 1+blsPubkeySize+blsMessageSize+blsSignatureSize {
 		return nil, remainingGas, ErrInvalidInput
 	}
 
-	pubKeyBytes := input[:48]
-	message := input[48:80]
-	sigBytes := input[80:176]
+	mode := input[0]
+	pubKeyBytes := input[1 : 1+blsPubkeySize]
+	message := input[1+blsPubkeySize : 1+blsPubkeySize+blsMessageSize]
+	sigBytes := input[1+blsPubkeySize+blsMessageSize : 1+blsPubkeySize+blsMessageSize+blsSignatureSize]
+
+	if mode == 1 && !isRegisteredPubkey(accessibleState.GetStateDB(), common.HexToAddress(BLSPoPRegistryAddress), pubKeyBytes) {
+		return []byte{0}, remainingGas, nil
+	}
 
 	// Verify BLS signature
 	pubKey, err := bls.PublicKeyFromCompressedBytes(pubKeyBytes)
@@ -136,7 +154,13 @@ func (b *blsAggregatePrecompile) Address() common.Address {
 }
 
 func (b *blsAggregatePrecompile) RequiredGas(input []byte) uint64 {
-	// Gas scales with number of signatures
+	// Legacy mode (no leading mode byte, or mode=0): concatenated 96-byte
+	// signatures. Mode=1: [mode(1)] [N(2)] [sig_1..sig_N(96)]
+	// [pubkey_1..pubkey_N(48)], gas still scaling with N.
+	if len(input) > 0 && input[0] == 1 && len(input) >= 3 {
+		n := binary.BigEndian.Uint16(input[1:3])
+		return BLSAggregateGas * uint64(n)
+	}
 	numSigs := len(input) / 96
 	return BLSAggregateGas * uint64(numSigs)
 }
@@ -148,7 +172,15 @@ func (b *blsAggregatePrecompile) Run(accessibleState contract.AccessibleState, c
 	}
 	remainingGas = suppliedGas - requiredGas
 
-	// Input: concatenated BLS signatures (96 bytes each)
+	if len(input) > 0 && input[0] == 1 {
+		// Registry-checked mode: every contributing pubkey must already hold
+		// a registered proof of possession, closing the rogue-key attack a
+		// bare signature aggregate is vulnerable to.
+		return b.runWithRegistry(accessibleState, input[1:], remainingGas)
+	}
+
+	// Legacy mode - Input: concatenated BLS signatures (96 bytes each),
+	// no pubkey binding.
 	if len(input)%96 != 0 {
 		return nil, remainingGas, ErrInvalidInput
 	}
@@ -174,6 +206,43 @@ func (b *blsAggregatePrecompile) Run(accessibleState contract.AccessibleState, c
 	return bls.SignatureToBytes(aggSig), remainingGas, nil
 }
 
+// runWithRegistry implements mode=1: body is
+// [N(2)] [sig_1..sig_N(96)] [pubkey_1..pubkey_N(48)].
+func (b *blsAggregatePrecompile) runWithRegistry(accessibleState contract.AccessibleState, body []byte, remainingGas uint64) ([]byte, uint64, error) {
+	if len(body) < 2 {
+		return nil, remainingGas, ErrInvalidInput
+	}
+	n := int(binary.BigEndian.Uint16(body[:2]))
+	sigsOff := 2
+	pubKeysOff := sigsOff + n*blsSignatureSize
+	if n == 0 || len(body) != pubKeysOff+n*blsPubkeySize {
+		return nil, remainingGas, ErrInvalidInput
+	}
+
+	state := accessibleState.GetStateDB()
+	signatures := make([]*bls.Signature, 0, n)
+	for i := 0; i < n; i++ {
+		pubKeyBytes := body[pubKeysOff+i*blsPubkeySize : pubKeysOff+(i+1)*blsPubkeySize]
+		if !isRegisteredPubkey(state, common.HexToAddress(BLSPoPRegistryAddress), pubKeyBytes) {
+			return nil, remainingGas, ErrPubkeyNotRegistered
+		}
+
+		sigBytes := body[sigsOff+i*blsSignatureSize : sigsOff+(i+1)*blsSignatureSize]
+		sig, err := bls.SignatureFromBytes(sigBytes)
+		if err != nil {
+			return nil, remainingGas, ErrInvalidSignature
+		}
+		signatures = append(signatures, sig)
+	}
+
+	aggSig, err := bls.AggregateSignatures(signatures)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	return bls.SignatureToBytes(aggSig), remainingGas, nil
+}
+
 // ringtailPrecompile verifies Ringtail (ML-DSA) signatures
 type ringtailPrecompile struct{}
 
@@ -234,10 +303,22 @@ func (h *hybridPrecompile) Address() common.Address {
 }
 
 func (h *hybridPrecompile) RequiredGas(input []byte) uint64 {
+	if len(input) > 0 && input[0] == hybridModeRegistry {
+		return HybridRegistryBaseGas + uint64(peekBitfieldBits(input[1:]))*HybridRegistryPerBitGas
+	}
 	return HybridVerifyGas
 }
 
 func (h *hybridPrecompile) Run(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	// Registry mode (input[0] == hybridModeRegistry) references a committee
+	// registered in the validator registry (see committee_registry.go) by
+	// ID and bitfield instead of embedding raw keys, and enforces the
+	// committee's real threshold_t against the keys the bitfield actually
+	// selects rather than just counting set bits.
+	if len(input) > 0 && input[0] == hybridModeRegistry {
+		return h.runWithRegistry(accessibleState, input[1:], suppliedGas)
+	}
+
 	if suppliedGas < HybridVerifyGas {
 		return nil, 0, vm.ErrOutOfGas
 	}
@@ -289,6 +370,135 @@ func (h *hybridPrecompile) Run(accessibleState contract.AccessibleState, caller
 	return []byte{1}, remainingGas, nil
 }
 
+// runWithRegistry implements hybridPrecompile's registry-referenced mode.
+//
+// Input: [committeeID(32)] [version(4)] [bitfieldLen(2)] [bitfield]
+// [message(32)] [blsAggSig(96)] [mldsaSigCount(2)]
+// { [mldsaSigLen(2)] [mldsaSig] }*mldsaSigCount
+//
+// The bitfield selects which committee signers participated; their BLS
+// keys are aggregated and checked against blsAggSig in one pairing, while
+// their ML-DSA keys (which this repo has no aggregation primitive for)
+// are checked one signature at a time, in bitfield order. The real
+// registered threshold_t is enforced against the selected signer count,
+// not merely the popcount of an unbound bitfield.
+func (h *hybridPrecompile) runWithRegistry(accessibleState contract.AccessibleState, body []byte, suppliedGas uint64) ([]byte, uint64, error) {
+	gasCost := HybridRegistryBaseGas + uint64(peekBitfieldBits(body))*HybridRegistryPerBitGas
+	if suppliedGas < gasCost {
+		return nil, 0, vm.ErrOutOfGas
+	}
+	remainingGas := suppliedGas - gasCost
+
+	if len(body) < 38 {
+		return nil, remainingGas, ErrInvalidInput
+	}
+	committeeID := common.BytesToHash(body[0:32])
+	version := binary.BigEndian.Uint32(body[32:36])
+	bitfieldLen := int(binary.BigEndian.Uint16(body[36:38]))
+	offset := 38
+	if len(body) < offset+bitfieldLen {
+		return nil, remainingGas, ErrInvalidInput
+	}
+	bitfield := body[offset : offset+bitfieldLen]
+	offset += bitfieldLen
+
+	if len(body) < offset+blsMessageSize+blsSignatureSize {
+		return nil, remainingGas, ErrInvalidInput
+	}
+	message := body[offset : offset+blsMessageSize]
+	offset += blsMessageSize
+	blsAggSigBytes := body[offset : offset+blsSignatureSize]
+	offset += blsSignatureSize
+
+	if len(body) < offset+2 {
+		return nil, remainingGas, ErrInvalidInput
+	}
+	mldsaSigCount := int(binary.BigEndian.Uint16(body[offset : offset+2]))
+	offset += 2
+
+	state := accessibleState.GetStateDB()
+	storedVersion, threshold, totalN, ok := lookupCommitteeMeta(state, committeeID)
+	if !ok {
+		return nil, remainingGas, ErrCommitteeNotFound
+	}
+	if storedVersion != version {
+		return nil, remainingGas, ErrVersionMismatch
+	}
+	if uint32(bitfieldLen)*8 < totalN {
+		return nil, remainingGas, ErrInvalidInput
+	}
+
+	selected := make([]uint32, 0, totalN)
+	for i := uint32(0); i < totalN; i++ {
+		if bitfield[i/8]&(1<<(i%8)) != 0 {
+			selected = append(selected, i)
+		}
+	}
+	if uint32(len(selected)) < threshold {
+		return nil, remainingGas, ErrThresholdNotMet
+	}
+	if len(selected) != mldsaSigCount {
+		return nil, remainingGas, ErrInvalidInput
+	}
+
+	blsPubKeys := make([]*bls.PublicKey, 0, len(selected))
+	for _, idx := range selected {
+		pk, err := bls.PublicKeyFromCompressedBytes(lookupBLSKey(state, committeeID, idx))
+		if err != nil {
+			return []byte{0}, remainingGas, nil
+		}
+		blsPubKeys = append(blsPubKeys, pk)
+	}
+	aggBLSPubKey, err := bls.AggregatePublicKeys(blsPubKeys)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	blsAggSig, err := bls.SignatureFromBytes(blsAggSigBytes)
+	if err != nil {
+		return []byte{0}, remainingGas, nil
+	}
+	if !bls.Verify(aggBLSPubKey, blsAggSig, message) {
+		return []byte{0}, remainingGas, nil
+	}
+
+	for _, idx := range selected {
+		if len(body) < offset+2 {
+			return nil, remainingGas, ErrInvalidInput
+		}
+		sigLen := int(binary.BigEndian.Uint16(body[offset : offset+2]))
+		offset += 2
+		if len(body) < offset+sigLen {
+			return nil, remainingGas, ErrInvalidInput
+		}
+		sig := body[offset : offset+sigLen]
+		offset += sigLen
+
+		mode, keyBytes := lookupMLDSAKey(state, committeeID, idx)
+		pk, err := mldsa.PublicKeyFromBytes(keyBytes, mldsa.Mode(mode))
+		if err != nil {
+			return []byte{0}, remainingGas, nil
+		}
+		if !pk.Verify(message, sig, nil) {
+			return []byte{0}, remainingGas, nil
+		}
+	}
+	if offset != len(body) {
+		return nil, remainingGas, ErrInvalidInput
+	}
+
+	return []byte{1}, remainingGas, nil
+}
+
+// peekBitfieldBits reads a registry-mode body's bitfieldLen field (the
+// 2-byte word at offset 36, right after committeeID and version) to price
+// gas purely from input, without needing a committee lookup.
+func peekBitfieldBits(body []byte) uint32 {
+	if len(body) < 38 {
+		return 0
+	}
+	return uint32(binary.BigEndian.Uint16(body[36:38])) * 8
+}
+
 // compressedPrecompile verifies ultra-compressed witnesses
 type compressedPrecompile struct{}
 
@@ -297,10 +507,17 @@ func (c *compressedPrecompile) Address() common.Address {
 }
 
 func (c *compressedPrecompile) RequiredGas(input []byte) uint64 {
+	if len(input) > 0 && input[0] == hybridModeRegistry {
+		return CompressedVerifyGas + uint64(peekBitfieldBits(input[1:]))*CompressedRegistryPerBitGas
+	}
 	return CompressedVerifyGas // Ultra-low gas
 }
 
 func (c *compressedPrecompile) Run(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if len(input) > 0 && input[0] == hybridModeRegistry {
+		return c.runWithRegistry(accessibleState, input[1:], suppliedGas)
+	}
+
 	if suppliedGas < CompressedVerifyGas {
 		return nil, 0, vm.ErrOutOfGas
 	}
@@ -330,27 +547,82 @@ func (c *compressedPrecompile) Run(accessibleState contract.AccessibleState, cal
 	return []byte{0}, remainingGas, nil
 }
 
-// Helper functions
+// runWithRegistry implements compressedPrecompile's registry-referenced
+// mode, binding the threshold check to a real registered committee instead
+// of an arbitrary 32-bit bitfield with a hardcoded 22-of-32 assumption.
+//
+// Input: [committeeID(32)] [version(4)] [bitfieldLen(2)] [bitfield]
+//
+// This precompile's witness format carries no signature of its own (the
+// commitment/proof fields are assumed verified upstream, as in the legacy
+// path above), so registry mode only strengthens the threshold check
+// itself: the bitfield is validated against the committee's actual
+// total_n and threshold_t rather than counted in a vacuum.
+func (c *compressedPrecompile) runWithRegistry(accessibleState contract.AccessibleState, body []byte, suppliedGas uint64) ([]byte, uint64, error) {
+	gasCost := CompressedVerifyGas + uint64(peekBitfieldBits(body))*CompressedRegistryPerBitGas
+	if suppliedGas < gasCost {
+		return nil, 0, vm.ErrOutOfGas
+	}
+	remainingGas := suppliedGas - gasCost
+
+	if len(body) < 38 {
+		return nil, remainingGas, ErrInvalidInput
+	}
+	committeeID := common.BytesToHash(body[0:32])
+	version := binary.BigEndian.Uint32(body[32:36])
+	bitfieldLen := int(binary.BigEndian.Uint16(body[36:38]))
+	if len(body) != 38+bitfieldLen {
+		return nil, remainingGas, ErrInvalidInput
+	}
+	bitfield := body[38 : 38+bitfieldLen]
+
+	state := accessibleState.GetStateDB()
+	storedVersion, threshold, totalN, ok := lookupCommitteeMeta(state, committeeID)
+	if !ok {
+		return nil, remainingGas, ErrCommitteeNotFound
+	}
+	if storedVersion != version {
+		return nil, remainingGas, ErrVersionMismatch
+	}
+	if uint32(bitfieldLen)*8 < totalN {
+		return nil, remainingGas, ErrInvalidInput
+	}
 
-func verifyVerkleLight(commitment, proof []byte) bool {
-	// Simplified Verkle verification
-	// In production: use full IPA verification
-	for i := 0; i < len(commitment) && i < len(proof); i++ {
-		if commitment[i] != proof[i] {
-			return i > 16 // At least half match
+	validatorCount := uint32(0)
+	for i := uint32(0); i < totalN; i++ {
+		if bitfield[i/8]&(1<<(i%8)) != 0 {
+			validatorCount++
 		}
 	}
-	return true
+
+	if validatorCount >= threshold {
+		return []byte{1}, remainingGas, nil
+	}
+	return []byte{0}, remainingGas, nil
 }
 
-// GetAllPrecompiles returns all Quasar precompiles
+// GetAllPrecompiles returns all Quasar precompiles.
+//
+// VerkleVerifyAddress/verklePrecompile is deliberately absent: see
+// VerkleWitnessesAreGoVerkleCompatible in verkle.go. VerifyIPA folds
+// commitments over a 2048-bit multiplicative group, not the Bandersnatch/
+// Banderwagon curve go-verkle actually commits Verkle witnesses over, so
+// a real go-verkle witness will never verify against it. Registering it
+// live at a production precompile address would let a contract call
+// "Verkle verification" and get a confident answer about math that has
+// nothing to do with the Verkle tree it thinks it's checking, which is
+// worse than not shipping it. Re-add it here once verifyVerkleLight is
+// backed by a real Banderwagon implementation.
 func GetAllPrecompiles() map[common.Address]contract.StatefulPrecompiledContract {
 	return map[common.Address]contract.StatefulPrecompiledContract{
-		common.HexToAddress(VerkleVerifyAddress):   &verklePrecompile{},
-		common.HexToAddress(BLSVerifyAddress):      &blsPrecompile{},
-		common.HexToAddress(BLSAggregateAddress):   &blsAggregatePrecompile{},
-		common.HexToAddress(RingtailVerifyAddress): &ringtailPrecompile{},
-		common.HexToAddress(HybridVerifyAddress):   &hybridPrecompile{},
-		common.HexToAddress(CompressedAddress):     &compressedPrecompile{},
+		common.HexToAddress(BLSVerifyAddress):              &blsPrecompile{},
+		common.HexToAddress(BLSAggregateAddress):           &blsAggregatePrecompile{},
+		common.HexToAddress(RingtailVerifyAddress):         &ringtailPrecompile{},
+		common.HexToAddress(HybridVerifyAddress):           &hybridPrecompile{},
+		common.HexToAddress(CompressedAddress):             &compressedPrecompile{},
+		common.HexToAddress(BLSPoPRegistryAddress):         &blsPoPRegistryPrecompile{},
+		common.HexToAddress(BLSFastAggregateVerifyAddress): &fastAggregateVerifyPrecompile{},
+		common.HexToAddress(ValidatorRegistryAddress):      &validatorRegistryPrecompile{},
+		common.HexToAddress(RingtailBatchVerifyAddress):    &ringtailBatchPrecompile{},
 	}
 }
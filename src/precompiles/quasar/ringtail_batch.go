@@ -0,0 +1,177 @@
+// Copyright (C) 2025, Lux Industries Inc All rights reserved.
+
+package quasar
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/luxfi/crypto/mldsa"
+	"github.com/luxfi/evm/precompile/contract"
+	"github.com/luxfi/geth/common"
+	"github.com/luxfi/geth/core/vm"
+)
+
+// RingtailBatchVerifyAddress is the address of the batched ML-DSA
+// verification precompile, a cheaper sibling to ringtailPrecompile for
+// callers that already have N (pubkey, message, signature) tuples to
+// check in one call instead of N separate ones.
+const RingtailBatchVerifyAddress = "0x0300000000000000000000000000000000000029"
+
+const (
+	RingtailBatchBaseGas uint64 = 5_000
+
+	// RingtailBatchPerSigGas is priced at the same cost as an individual
+	// ringtailPrecompile verification rather than the 40-60% discount true
+	// algebraic batching would allow. See batchVerifyMLDSA's doc comment:
+	// this repo has no access to the ML-DSA library's internal NTT/matrix
+	// representation, so each entry still costs one real Verify call, and
+	// charging less than that would underprice gas for the work done.
+	RingtailBatchPerSigGas uint64 = RingtailVerifyGas
+
+	// maxBatchEntries bounds N to what the 256-bit failure bitmap can name.
+	maxBatchEntries = 256
+)
+
+var (
+	_ contract.StatefulPrecompiledContract = &ringtailBatchPrecompile{}
+
+	// RingtailBatchPrecompile is the singleton instance.
+	RingtailBatchPrecompile = &ringtailBatchPrecompile{}
+
+	ErrBatchTooLarge = errors.New("quasar: batch verify entry count exceeds the 256-bit failure bitmap")
+)
+
+type ringtailBatchPrecompile struct{}
+
+func (r *ringtailBatchPrecompile) Address() common.Address {
+	return common.HexToAddress(RingtailBatchVerifyAddress)
+}
+
+func (r *ringtailBatchPrecompile) RequiredGas(input []byte) uint64 {
+	return RingtailBatchBaseGas + uint64(peekBatchCount(input))*RingtailBatchPerSigGas
+}
+
+// peekBatchCount reads the N field (right after the 1-byte mode) so gas can
+// be priced purely from input, without parsing the whole batch.
+func peekBatchCount(input []byte) uint16 {
+	if len(input) < 3 {
+		return 0
+	}
+	return binary.BigEndian.Uint16(input[1:3])
+}
+
+// batchEntry is one parsed (pubkey, message, signature) tuple from a batch
+// verify call.
+type batchEntry struct {
+	mode      mldsa.Mode
+	pubKey    []byte
+	message   []byte
+	signature []byte
+}
+
+// Run verifies N ML-DSA signatures in one call and returns a 32-byte
+// failure bitmap: bit i is set when entry i failed to verify, so a caller
+// can fall back to individually re-checking just the failing signatures.
+// An all-zero result means every signature verified.
+//
+// Input: [mode(1)] [N(2)]
+// { [pubkeyLen(2)] [pubkey] [msgLen(2)] [msg] [sigLen(2)] [sig] }*N
+func (r *ringtailBatchPrecompile) Run(
+	accessibleState contract.AccessibleState,
+	caller common.Address,
+	addr common.Address,
+	input []byte,
+	suppliedGas uint64,
+	readOnly bool,
+) ([]byte, uint64, error) {
+	gasCost := r.RequiredGas(input)
+	if suppliedGas < gasCost {
+		return nil, 0, vm.ErrOutOfGas
+	}
+	remainingGas := suppliedGas - gasCost
+
+	if len(input) < 3 {
+		return nil, remainingGas, ErrInvalidInput
+	}
+	mode := mldsa.Mode(input[0])
+	n := int(binary.BigEndian.Uint16(input[1:3]))
+	if n > maxBatchEntries {
+		return nil, remainingGas, ErrBatchTooLarge
+	}
+
+	entries := make([]batchEntry, n)
+	offset := 3
+	for i := 0; i < n; i++ {
+		entry, next, err := parseBatchEntry(input, offset, mode)
+		if err != nil {
+			return nil, remainingGas, err
+		}
+		entries[i] = entry
+		offset = next
+	}
+	if offset != len(input) {
+		return nil, remainingGas, ErrInvalidInput
+	}
+
+	bitmap := batchVerifyMLDSA(entries)
+	return bitmap[:], remainingGas, nil
+}
+
+func parseBatchEntry(input []byte, offset int, mode mldsa.Mode) (batchEntry, int, error) {
+	if len(input) < offset+2 {
+		return batchEntry{}, 0, ErrInvalidInput
+	}
+	pubKeyLen := int(binary.BigEndian.Uint16(input[offset : offset+2]))
+	offset += 2
+	if len(input) < offset+pubKeyLen+2 {
+		return batchEntry{}, 0, ErrInvalidInput
+	}
+	pubKey := input[offset : offset+pubKeyLen]
+	offset += pubKeyLen
+
+	msgLen := int(binary.BigEndian.Uint16(input[offset : offset+2]))
+	offset += 2
+	if len(input) < offset+msgLen+2 {
+		return batchEntry{}, 0, ErrInvalidInput
+	}
+	msg := input[offset : offset+msgLen]
+	offset += msgLen
+
+	sigLen := int(binary.BigEndian.Uint16(input[offset : offset+2]))
+	offset += 2
+	if len(input) < offset+sigLen {
+		return batchEntry{}, 0, ErrInvalidInput
+	}
+	sig := input[offset : offset+sigLen]
+	offset += sigLen
+
+	return batchEntry{mode: mode, pubKey: pubKey, message: msg, signature: sig}, offset, nil
+}
+
+// batchVerifyMLDSA is this repo's equivalent of the mldsa.BatchVerify
+// helper the originating request asked for directly on the ML-DSA library.
+// github.com/luxfi/crypto/mldsa isn't vendored in this repo, so a method
+// can't actually be added to it here; this lives in quasar instead and
+// wraps the library's existing, already-used PublicKeyFromBytes/Verify
+// entry points.
+//
+// Real lattice-level batching -- combining all N verification equations
+// Sum(rho_i * (A*z_i - c_i*t1_i*2^d)) ~= Sum(rho_i * w1_i) into a single
+// NTT/matrix pass over randomized per-signature scalars rho_i -- needs the
+// library's internal polynomial representation (A, z, c, t1, w1), which
+// isn't exposed by its public API. Lacking that, this performs N
+// independent Verify calls rather than fabricating an unverifiable
+// internal combination; see RingtailBatchPerSigGas for the gas
+// consequence of that limitation.
+func batchVerifyMLDSA(entries []batchEntry) [32]byte {
+	var bitmap [32]byte
+	for i, e := range entries {
+		pubKey, err := mldsa.PublicKeyFromBytes(e.pubKey, e.mode)
+		valid := err == nil && pubKey.Verify(e.message, e.signature, nil)
+		if !valid {
+			bitmap[31-i/8] |= 1 << (i % 8)
+		}
+	}
+	return bitmap
+}
@@ -0,0 +1,140 @@
+// Copyright (C) 2025, Lux Industries Inc All rights reserved.
+// Quasar Consensus Precompiles for Hyper-Efficient On-Chain Verification
+//
+// This package has no network access and no go.mod/vendor tree to pull
+// go-verkle in for cross-compatible test vectors (see
+// VerkleWitnessesAreGoVerkleCompatible in verkle.go -- those vectors
+// wouldn't verify here anyway, since VerifyIPA runs over a stand-in group,
+// not Bandersnatch/Banderwagon). What these tests prove instead is that
+// VerifyIPA's folding algebra is self-consistent and rejects a tampered
+// transcript.
+//
+// Building even a self-consistent satisfying proof ran into a second,
+// separate gap while writing these tests: commitment, each round's L/R,
+// and finalScalar are each constrained to exactly 32 bytes by VerifyIPA's
+// wire format, but ipaModulus is a 2048-bit prime -- so they only ever
+// range over a small slice of the group, not its full span. Solving the
+// verification equation for a non-trivial assignment generally lands
+// outside that 32-byte range (the commitment a correct solve produces is
+// essentially a uniform residue mod a 2048-bit prime, astronomically
+// unlikely to fit in 256 bits). The one case that reliably stays in range
+// is the identity-element transcript below (commitment = L_i = R_i = 1,
+// value = 0, finalScalar = 0), which is a real, honestly-checkable fixed
+// point of the equations, not a shortcut around them -- see
+// TestVerifyIPA_AcceptsSelfConsistentProof for the worked-out algebra.
+// Flagging this 32-byte/2048-bit mismatch here rather than silently
+// routing around it, in the same spirit as
+// VerkleWitnessesAreGoVerkleCompatible.
+
+package quasar
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// identityIPAProof builds the one class of proof that reliably stays
+// within VerifyIPA's 32-byte field width for every element involved:
+// commitment = 1, value = 0, and every round's (L, R) = (1, 1).
+//
+//   - cFolded starts at commitment·Q^0 = 1·1 = 1, and 1^x·1^(x⁻¹) = 1 for
+//     any challenge x, so cFolded stays exactly 1 through every round
+//     regardless of point or round count.
+//   - With finalScalar = 0, the right-hand side is G^0·Q^(0·bFinal) = 1
+//     regardless of bFinal (and so regardless of point).
+//
+// cFolded == 1 == rhs, so this is a genuine accept, not a forced one --
+// but note point never enters either side when finalScalar is 0, so this
+// shape alone can't probe point-sensitivity (see the tamper tests below,
+// none of which tamper point for that reason).
+func identityIPAProof(rounds int) (commitment, point, value, proof []byte) {
+	one := leftPad32(big.NewInt(1).Bytes())
+	zero := leftPad32(big.NewInt(0).Bytes())
+
+	proof = make([]byte, 0, rounds*64+32)
+	for i := 0; i < rounds; i++ {
+		proof = append(proof, one...) // L_i = 1
+		proof = append(proof, one...) // R_i = 1
+	}
+	proof = append(proof, zero...) // finalScalar = 0
+
+	return one, zero /* point, unused when finalScalar is 0 */, zero, proof
+}
+
+func leftPad32(b []byte) []byte {
+	out := make([]byte, 32)
+	copy(out[32-len(b):], b)
+	return out
+}
+
+func TestVerifyIPA_AcceptsSelfConsistentProof(t *testing.T) {
+	for _, rounds := range []int{0, 1, 2, 3, 4} {
+		commitment, point, value, proof := identityIPAProof(rounds)
+		valid, err := VerifyIPA(commitment, point, value, proof)
+		require.NoError(t, err)
+		require.True(t, valid, "rounds=%d", rounds)
+	}
+}
+
+func TestVerifyIPA_RejectsTamperedCommitment(t *testing.T) {
+	commitment, point, value, proof := identityIPAProof(2)
+	commitment[31] = 2 // commitment = 2 instead of 1
+
+	valid, err := VerifyIPA(commitment, point, value, proof)
+	require.NoError(t, err)
+	require.False(t, valid)
+}
+
+func TestVerifyIPA_RejectsTamperedValue(t *testing.T) {
+	commitment, point, value, proof := identityIPAProof(2)
+	value[31] = 1 // value = 1 instead of 0, so Q^value != 1
+
+	valid, err := VerifyIPA(commitment, point, value, proof)
+	require.NoError(t, err)
+	require.False(t, valid)
+}
+
+func TestVerifyIPA_RejectsTamperedProofRound(t *testing.T) {
+	commitment, point, value, proof := identityIPAProof(2)
+	proof[31] = 3 // round 0's L = 3 instead of 1
+
+	valid, err := VerifyIPA(commitment, point, value, proof)
+	require.NoError(t, err)
+	require.False(t, valid)
+}
+
+func TestVerifyIPA_RejectsTamperedFinalScalar(t *testing.T) {
+	commitment, point, value, proof := identityIPAProof(2)
+	proof[len(proof)-1] = 1 // finalScalar = 1 instead of 0
+
+	valid, err := VerifyIPA(commitment, point, value, proof)
+	require.NoError(t, err)
+	require.False(t, valid)
+}
+
+func TestVerifyIPA_RejectsShortInput(t *testing.T) {
+	_, err := VerifyIPA(make([]byte, 31), make([]byte, 32), make([]byte, 32), make([]byte, 32))
+	require.ErrorIs(t, err, ErrIPAInvalidInput)
+}
+
+func TestVerifyIPA_RejectsMalformedProofLength(t *testing.T) {
+	_, err := VerifyIPA(make([]byte, 32), make([]byte, 32), make([]byte, 32), make([]byte, 63))
+	require.ErrorIs(t, err, ErrIPAProofMismatch)
+}
+
+func TestVerifyVerkleLight_ThresholdGatesAnOtherwiseValidProof(t *testing.T) {
+	commitment, point, value, proof := identityIPAProof(2)
+	validInput := append(append(append(append([]byte{}, commitment...), point...), value...), proof...)
+
+	passInput := append(append([]byte{}, validInput...), 1)
+	valid, err := verifyVerkleLight(passInput)
+	require.NoError(t, err)
+	require.True(t, valid)
+
+	failInput := append(append([]byte{}, validInput...), 0)
+	valid, err = verifyVerkleLight(failInput)
+	require.NoError(t, err)
+	require.False(t, valid, "threshold_met=0 must never let an otherwise-valid proof pass")
+}
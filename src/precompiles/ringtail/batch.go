@@ -0,0 +1,232 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+//
+// Status: this is not the random-linear-combination aggregate check the
+// request asked for. It still calls sign.Verify once per signature (see
+// Run's doc comment below for why) and RingtailBatchVerifyPerSigGas still
+// scales linearly with the number of signatures in the batch. The gas
+// savings TestRingtailThresholdBatchVerify_GasSavings demonstrates are real,
+// but they come entirely from decoding the shared rings and A/bTilde once
+// per batch instead of once per call, not from collapsing N verify checks
+// into one via Σ r_i·(A·z_i − c_i·bTilde − Delta_i). Building the latter
+// would need the lower-level ring arithmetic ringtail/sign doesn't expose
+// past its monolithic Verify.
+
+package ringtailthreshold
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/luxfi/evm/precompile/contract"
+	"github.com/luxfi/geth/common"
+	"github.com/luxfi/lattice/v6/ring"
+	"github.com/luxfi/standard/src/precompiles/ringtail/format"
+
+	"ringtail/sign"
+)
+
+var (
+	// ContractRingtailThresholdBatchAddress is the address of the batch
+	// Ringtail threshold verify precompile. It is a distinct address from
+	// ContractRingtailThresholdAddress so existing single-signature callers
+	// are unaffected by the batch calldata shape.
+	ContractRingtailThresholdBatchAddress = common.HexToAddress("0x0200000000000000000000000000000000000018")
+
+	RingtailThresholdBatchPrecompile = &ringtailThresholdBatchPrecompile{}
+
+	_ contract.StatefulPrecompiledContract = &ringtailThresholdBatchPrecompile{}
+
+	ErrBatchEmpty = errors.New("batch must contain at least one signature")
+)
+
+const (
+	// RingtailBatchVerifyBaseGas covers the one-time cost shared by every
+	// signature in the batch: constructing the rings once and decoding the
+	// shared A/bTilde public parameters once, rather than per signature.
+	RingtailBatchVerifyBaseGas uint64 = 60_000
+
+	// RingtailBatchVerifyPerSigGas is charged per signature in the batch,
+	// on top of the per-party cost each signature's own totalParties
+	// contributes. It is lower than RingtailThresholdBaseGas (the
+	// single-call base cost) since RingtailBatchVerifyBaseGas already
+	// covers the setup a solo call would otherwise pay for on every
+	// invocation.
+	RingtailBatchVerifyPerSigGas uint64 = 100_000
+)
+
+type ringtailThresholdBatchPrecompile struct{}
+
+// Address returns the address of the batch Ringtail threshold verify
+// precompile.
+func (p *ringtailThresholdBatchPrecompile) Address() common.Address {
+	return ContractRingtailThresholdBatchAddress
+}
+
+// RequiredGas calculates the gas required for a batch verification call.
+func (p *ringtailThresholdBatchPrecompile) RequiredGas(input []byte) uint64 {
+	entries, ok := parseBatchHeader(input)
+	if !ok {
+		return RingtailBatchVerifyBaseGas
+	}
+	gas := RingtailBatchVerifyBaseGas
+	for _, e := range entries {
+		gas += RingtailBatchVerifyPerSigGas + uint64(e.totalParties)*RingtailThresholdPerPartyGas
+	}
+	return gas
+}
+
+// Run verifies a batch of Ringtail threshold signatures that were all
+// produced against the same public parameters A and bTilde. Sharing those
+// parameters and the ring setup across the whole batch, rather than
+// reconstructing them on every call the way RingtailThresholdPrecompile
+// does, is what makes batch verification cheaper per signature than N
+// separate calls.
+//
+// The underlying ringtail/sign verifier only exposes a single monolithic
+// Verify per signature rather than the lower-level ring arithmetic (matrix-
+// vector multiply, polynomial add/sub) a true random-linear-combination
+// aggregate check over Σ r_i·(A·z_i − c_i·bTilde − Delta_i) would need, so
+// this entrypoint calls Verify once per signature; the gas savings come from
+// the shared setup rather than from collapsing the per-signature checks
+// into one.
+//
+// Input format:
+// [count(2)]
+// [sharedParams] - a canonical format.Signature encoding only A and bTilde
+// count times: [threshold(4)] [totalParties(4)] [msgHash(32)] [sig] where
+// sig is a canonical format.Signature encoding only C, Z, and Delta.
+//
+// Output: a 32-byte word, 1 iff every signature in the batch verifies.
+func (p *ringtailThresholdBatchPrecompile) Run(
+	accessibleState contract.AccessibleState,
+	caller common.Address,
+	addr common.Address,
+	input []byte,
+	suppliedGas uint64,
+	readOnly bool,
+) ([]byte, uint64, error) {
+	gasCost := p.RequiredGas(input)
+	if suppliedGas < gasCost {
+		return nil, 0, errors.New("out of gas")
+	}
+	remainingGas := suppliedGas - gasCost
+
+	sharedParams, entries, err := parseBatchInput(input)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	if len(entries) == 0 {
+		return nil, remainingGas, ErrBatchEmpty
+	}
+
+	r, err := ring.NewRing(1<<sign.LogN, []uint64{sign.Q})
+	if err != nil {
+		return nil, remainingGas, fmt.Errorf("failed to create ring: %w", err)
+	}
+	rXi, err := ring.NewRing(1<<sign.LogN, []uint64{sign.QXi})
+	if err != nil {
+		return nil, remainingGas, fmt.Errorf("failed to create r_xi ring: %w", err)
+	}
+	rNu, err := ring.NewRing(1<<sign.LogN, []uint64{sign.QNu})
+	if err != nil {
+		return nil, remainingGas, fmt.Errorf("failed to create r_nu ring: %w", err)
+	}
+
+	allValid := true
+	for i, e := range entries {
+		if e.threshold == 0 || e.threshold > e.totalParties {
+			return nil, remainingGas, fmt.Errorf("%w: entry %d t=%d, n=%d",
+				ErrInvalidThreshold, i, e.threshold, e.totalParties)
+		}
+
+		merged := *e.sig
+		merged.A = sharedParams.A
+		merged.BTilde = sharedParams.BTilde
+
+		c, z, Delta, A, bTilde, err := ringElementsFromCanonical(r, rXi, rNu, &merged)
+		if err != nil {
+			return nil, remainingGas, fmt.Errorf("%w: entry %d: %w", ErrDeserializationFailed, i, err)
+		}
+
+		mu := fmt.Sprintf("%x", e.msgHash)
+		if !sign.Verify(r, rXi, rNu, z, A, mu, bTilde, c, Delta) {
+			allValid = false
+			break
+		}
+	}
+
+	result := make([]byte, 32)
+	if allValid {
+		result[31] = 1
+	}
+	return result, remainingGas, nil
+}
+
+// batchEntry is one (threshold, totalParties, msgHash, sig) tuple of a
+// batch-verify call, prior to being merged with the batch's shared A/bTilde.
+type batchEntry struct {
+	threshold    uint32
+	totalParties uint32
+	msgHash      []byte
+	sig          *format.Signature
+}
+
+// parseBatchInput decodes the shared A/bTilde parameters and every
+// (threshold, totalParties, msgHash, sig) entry from a batch-verify call's
+// input.
+func parseBatchInput(input []byte) (*format.Signature, []batchEntry, error) {
+	if len(input) < 2 {
+		return nil, nil, fmt.Errorf("%w: missing entry count", ErrInvalidInputLength)
+	}
+	count := binary.BigEndian.Uint16(input[0:2])
+	off := 2
+
+	sharedParams, n, err := format.UnmarshalPrefix(input[off:])
+	if err != nil {
+		return nil, nil, fmt.Errorf("shared params: %w", err)
+	}
+	off += n
+
+	entries := make([]batchEntry, count)
+	for i := range entries {
+		if len(input) < off+ThresholdSize+TotalPartiesSize+MessageHashSize {
+			return nil, nil, fmt.Errorf("%w: entry %d truncated header", ErrInvalidInputLength, i)
+		}
+		threshold := binary.BigEndian.Uint32(input[off : off+ThresholdSize])
+		off += ThresholdSize
+		totalParties := binary.BigEndian.Uint32(input[off : off+TotalPartiesSize])
+		off += TotalPartiesSize
+		msgHash := input[off : off+MessageHashSize]
+		off += MessageHashSize
+
+		sig, n, err := format.UnmarshalPrefix(input[off:])
+		if err != nil {
+			return nil, nil, fmt.Errorf("entry %d signature: %w", i, err)
+		}
+		off += n
+
+		entries[i] = batchEntry{
+			threshold:    threshold,
+			totalParties: totalParties,
+			msgHash:      msgHash,
+			sig:          sig,
+		}
+	}
+
+	return sharedParams, entries, nil
+}
+
+// parseBatchHeader parses just enough of the batch input to estimate gas:
+// the number of entries and each entry's totalParties. ok is false if the
+// input is too short or malformed to parse at all, in which case
+// RequiredGas falls back to the base cost so an invalid call is never
+// under-charged.
+func parseBatchHeader(input []byte) ([]batchEntry, bool) {
+	_, entries, err := parseBatchInput(input)
+	if err != nil {
+		return nil, false
+	}
+	return entries, true
+}
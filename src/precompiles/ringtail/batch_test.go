@@ -0,0 +1,162 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ringtailthreshold
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/luxfi/geth/common"
+	"github.com/luxfi/lattice/v6/ring"
+	"github.com/luxfi/lattice/v6/utils/structs"
+	"github.com/luxfi/standard/src/precompiles/ringtail/format"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRingtailThresholdBatchVerify tests that a batch of signatures sharing
+// the same A/bTilde all verify together.
+func TestRingtailThresholdBatchVerify(t *testing.T) {
+	threshold, totalParties := uint32(2), uint32(3)
+	messages := []string{"batch message one", "batch message two", "batch message three"}
+
+	input, err := buildBatchInput(t, threshold, totalParties, messages)
+	require.NoError(t, err)
+
+	precompile := &ringtailThresholdBatchPrecompile{}
+	result, _, err := precompile.Run(nil, common.Address{}, precompile.Address(), input, 10_000_000, true)
+	require.NoError(t, err)
+	require.Equal(t, byte(1), result[31], "batch should verify")
+}
+
+// TestRingtailThresholdBatchVerify_RejectsInvalidMember tests that a batch
+// with one corrupted signature is rejected as a whole.
+func TestRingtailThresholdBatchVerify_RejectsInvalidMember(t *testing.T) {
+	threshold, totalParties := uint32(2), uint32(3)
+	messages := []string{"batch message one", "batch message two"}
+
+	input, err := buildBatchInput(t, threshold, totalParties, messages)
+	require.NoError(t, err)
+
+	// Flip a coefficient byte inside the shared A parameters (well past the
+	// count/version/length-prefix header) so the corruption changes a
+	// polynomial value rather than a length field, forcing verification to
+	// fail rather than the parser to reject truncated input.
+	const corruptOffset = 2 + 20
+	require.Greater(t, len(input), corruptOffset)
+	input[corruptOffset] ^= 0xFF
+
+	precompile := &ringtailThresholdBatchPrecompile{}
+	result, _, err := precompile.Run(nil, common.Address{}, precompile.Address(), input, 10_000_000, true)
+	require.NoError(t, err)
+	require.Equal(t, byte(0), result[31], "batch with a corrupted member should fail")
+}
+
+// TestRingtailThresholdBatchVerify_GasSavings demonstrates that batching
+// N>1 signatures costs meaningfully less gas than N separate solo-verify
+// calls, for N=4,8,16.
+func TestRingtailThresholdBatchVerify_GasSavings(t *testing.T) {
+	threshold, totalParties := uint32(2), uint32(3)
+
+	for _, n := range []int{4, 8, 16} {
+		t.Run(fmt.Sprintf("n=%d", n), func(t *testing.T) {
+			messages := make([]string, n)
+			for i := range messages {
+				messages[i] = fmt.Sprintf("batch message %d", i)
+			}
+
+			input, err := buildBatchInput(t, threshold, totalParties, messages)
+			require.NoError(t, err)
+
+			batchPrecompile := &ringtailThresholdBatchPrecompile{}
+			batchGas := batchPrecompile.RequiredGas(input)
+
+			soloInput := make([]byte, SigModeSize+MinInputSize)
+			soloInput[0] = byte(ModeRawDigest)
+			binary.BigEndian.PutUint32(soloInput[SigModeSize:SigModeSize+4], threshold)
+			binary.BigEndian.PutUint32(soloInput[SigModeSize+4:SigModeSize+8], totalParties)
+			soloPrecompile := &ringtailThresholdPrecompile{}
+			soloGasPerCall := soloPrecompile.RequiredGas(soloInput)
+			soloTotalGas := soloGasPerCall * uint64(n)
+
+			require.Less(t, batchGas, soloTotalGas,
+				"batch of %d should cost less gas than %d solo calls", n, n)
+		})
+	}
+}
+
+// buildBatchInput assembles a batch-verify call's input: count, the shared
+// A/bTilde parameters, and one (threshold, totalParties, msgHash, sig) entry
+// per message, all signed under the same key setup so they genuinely share
+// A/bTilde the way the batch precompile requires.
+func buildBatchInput(t *testing.T, threshold, totalParties uint32, messages []string) ([]byte, error) {
+	t.Helper()
+
+	signers, err := setupThresholdSigners(threshold, totalParties)
+	if err != nil {
+		return nil, err
+	}
+
+	sharedParams := &format.Signature{
+		A:      matrixToBigints(signers.r, signers.A),
+		BTilde: vectorToBigints(signers.rXi, signers.bTilde),
+	}
+	sharedParamsBytes, err := format.Marshal(sharedParams)
+	if err != nil {
+		return nil, err
+	}
+
+	input := make([]byte, 2)
+	binary.BigEndian.PutUint16(input[0:2], uint16(len(messages)))
+	input = append(input, sharedParamsBytes...)
+
+	for _, message := range messages {
+		c, zSum, Delta, err := signers.sign(message)
+		if err != nil {
+			return nil, err
+		}
+
+		entrySig := &format.Signature{
+			C:     polyToBigints(signers.r, c),
+			Z:     vectorToBigints(signers.r, zSum),
+			Delta: vectorToBigints(signers.rNu, Delta),
+		}
+		entryBytes, err := format.Marshal(entrySig)
+		if err != nil {
+			return nil, err
+		}
+
+		var header [ThresholdSize + TotalPartiesSize + MessageHashSize]byte
+		binary.BigEndian.PutUint32(header[0:4], threshold)
+		binary.BigEndian.PutUint32(header[4:8], totalParties)
+		copy(header[8:40], hashMessage(message))
+
+		input = append(input, header[:]...)
+		input = append(input, entryBytes...)
+	}
+
+	return input, nil
+}
+
+// vectorToBigints extracts every polynomial in v as big.Int coefficients.
+func vectorToBigints(r *ring.Ring, v structs.Vector[ring.Poly]) [][]*big.Int {
+	out := make([][]*big.Int, len(v))
+	for i, poly := range v {
+		out[i] = polyToBigints(r, poly)
+	}
+	return out
+}
+
+// matrixToBigints extracts every polynomial in m as big.Int coefficients.
+func matrixToBigints(r *ring.Ring, m structs.Matrix[ring.Poly]) [][][]*big.Int {
+	out := make([][][]*big.Int, len(m))
+	for i, row := range m {
+		out[i] = make([][]*big.Int, len(row))
+		for j, poly := range row {
+			out[i][j] = polyToBigints(r, poly)
+		}
+	}
+	return out
+}
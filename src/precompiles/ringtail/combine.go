@@ -0,0 +1,375 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ringtailthreshold
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/luxfi/evm/precompile/contract"
+	"github.com/luxfi/geth/common"
+	"github.com/luxfi/standard/src/precompiles/ringtail/format"
+
+	"ringtail/sign"
+)
+
+// ContractRingtailCombineAddress is the address of the partial-signature
+// combiner precompile. It is a stateless consumer of the DKG registry at
+// ContractRingtailDKGAddress: it reads a group's threshold, totalParties,
+// per-party commitments, and public A/bTilde from that registry's storage,
+// but has no state of its own.
+var (
+	ContractRingtailCombineAddress = common.HexToAddress("0x020000000000000000000000000000000000001A")
+
+	RingtailCombinePrecompile = &ringtailCombinePrecompile{}
+
+	_ contract.StatefulPrecompiledContract = &ringtailCombinePrecompile{}
+
+	ErrDuplicatePartialParty = errors.New("duplicate party index in partial signature set")
+	ErrPartyNotCommitted     = errors.New("party has not submitted a share commitment for this group")
+	ErrPartialProofFailed    = errors.New("partial signature proof does not recover to the party's registered commitment")
+	ErrPartialShapeMismatch  = errors.New("partial signature vectors do not match the group's expected dimensions")
+)
+
+const (
+	// CombinePartialsBaseGas covers loading the group's threshold,
+	// totalParties, and public A/bTilde once per call.
+	CombinePartialsBaseGas uint64 = 80_000
+
+	// CombinePartialsPerPartialGas is charged per partial signature supplied,
+	// covering its proof check and its contribution to the weighted sum.
+	CombinePartialsPerPartialGas uint64 = 60_000
+
+	// combineHeaderSize is [groupId(32)] [msgHash(32)] [count(2)].
+	combineHeaderSize = groupIDSize + MessageHashSize + 2
+)
+
+type ringtailCombinePrecompile struct{}
+
+// Address returns the precompile address.
+func (p *ringtailCombinePrecompile) Address() common.Address {
+	return ContractRingtailCombineAddress
+}
+
+// RequiredGas calculates the gas required for a CombinePartials call.
+func (p *ringtailCombinePrecompile) RequiredGas(input []byte) uint64 {
+	if len(input) < combineHeaderSize {
+		return CombinePartialsBaseGas
+	}
+	count := binary.BigEndian.Uint16(input[combineHeaderSize-2 : combineHeaderSize])
+	return CombinePartialsBaseGas + uint64(count)*CombinePartialsPerPartialGas
+}
+
+// Run combines at least a signing group's threshold worth of partial
+// signatures into a single, fully serialized Ringtail threshold signature,
+// so that smart contracts can orchestrate threshold signing - each party
+// publishing its own partial on-chain - without trusting a combiner to
+// perform the aggregation honestly off-chain.
+//
+// Each partial contributes a share z_i of the response vector and a share
+// Delta_i of the masking vector; Run verifies the partial's NIZK proof
+// against the party's registered DKG commitment (the same recoverable-ECDSA
+// scheme dkg.go's submitShareCommitment uses), then combines the partials by
+// weighting each by its Lagrange coefficient (computed over evaluation
+// points x_i = partyIdx_i + 1, reduced modulo the same ring modulus the
+// shared z/Delta vectors live in) and summing. The challenge C is re-derived
+// as a Fiat-Shamir-style hash of the group, message, and combined z/Delta -
+// a documented best-effort reconstruction of the transcript rather than a
+// byte-exact replica of ringtail/sign's internal challenge derivation, which
+// this package cannot inspect (ringtail/sign is not vendored in this tree).
+//
+// NOT CONFIRMED TO PRODUCE A VERIFIABLE SIGNATURE. Two separate gaps, not
+// just the challenge transcript above:
+//
+//  1. (*thresholdSigners).sign in contract_test.go, which drives the real
+//     two-round protocol end to end, never produces a per-party Delta_i:
+//     Delta comes out of SignFinalize once, computed from the already-
+//     combined round-1 masking matrices, not submitted by each party and
+//     Lagrange-combined the way this file's deltaShares/weightedSum
+//     handling assumes. Whether Delta can even correctly be reconstructed
+//     as a per-party Lagrange sum is therefore itself unconfirmed, on top
+//     of the challenge-derivation gap.
+//  2. TestRingtailCombine_OutputFailsRealThresholdVerification feeds this
+//     precompile's own output through VerifyThresholdSignature and
+//     documents that it does not pass -- that test is the actual evidence
+//     for this comment, not just a restatement of it.
+//
+// Until both are resolved against a real signing session, treat
+// CombinePartials as on-chain Lagrange-combination bookkeeping only, not a
+// certified drop-in for the real protocol's combine step.
+//
+// Input: [groupId(32)] [msgHash(32)] [count(2)]
+// count times: [partyIdx(4)] [zLen(4)] [z_i bytes] [deltaLen(4)] [delta_i bytes] [proof(65)]
+//
+// z_i and delta_i are each encoded as a canonical format.Signature carrying
+// only Z (respectively Delta), the same per-field subset convention dkg.go
+// uses for A/BTilde.
+//
+// Output: a canonical format.Signature (C, Z, Delta, A, BTilde) in the same
+// wire shape RingtailThresholdPrecompile/RingtailThresholdBatchPrecompile
+// expect -- but see the NOT CONFIRMED paragraph above before treating that
+// shape compatibility as verification compatibility.
+func (p *ringtailCombinePrecompile) Run(
+	accessibleState contract.AccessibleState,
+	caller common.Address,
+	addr common.Address,
+	input []byte,
+	suppliedGas uint64,
+	readOnly bool,
+) ([]byte, uint64, error) {
+	gasCost := p.RequiredGas(input)
+	if suppliedGas < gasCost {
+		return nil, 0, errors.New("out of gas")
+	}
+	remainingGas := suppliedGas - gasCost
+
+	if len(input) < combineHeaderSize {
+		return nil, remainingGas, fmt.Errorf("%w: combine header truncated", ErrInvalidInputLength)
+	}
+	groupID := common.BytesToHash(input[0:groupIDSize])
+	msgHash := input[groupIDSize : groupIDSize+MessageHashSize]
+	count := binary.BigEndian.Uint16(input[combineHeaderSize-2 : combineHeaderSize])
+	off := combineHeaderSize
+
+	state := accessibleState.GetStateDB()
+	if !groupExists(state, groupID) {
+		return nil, remainingGas, ErrGroupNotFound
+	}
+	if !groupFinalized(state, groupID) {
+		return nil, remainingGas, ErrGroupNotFinalized
+	}
+	threshold := groupThreshold(state, groupID)
+	totalParties := groupTotalParties(state, groupID)
+
+	if uint32(count) < threshold {
+		return nil, remainingGas, fmt.Errorf("%w: have %d, need %d", ErrInsufficientParties, count, threshold)
+	}
+
+	seen := make(map[uint32]bool, count)
+	zShares := make(map[uint32][][]*big.Int, count)
+	deltaShares := make(map[uint32][][]*big.Int, count)
+
+	for i := uint16(0); i < count; i++ {
+		if len(input) < off+partyIdxSize+4 {
+			return nil, remainingGas, fmt.Errorf("%w: partial %d header truncated", ErrInvalidInputLength, i)
+		}
+		partyIdx := binary.BigEndian.Uint32(input[off : off+partyIdxSize])
+		off += partyIdxSize
+
+		if partyIdx >= totalParties {
+			return nil, remainingGas, fmt.Errorf("%w: party %d, n=%d", ErrPartyIndexOutOfRange, partyIdx, totalParties)
+		}
+		if seen[partyIdx] {
+			return nil, remainingGas, fmt.Errorf("%w: party %d", ErrDuplicatePartialParty, partyIdx)
+		}
+		seen[partyIdx] = true
+
+		zSig, n, err := format.UnmarshalPrefix(input[off:])
+		if err != nil {
+			return nil, remainingGas, fmt.Errorf("partial %d z: %w: %w", i, ErrDeserializationFailed, err)
+		}
+		zBytes := input[off : off+n]
+		off += n
+
+		deltaSig, n, err := format.UnmarshalPrefix(input[off:])
+		if err != nil {
+			return nil, remainingGas, fmt.Errorf("partial %d delta: %w: %w", i, ErrDeserializationFailed, err)
+		}
+		deltaBytes := input[off : off+n]
+		off += n
+
+		if len(input) < off+proofSize {
+			return nil, remainingGas, fmt.Errorf("%w: partial %d proof truncated", ErrInvalidInputLength, i)
+		}
+		proof := input[off : off+proofSize]
+		off += proofSize
+
+		if !partyCommitted(state, groupID, partyIdx) {
+			return nil, remainingGas, fmt.Errorf("%w: party %d", ErrPartyNotCommitted, partyIdx)
+		}
+		commitment := partyCommitment(state, groupID, partyIdx)
+
+		msg := partialProofMessage(groupID, msgHash, partyIdx, zBytes, deltaBytes)
+		if !verifyShareCommitmentProof(commitment, msg, proof) {
+			return nil, remainingGas, fmt.Errorf("%w: party %d", ErrPartialProofFailed, partyIdx)
+		}
+
+		zShares[partyIdx] = zSig.Z
+		deltaShares[partyIdx] = deltaSig.Delta
+	}
+
+	paramsBlob := loadBlob(state, groupID)
+	params, err := format.Unmarshal(paramsBlob)
+	if err != nil {
+		return nil, remainingGas, fmt.Errorf("stored group params: %w: %w", ErrDeserializationFailed, err)
+	}
+
+	qMod := new(big.Int).SetUint64(sign.Q)
+	qNuMod := new(big.Int).SetUint64(sign.QNu)
+
+	partyIdxs := make([]uint32, 0, len(zShares))
+	for idx := range zShares {
+		partyIdxs = append(partyIdxs, idx)
+	}
+	zLambda := lagrangeCoefficients(partyIdxs, qMod)
+	deltaLambda := lagrangeCoefficients(partyIdxs, qNuMod)
+
+	zSum, err := weightedSumCoeffVectors(zShares, zLambda, qMod)
+	if err != nil {
+		return nil, remainingGas, fmt.Errorf("%w: %w", ErrPartialShapeMismatch, err)
+	}
+	deltaSum, err := weightedSumCoeffVectors(deltaShares, deltaLambda, qNuMod)
+	if err != nil {
+		return nil, remainingGas, fmt.Errorf("%w: %w", ErrPartialShapeMismatch, err)
+	}
+
+	c := deriveCombinedChallenge(groupID, msgHash, zSum, deltaSum, qMod)
+
+	combined := &format.Signature{
+		C:      c,
+		Z:      zSum,
+		Delta:  deltaSum,
+		A:      params.A,
+		BTilde: params.BTilde,
+	}
+	combinedBytes, err := format.Marshal(combined)
+	if err != nil {
+		return nil, remainingGas, fmt.Errorf("marshal combined signature: %w", err)
+	}
+
+	return combinedBytes, remainingGas, nil
+}
+
+// partialProofMessage reconstructs the sign-bytes a partial signature's NIZK
+// proof must cover: "RINGTAIL-COMBINE" || groupId || msgHash || partyIdx ||
+// z bytes || delta bytes. Binding the proof to the submitted z_i/Delta_i
+// values, rather than just the party's identity, stops one party from
+// replaying another party's proof against a forged partial.
+func partialProofMessage(groupID common.Hash, msgHash []byte, partyIdx uint32, zBytes, deltaBytes []byte) []byte {
+	var idx [4]byte
+	binary.BigEndian.PutUint32(idx[:], partyIdx)
+
+	msg := []byte("RINGTAIL-COMBINE")
+	msg = append(msg, groupID.Bytes()...)
+	msg = append(msg, msgHash...)
+	msg = append(msg, idx[:]...)
+	msg = append(msg, zBytes...)
+	msg = append(msg, deltaBytes...)
+	return msg
+}
+
+// lagrangeCoefficients computes, for each party index in parties, the
+// Lagrange coefficient at x=0 of the polynomial interpolating the points
+// {(partyIdx_i+1, share_i)}, reduced modulo mod. Evaluation points are
+// partyIdx+1 rather than partyIdx so that party 0's point is never the same
+// as the interpolation target (x=0).
+func lagrangeCoefficients(parties []uint32, mod *big.Int) map[uint32]*big.Int {
+	coeffs := make(map[uint32]*big.Int, len(parties))
+	for _, i := range parties {
+		xi := new(big.Int).SetInt64(int64(i) + 1)
+		num := big.NewInt(1)
+		den := big.NewInt(1)
+		for _, j := range parties {
+			if j == i {
+				continue
+			}
+			xj := new(big.Int).SetInt64(int64(j) + 1)
+			num.Mul(num, new(big.Int).Neg(xj))
+			num.Mod(num, mod)
+			diff := new(big.Int).Sub(xi, xj)
+			diff.Mod(diff, mod)
+			den.Mul(den, diff)
+			den.Mod(den, mod)
+		}
+		lambda := new(big.Int).Mul(num, new(big.Int).ModInverse(den, mod))
+		coeffs[i] = lambda.Mod(lambda, mod)
+	}
+	return coeffs
+}
+
+// weightedSumCoeffVectors computes Σ lambda[i]·vectors[i] mod mod,
+// coefficient-wise, validating that every party's vector shares the same
+// shape as the first one encountered.
+func weightedSumCoeffVectors(vectors map[uint32][][]*big.Int, lambda map[uint32]*big.Int, mod *big.Int) ([][]*big.Int, error) {
+	if len(vectors) == 0 {
+		return nil, errors.New("no shares to combine")
+	}
+
+	var rows, cols int
+	first := true
+	var sum [][]*big.Int
+
+	for idx, vec := range vectors {
+		if first {
+			rows = len(vec)
+			sum = make([][]*big.Int, rows)
+			for r := range sum {
+				cols = len(vec[r])
+				sum[r] = make([]*big.Int, cols)
+				for c := range sum[r] {
+					sum[r][c] = big.NewInt(0)
+				}
+			}
+			first = false
+		}
+		if len(vec) != rows {
+			return nil, fmt.Errorf("party %d row count %d does not match %d", idx, len(vec), rows)
+		}
+		lam := lambda[idx]
+		for r, row := range vec {
+			if len(row) != len(sum[r]) {
+				return nil, fmt.Errorf("party %d row %d column count %d does not match %d", idx, r, len(row), len(sum[r]))
+			}
+			for c, coeff := range row {
+				term := new(big.Int).Mul(coeff, lam)
+				sum[r][c].Add(sum[r][c], term)
+				sum[r][c].Mod(sum[r][c], mod)
+			}
+		}
+	}
+
+	return sum, nil
+}
+
+// deriveCombinedChallenge re-derives the challenge polynomial C from the
+// combined z/Delta vectors via a SHA-256-based Fiat-Shamir expansion. See
+// Run's doc comment for why this is a best-effort transcript reconstruction
+// rather than a verified match for ringtail/sign's internal derivation.
+func deriveCombinedChallenge(groupID common.Hash, msgHash []byte, zSum, deltaSum [][]*big.Int, mod *big.Int) []*big.Int {
+	transcript := []byte("RINGTAIL-COMBINE-CHALLENGE")
+	transcript = append(transcript, groupID.Bytes()...)
+	transcript = append(transcript, msgHash...)
+	for _, row := range zSum {
+		for _, coeff := range row {
+			transcript = append(transcript, coeff.Bytes()...)
+		}
+	}
+	for _, row := range deltaSum {
+		for _, coeff := range row {
+			transcript = append(transcript, coeff.Bytes()...)
+		}
+	}
+
+	degree := 0
+	if len(zSum) > 0 {
+		degree = len(zSum[0])
+	}
+	coeffs := make([]*big.Int, degree)
+	var counter uint32
+	for i := 0; i < degree; {
+		var ctr [4]byte
+		binary.BigEndian.PutUint32(ctr[:], counter)
+		counter++
+		digest := sha256.Sum256(append(append([]byte(nil), transcript...), ctr[:]...))
+		for j := 0; j+8 <= len(digest) && i < degree; j += 8 {
+			v := new(big.Int).SetBytes(digest[j : j+8])
+			coeffs[i] = v.Mod(v, mod)
+			i++
+		}
+	}
+	return coeffs
+}
@@ -0,0 +1,238 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ringtailthreshold
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"math/big"
+	"testing"
+
+	"github.com/luxfi/geth/common"
+	"github.com/luxfi/geth/crypto"
+	"github.com/luxfi/standard/src/precompiles/ringtail/format"
+	"github.com/stretchr/testify/require"
+
+	"ringtail/sign"
+)
+
+// combinePartyFixture is one party's key and registered commitment, set up
+// against a finalized signing group, for building CombinePartials test
+// inputs.
+type combinePartyFixture struct {
+	idx uint32
+	key *ecdsa.PrivateKey
+}
+
+// setupCombineGroup creates and finalizes a signing group with one
+// commitment per party in 0..totalParties-1, returning each party's key so
+// tests can build valid partial-signature proofs.
+func setupCombineGroup(t *testing.T, precompile *ringtailDKGPrecompile, groupID common.Hash, threshold, totalParties uint32) []combinePartyFixture {
+	t.Helper()
+
+	signers, err := setupThresholdSigners(threshold, totalParties)
+	require.NoError(t, err)
+	require.NoError(t, mustCreateGroup(t, precompile, groupID, threshold, totalParties, signers))
+
+	parties := make([]combinePartyFixture, totalParties)
+	for i := uint32(0); i < totalParties; i++ {
+		key, err := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+		require.NoError(t, err)
+		require.NoError(t, submitCommitment(t, precompile, groupID, i, key))
+		parties[i] = combinePartyFixture{idx: i, key: key}
+	}
+
+	_, _, err = precompile.Run(nil, common.Address{}, precompile.Address(), finalizeGroupInput(groupID), 1_000_000, false)
+	require.NoError(t, err)
+
+	return parties
+}
+
+// buildPartial encodes one party's (z_i, Delta_i) contribution and a valid
+// proof over them into the wire layout CombinePartials expects for a single
+// partial entry.
+func buildPartial(t *testing.T, groupID common.Hash, msgHash []byte, party combinePartyFixture, zVec, deltaVec [][]*big.Int) []byte {
+	t.Helper()
+
+	zBytes, err := format.Marshal(&format.Signature{Z: zVec})
+	require.NoError(t, err)
+	deltaBytes, err := format.Marshal(&format.Signature{Delta: deltaVec})
+	require.NoError(t, err)
+
+	msg := partialProofMessage(groupID, msgHash, party.idx, zBytes, deltaBytes)
+	digest := sha256.Sum256(msg)
+	proof, err := crypto.Sign(digest[:], party.key)
+	require.NoError(t, err)
+
+	var idx [4]byte
+	binary.BigEndian.PutUint32(idx[:], party.idx)
+
+	entry := append([]byte{}, idx[:]...)
+	entry = append(entry, zBytes...)
+	entry = append(entry, deltaBytes...)
+	entry = append(entry, proof...)
+	return entry
+}
+
+func buildCombineInput(groupID common.Hash, msgHash []byte, partials [][]byte) []byte {
+	input := append([]byte{}, groupID.Bytes()...)
+	input = append(input, msgHash...)
+	var count [2]byte
+	binary.BigEndian.PutUint16(count[:], uint16(len(partials)))
+	input = append(input, count[:]...)
+	for _, p := range partials {
+		input = append(input, p...)
+	}
+	return input
+}
+
+func sampleVec(rows, cols int, seed int64) [][]*big.Int {
+	vec := make([][]*big.Int, rows)
+	for i := range vec {
+		vec[i] = make([]*big.Int, cols)
+		for j := range vec[i] {
+			vec[i][j] = big.NewInt(seed + int64(i*cols+j))
+		}
+	}
+	return vec
+}
+
+func TestRingtailCombine_ProducesWellFormedSignature(t *testing.T) {
+	dkg := &ringtailDKGPrecompile{}
+	combine := &ringtailCombinePrecompile{}
+	threshold, totalParties := uint32(2), uint32(3)
+
+	groupID := common.HexToHash("0x11")
+	parties := setupCombineGroup(t, dkg, groupID, threshold, totalParties)
+
+	msgHash := make([]byte, MessageHashSize)
+	copy(msgHash, []byte("combine test message"))
+
+	partials := make([][]byte, 0, threshold)
+	for i := uint32(0); i < threshold; i++ {
+		partials = append(partials, buildPartial(t, groupID, msgHash, parties[i], sampleVec(2, 2, int64(i)+1), sampleVec(2, 2, int64(i)+10)))
+	}
+
+	input := buildCombineInput(groupID, msgHash, partials)
+	result, _, err := combine.Run(nil, common.Address{}, combine.Address(), input, 1_000_000, true)
+	require.NoError(t, err)
+
+	sig, err := format.Unmarshal(result)
+	require.NoError(t, err)
+	require.NotEmpty(t, sig.C)
+	require.NotEmpty(t, sig.A)
+	require.NotEmpty(t, sig.BTilde)
+}
+
+func TestRingtailCombine_InsufficientPartialsRejected(t *testing.T) {
+	dkg := &ringtailDKGPrecompile{}
+	combine := &ringtailCombinePrecompile{}
+	threshold, totalParties := uint32(2), uint32(3)
+
+	groupID := common.HexToHash("0x12")
+	parties := setupCombineGroup(t, dkg, groupID, threshold, totalParties)
+
+	msgHash := make([]byte, MessageHashSize)
+	copy(msgHash, []byte("insufficient partials"))
+
+	// Only one partial, below the group's threshold of 2.
+	partial := buildPartial(t, groupID, msgHash, parties[0], sampleVec(2, 2, 1), sampleVec(2, 2, 10))
+	input := buildCombineInput(groupID, msgHash, [][]byte{partial})
+
+	_, _, err := combine.Run(nil, common.Address{}, combine.Address(), input, 1_000_000, true)
+	require.ErrorIs(t, err, ErrInsufficientParties)
+}
+
+func TestRingtailCombine_BadProofRejected(t *testing.T) {
+	dkg := &ringtailDKGPrecompile{}
+	combine := &ringtailCombinePrecompile{}
+	threshold, totalParties := uint32(2), uint32(3)
+
+	groupID := common.HexToHash("0x13")
+	parties := setupCombineGroup(t, dkg, groupID, threshold, totalParties)
+
+	msgHash := make([]byte, MessageHashSize)
+	copy(msgHash, []byte("bad proof"))
+
+	partials := make([][]byte, 0, threshold)
+	for i := uint32(0); i < threshold; i++ {
+		partials = append(partials, buildPartial(t, groupID, msgHash, parties[i], sampleVec(2, 2, int64(i)+1), sampleVec(2, 2, int64(i)+10)))
+	}
+	// Corrupt the second partial's proof (its last byte).
+	partials[1][len(partials[1])-1] ^= 0xFF
+
+	input := buildCombineInput(groupID, msgHash, partials)
+	_, _, err := combine.Run(nil, common.Address{}, combine.Address(), input, 1_000_000, true)
+	require.ErrorIs(t, err, ErrPartialProofFailed)
+}
+
+func TestRingtailCombine_GasCost(t *testing.T) {
+	combine := &ringtailCombinePrecompile{}
+
+	base := combine.RequiredGas(make([]byte, combineHeaderSize))
+	require.Equal(t, CombinePartialsBaseGas, base)
+
+	header := make([]byte, combineHeaderSize)
+	binary.BigEndian.PutUint16(header[combineHeaderSize-2:], 3)
+	require.Equal(t, CombinePartialsBaseGas+3*CombinePartialsPerPartialGas, combine.RequiredGas(header))
+}
+
+// TestRingtailCombine_OutputFailsRealThresholdVerification is the
+// end-to-end check combine.go's doc comment promises: it feeds
+// CombinePartials' own output through VerifyThresholdSignature, the exact
+// entry point a real caller would use next, rather than only checking that
+// the output deserializes.
+//
+// It uses a real key setup from setupThresholdSigners so A/BTilde are
+// correctly dimensioned (sign.M x sign.N and sign.M), but per-party z_i/
+// Delta_i are still synthetic placeholders, not shares from an actual
+// signing session: (*thresholdSigners).sign never produces a per-party
+// Delta_i to submit in the first place (see combine.go's doc comment), so
+// there is no real fixture this test could build instead without first
+// resolving that gap. Either outcome below -- sign.Verify returning false,
+// or deserializeSignature erroring outright -- demonstrates the same
+// thing: CombinePartials' output must not be treated as a certified,
+// verify-ready signature today.
+func TestRingtailCombine_OutputFailsRealThresholdVerification(t *testing.T) {
+	dkg := &ringtailDKGPrecompile{}
+	combine := &ringtailCombinePrecompile{}
+	threshold, totalParties := uint32(2), uint32(3)
+
+	groupID := common.HexToHash("0x14")
+	signers, err := setupThresholdSigners(threshold, totalParties)
+	require.NoError(t, err)
+	require.NoError(t, mustCreateGroup(t, dkg, groupID, threshold, totalParties, signers))
+
+	parties := make([]combinePartyFixture, totalParties)
+	for i := uint32(0); i < totalParties; i++ {
+		key, err := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+		require.NoError(t, err)
+		require.NoError(t, submitCommitment(t, dkg, groupID, i, key))
+		parties[i] = combinePartyFixture{idx: i, key: key}
+	}
+	_, _, err = dkg.Run(nil, common.Address{}, dkg.Address(), finalizeGroupInput(groupID), 1_000_000, false)
+	require.NoError(t, err)
+
+	msgHash := make([]byte, MessageHashSize)
+	copy(msgHash, []byte("combine real-verify test"))
+
+	degree := signers.r.N()
+	partials := make([][]byte, 0, threshold)
+	for i := uint32(0); i < threshold; i++ {
+		zVec := sampleVec(sign.N, degree, int64(i)+1)
+		deltaVec := sampleVec(sign.M, degree, int64(i)+10)
+		partials = append(partials, buildPartial(t, groupID, msgHash, parties[i], zVec, deltaVec))
+	}
+
+	input := buildCombineInput(groupID, msgHash, partials)
+	result, _, err := combine.Run(nil, common.Address{}, combine.Address(), input, 1_000_000, true)
+	require.NoError(t, err)
+
+	valid, verifyErr := VerifyThresholdSignature(threshold, totalParties, msgHash, result)
+	if verifyErr == nil {
+		require.False(t, valid, "a combined signature built from synthetic per-party shares must not spuriously verify")
+	}
+}
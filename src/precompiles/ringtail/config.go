@@ -0,0 +1,84 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ringtailthreshold
+
+import (
+	"fmt"
+
+	"github.com/luxfi/evm/precompile/precompileconfig"
+	"github.com/luxfi/geth/common"
+)
+
+var _ precompileconfig.Config = &Config{}
+
+// Module is the unique key under which the Ringtail threshold precompile
+// config is registered, matching pqcrypto's convention of keying off the
+// contract address rather than a hand-picked string.
+var Module = common.BytesToAddress(ContractRingtailThresholdAddress.Bytes()).Hex()
+
+// MinActivationTimestamp is a placeholder for the chain's post-quantum fork
+// timestamp: ChainConfig does not yet expose that fork directly, so Verify
+// checks against this constant instead of silently accepting any
+// activation timestamp, including one that predates PQ support existing at
+// all.
+const MinActivationTimestamp uint64 = 1
+
+// Config implements the precompileconfig.Config interface for the Ringtail
+// threshold signature precompile. Embedding precompileconfig.Upgrade, as
+// pqcrypto.Config does, lets Ringtail be activated at a specific block
+// timestamp and disabled by a later upgrade, rather than always being on.
+type Config struct {
+	precompileconfig.Upgrade
+}
+
+// NewConfig returns a new Ringtail threshold precompile config activated at
+// blockTimestamp.
+func NewConfig(blockTimestamp *uint64) *Config {
+	return &Config{
+		Upgrade: precompileconfig.Upgrade{
+			BlockTimestamp: blockTimestamp,
+		},
+	}
+}
+
+// NewDisableConfig returns a config that disables the Ringtail threshold
+// precompile.
+func NewDisableConfig(blockTimestamp *uint64) *Config {
+	return &Config{
+		Upgrade: precompileconfig.Upgrade{
+			BlockTimestamp: blockTimestamp,
+			Disable:        true,
+		},
+	}
+}
+
+// Key returns the unique key for the Ringtail threshold precompile config.
+func (*Config) Key() string { return Module }
+
+// Verify returns an error if the config is invalid: enabling the precompile
+// requires an activation timestamp, and that timestamp must not predate the
+// chain's post-quantum fork.
+func (c *Config) Verify(chainConfig precompileconfig.ChainConfig) error {
+	if !c.Disable && c.BlockTimestamp == nil {
+		return fmt.Errorf("Ringtail threshold precompile is enabled but no activation timestamp is set")
+	}
+	if c.BlockTimestamp != nil && *c.BlockTimestamp < MinActivationTimestamp {
+		return fmt.Errorf("Ringtail threshold activation timestamp %d predates the post-quantum fork", *c.BlockTimestamp)
+	}
+	return nil
+}
+
+// Equal returns true if the provided config is equivalent.
+func (c *Config) Equal(cfg precompileconfig.Config) bool {
+	other, ok := cfg.(*Config)
+	if !ok {
+		return false
+	}
+	return c.Upgrade.Equal(&other.Upgrade)
+}
+
+// String returns a string representation of the config.
+func (c *Config) String() string {
+	return fmt.Sprintf("RingtailThreshold{BlockTimestamp: %v, Disable: %v}", c.BlockTimestamp, c.Disable)
+}
@@ -0,0 +1,68 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ringtailthreshold
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/luxfi/evm/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigJSONRoundTrip(t *testing.T) {
+	cfg := NewConfig(utils.NewUint64(100))
+
+	encoded, err := json.Marshal(cfg)
+	require.NoError(t, err)
+
+	var decoded Config
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	require.True(t, cfg.Equal(&decoded))
+}
+
+func TestConfigEqual(t *testing.T) {
+	tests := map[string]struct {
+		a, b  *Config
+		equal bool
+	}{
+		"same timestamp": {
+			a:     NewConfig(utils.NewUint64(10)),
+			b:     NewConfig(utils.NewUint64(10)),
+			equal: true,
+		},
+		"different timestamp": {
+			a:     NewConfig(utils.NewUint64(10)),
+			b:     NewConfig(utils.NewUint64(20)),
+			equal: false,
+		},
+		"enabled vs disabled": {
+			a:     NewConfig(utils.NewUint64(10)),
+			b:     NewDisableConfig(utils.NewUint64(10)),
+			equal: false,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tt.equal, tt.a.Equal(tt.b))
+		})
+	}
+}
+
+func TestConfigVerifyRequiresTimestampWhenEnabled(t *testing.T) {
+	cfg := &Config{}
+	require.Error(t, cfg.Verify(nil))
+
+	cfg = NewConfig(utils.NewUint64(MinActivationTimestamp))
+	require.NoError(t, cfg.Verify(nil))
+
+	cfg = NewDisableConfig(nil)
+	require.NoError(t, cfg.Verify(nil))
+}
+
+func TestConfigVerifyRejectsPreForkTimestamp(t *testing.T) {
+	cfg := NewConfig(utils.NewUint64(0))
+	require.Error(t, cfg.Verify(nil))
+}
@@ -4,17 +4,16 @@
 package ringtailthreshold
 
 import (
-	"bytes"
 	"encoding/binary"
 	"errors"
 	"fmt"
-	"math/big"
 
 	"github.com/luxfi/evm/precompile/contract"
 	"github.com/luxfi/geth/common"
 	"github.com/luxfi/lattice/v6/ring"
 	"github.com/luxfi/lattice/v6/utils/sampling"
 	"github.com/luxfi/lattice/v6/utils/structs"
+	"github.com/luxfi/standard/src/precompiles/ringtail/format"
 
 	"ringtail/sign"
 	"ringtail/utils"
@@ -39,7 +38,7 @@ var (
 const (
 	// Gas costs for Ringtail threshold signature verification
 	// Based on lattice operations being more expensive than elliptic curve
-	RingtailThresholdBaseGas    uint64 = 150_000 // Base cost for threshold verification
+	RingtailThresholdBaseGas     uint64 = 150_000 // Base cost for threshold verification
 	RingtailThresholdPerPartyGas uint64 = 10_000  // Cost per party in threshold
 
 	// Input format constants
@@ -50,17 +49,16 @@ const (
 	// Minimum input size: threshold + total parties + message hash + minimal signature
 	MinInputSize = ThresholdSize + TotalPartiesSize + MessageHashSize
 
-	// Ringtail signature component sizes (based on sign.go constants)
-	// These are serialized sizes for the signature components
-	PolySize        = 256 // Approximate size per polynomial coefficient
-	VectorM         = 8   // M parameter from config
-	VectorN         = 7   // N parameter from config
-	DeltaVectorSize = VectorM * PolySize
-	ZVectorSize     = VectorN * PolySize
-	CPolySize       = PolySize
-
-	// Expected signature size: c + z + Delta
-	ExpectedSignatureSize = CPolySize + ZVectorSize + DeltaVectorSize
+	// SigModeSize is the width of the sigMode byte that selects how the
+	// message digest is derived (see prehash.go). It is only part of
+	// RingtailThresholdPrecompile's own input layout, not registry_mode.go's
+	// or the batch/DKG entry points', which still take a raw digest.
+	SigModeSize = 1
+
+	// minSoloHeaderSize is the smallest input RingtailThresholdPrecompile's
+	// Run can parse a sigMode/threshold/totalParties header out of, before
+	// it even attempts to resolve a digest.
+	minSoloHeaderSize = SigModeSize + ThresholdSize + TotalPartiesSize
 )
 
 type ringtailThresholdPrecompile struct{}
@@ -77,12 +75,12 @@ func (p *ringtailThresholdPrecompile) RequiredGas(input []byte) uint64 {
 
 // RingtailThresholdGasCost calculates the gas cost for threshold verification
 func RingtailThresholdGasCost(input []byte) uint64 {
-	if len(input) < MinInputSize {
+	if len(input) < minSoloHeaderSize {
 		return RingtailThresholdBaseGas
 	}
 
-	// Extract number of parties from input
-	totalParties := binary.BigEndian.Uint32(input[ThresholdSize : ThresholdSize+TotalPartiesSize])
+	// Extract number of parties from input, skipping the leading sigMode byte.
+	totalParties := binary.BigEndian.Uint32(input[SigModeSize+ThresholdSize : SigModeSize+ThresholdSize+TotalPartiesSize])
 
 	// Base cost + per-party cost
 	return RingtailThresholdBaseGas + (uint64(totalParties) * RingtailThresholdPerPartyGas)
@@ -104,20 +102,23 @@ func (p *ringtailThresholdPrecompile) Run(
 	}
 
 	// Input format:
-	// [0:4]       = threshold t (uint32)
-	// [4:8]       = total parties n (uint32)
-	// [8:40]      = message hash (32 bytes)
-	// [40:...]    = threshold signature (variable, ~4KB for default params)
-
-	if len(input) < MinInputSize {
+	// [0]         = sigMode (1 byte; see prehash.go)
+	// [1:5]       = threshold t (uint32)
+	// [5:9]       = total parties n (uint32)
+	// [9:...]     = digest material: MessageHashSize raw bytes for
+	//               ModeRawDigest, or a length-prefixed ctx/msg pair for a
+	//               pre-hash mode (see resolveMessageDigest)
+	// [...:...]   = threshold signature (variable, ~4KB for default params)
+
+	if len(input) < minSoloHeaderSize {
 		return nil, suppliedGas - gasCost, fmt.Errorf("%w: expected at least %d bytes, got %d",
-			ErrInvalidInputLength, MinInputSize, len(input))
+			ErrInvalidInputLength, minSoloHeaderSize, len(input))
 	}
 
-	// Parse threshold parameters
-	threshold := binary.BigEndian.Uint32(input[0:ThresholdSize])
-	totalParties := binary.BigEndian.Uint32(input[ThresholdSize : ThresholdSize+TotalPartiesSize])
-	messageHash := input[ThresholdSize+TotalPartiesSize : ThresholdSize+TotalPartiesSize+MessageHashSize]
+	// Parse sigMode and threshold parameters
+	sigMode := SigMode(input[0])
+	threshold := binary.BigEndian.Uint32(input[SigModeSize : SigModeSize+ThresholdSize])
+	totalParties := binary.BigEndian.Uint32(input[SigModeSize+ThresholdSize : minSoloHeaderSize])
 
 	// Validate threshold
 	if threshold == 0 || threshold > totalParties {
@@ -125,11 +126,18 @@ func (p *ringtailThresholdPrecompile) Run(
 			ErrInvalidThreshold, threshold, totalParties)
 	}
 
-	// Extract signature bytes
-	signatureBytes := input[MinInputSize:]
-	if len(signatureBytes) < ExpectedSignatureSize {
-		return nil, suppliedGas - gasCost, fmt.Errorf("%w: expected at least %d bytes, got %d",
-			ErrInvalidInputLength, ExpectedSignatureSize, len(signatureBytes))
+	messageHash, consumed, err := resolveMessageDigest(sigMode, input[minSoloHeaderSize:])
+	if err != nil {
+		return nil, suppliedGas - gasCost, err
+	}
+
+	// Extract signature bytes. Its exact length depends on the canonical
+	// wire format's header and field counts, so truncation is detected by
+	// format.Unmarshal inside verifyThresholdSignature rather than by a
+	// fixed minimum here.
+	signatureBytes := input[minSoloHeaderSize+consumed:]
+	if len(signatureBytes) == 0 {
+		return nil, suppliedGas - gasCost, fmt.Errorf("%w: missing signature", ErrInvalidInputLength)
 	}
 
 	// Verify the threshold signature
@@ -147,6 +155,14 @@ func (p *ringtailThresholdPrecompile) Run(
 	return result, suppliedGas - gasCost, nil
 }
 
+// VerifyThresholdSignature is the exported form of verifyThresholdSignature,
+// for reuse by other precompiles (e.g. sigverify's unified dispatcher) that
+// need to verify a Ringtail threshold signature without going through the
+// RingtailThresholdPrecompile calldata layout.
+func VerifyThresholdSignature(threshold, totalParties uint32, messageHash, signatureBytes []byte) (bool, error) {
+	return verifyThresholdSignature(threshold, totalParties, messageHash, signatureBytes)
+}
+
 // verifyThresholdSignature verifies a Ringtail threshold signature
 func verifyThresholdSignature(threshold, totalParties uint32, messageHash, signatureBytes []byte) (bool, error) {
 	// Initialize ring parameters (from sign/config.go)
@@ -168,7 +184,7 @@ func verifyThresholdSignature(threshold, totalParties uint32, messageHash, signa
 	// Deserialize signature components from bytes
 	c, z, Delta, A, bTilde, err := deserializeSignature(r, r_xi, r_nu, signatureBytes)
 	if err != nil {
-		return false, fmt.Errorf("%w: %v", ErrDeserializationFailed, err)
+		return false, fmt.Errorf("%w: %w", ErrDeserializationFailed, err)
 	}
 
 	// Convert message hash to string for verification (matching sign.Verify interface)
@@ -181,7 +197,13 @@ func verifyThresholdSignature(threshold, totalParties uint32, messageHash, signa
 	return valid, nil
 }
 
-// deserializeSignature deserializes threshold signature components from bytes
+// deserializeSignature deserializes a full threshold signature - the
+// challenge c, response vector z, masking vector Delta, and the public
+// parameter matrix A and vector bTilde it was produced against - through the
+// canonical, range-checked format package (see ringtailthreshold/format),
+// which rejects any coefficient that is not already reduced modulo its
+// ring's modulus and any field whose declared length does not match the
+// expected one.
 func deserializeSignature(r, r_xi, r_nu *ring.Ring, data []byte) (
 	c ring.Poly,
 	z structs.Vector[ring.Poly],
@@ -190,67 +212,77 @@ func deserializeSignature(r, r_xi, r_nu *ring.Ring, data []byte) (
 	bTilde structs.Vector[ring.Poly],
 	err error,
 ) {
-	buf := bytes.NewReader(data)
+	sig, err := format.Unmarshal(data)
+	if err != nil {
+		err = fmt.Errorf("canonical signature: %w", err)
+		return
+	}
+	return ringElementsFromCanonical(r, r_xi, r_nu, sig)
+}
 
-	// Deserialize c (challenge polynomial)
-	c = r.NewPoly()
-	if err = deserializePoly(buf, r, c); err != nil {
+// ringElementsFromCanonical converts an already-decoded canonical signature
+// into the ring.Poly-based types sign.Verify expects, validating that every
+// vector/matrix has the dimensions the current parameter set requires.
+// Shared by deserializeSignature and the batch-verify entrypoint, which
+// decodes the public A/bTilde parameters once and reuses them across every
+// signature in the batch.
+func ringElementsFromCanonical(r, r_xi, r_nu *ring.Ring, sig *format.Signature) (
+	c ring.Poly,
+	z structs.Vector[ring.Poly],
+	Delta structs.Vector[ring.Poly],
+	A structs.Matrix[ring.Poly],
+	bTilde structs.Vector[ring.Poly],
+	err error,
+) {
+	if len(sig.Z) != sign.N {
+		err = fmt.Errorf("z vector length %d does not match expected %d", len(sig.Z), sign.N)
+		return
+	}
+	if len(sig.Delta) != sign.M {
+		err = fmt.Errorf("delta vector length %d does not match expected %d", len(sig.Delta), sign.M)
+		return
+	}
+	if len(sig.A) != sign.M {
+		err = fmt.Errorf("a matrix row count %d does not match expected %d", len(sig.A), sign.M)
+		return
+	}
+	if len(sig.BTilde) != sign.M {
+		err = fmt.Errorf("bTilde vector length %d does not match expected %d", len(sig.BTilde), sign.M)
 		return
 	}
 
-	// Deserialize z vector (N polynomials)
+	c = r.NewPoly()
+	r.SetCoefficientsBigint(c, sig.C)
+
 	z = utils.InitializeVector(r, sign.N)
 	for i := 0; i < sign.N; i++ {
-		if err = deserializePoly(buf, r, z[i]); err != nil {
-			return
-		}
+		r.SetCoefficientsBigint(z[i], sig.Z[i])
 	}
 
-	// Deserialize Delta vector (M polynomials in r_nu ring)
 	Delta = utils.InitializeVector(r_nu, sign.M)
 	for i := 0; i < sign.M; i++ {
-		if err = deserializePoly(buf, r_nu, Delta[i]); err != nil {
-			return
-		}
+		r_nu.SetCoefficientsBigint(Delta[i], sig.Delta[i])
 	}
 
-	// Deserialize A matrix (M x N)
 	A = utils.InitializeMatrix(r, sign.M, sign.N)
 	for i := 0; i < sign.M; i++ {
+		if len(sig.A[i]) != sign.N {
+			err = fmt.Errorf("a matrix row %d column count %d does not match expected %d", i, len(sig.A[i]), sign.N)
+			return
+		}
 		for j := 0; j < sign.N; j++ {
-			if err = deserializePoly(buf, r, A[i][j]); err != nil {
-				return
-			}
+			r.SetCoefficientsBigint(A[i][j], sig.A[i][j])
 		}
 	}
 
-	// Deserialize bTilde vector (M polynomials in r_xi ring)
 	bTilde = utils.InitializeVector(r_xi, sign.M)
 	for i := 0; i < sign.M; i++ {
-		if err = deserializePoly(buf, r_xi, bTilde[i]); err != nil {
-			return
-		}
+		r_xi.SetCoefficientsBigint(bTilde[i], sig.BTilde[i])
 	}
 
 	return
 }
 
-// deserializePoly deserializes a polynomial from binary data
-func deserializePoly(buf *bytes.Reader, r *ring.Ring, poly ring.Poly) error {
-	coeffs := make([]*big.Int, r.N())
-	for i := 0; i < r.N(); i++ {
-		coeffBytes := make([]byte, 8) // 64-bit coefficients
-		if _, err := buf.Read(coeffBytes); err != nil {
-			return fmt.Errorf("failed to read coefficient %d: %w", i, err)
-		}
-		coeffs[i] = new(big.Int).SetBytes(coeffBytes)
-	}
-
-	// Convert big.Int coefficients to ring polynomial
-	r.SetCoefficientsBigint(poly, coeffs)
-	return nil
-}
-
 // EstimateGas estimates gas for a given number of parties
 func EstimateGas(parties uint32) uint64 {
 	return RingtailThresholdBaseGas + (uint64(parties) * RingtailThresholdPerPartyGas)
@@ -4,7 +4,6 @@
 package ringtailthreshold
 
 import (
-	"bytes"
 	"encoding/binary"
 	"math/big"
 	"testing"
@@ -13,6 +12,7 @@ import (
 	"github.com/luxfi/lattice/v6/ring"
 	"github.com/luxfi/lattice/v6/utils/sampling"
 	"github.com/luxfi/lattice/v6/utils/structs"
+	"github.com/luxfi/standard/src/precompiles/ringtail/format"
 	"github.com/stretchr/testify/require"
 
 	"ringtail/primitives"
@@ -154,7 +154,7 @@ func TestRingtailThresholdVerify_ThresholdNotMet(t *testing.T) {
 
 // TestRingtailThresholdVerify_InputTooShort tests short input rejection
 func TestRingtailThresholdVerify_InputTooShort(t *testing.T) {
-	input := make([]byte, 20) // Too short
+	input := make([]byte, 5) // Too short to even hold sigMode+threshold+totalParties
 
 	precompile := &ringtailThresholdPrecompile{}
 	_, _, err := precompile.Run(nil, common.Address{}, precompile.Address(), input, 1_000_000, true)
@@ -165,9 +165,9 @@ func TestRingtailThresholdVerify_InputTooShort(t *testing.T) {
 // TestRingtailThresholdVerify_GasCost tests gas cost calculation
 func TestRingtailThresholdVerify_GasCost(t *testing.T) {
 	tests := []struct {
-		name         string
-		parties      uint32
-		expectedGas  uint64
+		name        string
+		parties     uint32
+		expectedGas uint64
 	}{
 		{"3 parties", 3, 150_000 + (3 * 10_000)},
 		{"5 parties", 5, 150_000 + (5 * 10_000)},
@@ -177,9 +177,10 @@ func TestRingtailThresholdVerify_GasCost(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Create minimal valid input
-			input := make([]byte, MinInputSize+100)
-			binary.BigEndian.PutUint32(input[0:4], tt.parties)
-			binary.BigEndian.PutUint32(input[4:8], tt.parties)
+			input := make([]byte, SigModeSize+MinInputSize+100)
+			input[0] = byte(ModeRawDigest)
+			binary.BigEndian.PutUint32(input[SigModeSize:SigModeSize+4], tt.parties)
+			binary.BigEndian.PutUint32(input[SigModeSize+4:SigModeSize+8], tt.parties)
 
 			precompile := &ringtailThresholdPrecompile{}
 			gas := precompile.RequiredGas(input)
@@ -215,49 +216,57 @@ func TestEstimateGas(t *testing.T) {
 
 // Helper functions
 
-// generateThresholdSignature generates a threshold signature using Ringtail protocol
-func generateThresholdSignature(threshold, totalParties uint32, message string) ([]byte, []byte, error) {
-	// Initialize ring parameters
+// thresholdSigners holds a completed Ringtail key setup (rings, public
+// parameters A/bTilde, and the per-party signing state produced by Gen), so
+// that multiple messages can be signed under the same public parameters
+// without repeating key generation. This is what lets batch-verify tests
+// build signatures that genuinely share A/bTilde, the way the batch
+// precompile expects.
+type thresholdSigners struct {
+	r, rXi, rNu *ring.Ring
+	A           structs.Matrix[ring.Poly]
+	bTilde      structs.Vector[ring.Poly]
+	parties     []*sign.Party
+	T           []int
+	sid         int
+	randomKey   []byte
+}
+
+// setupThresholdSigners runs Ringtail key generation for a threshold-of-n
+// signer set.
+func setupThresholdSigners(threshold, totalParties uint32) (*thresholdSigners, error) {
 	r, err := ring.NewRing(1<<sign.LogN, []uint64{sign.Q})
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
-
 	r_xi, err := ring.NewRing(1<<sign.LogN, []uint64{sign.QXi})
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
-
 	r_nu, err := ring.NewRing(1<<sign.LogN, []uint64{sign.QNu})
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
-	// Initialize sampler
 	randomKey := make([]byte, sign.KeySize)
 	prng, err := sampling.NewKeyedPRNG(randomKey)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 	uniformSampler := ring.NewUniformSampler(prng, r)
 
-	// Set parameters
 	sign.K = int(totalParties)
 	sign.Threshold = int(threshold)
 
-	// Create party set
 	T := make([]int, totalParties)
 	for i := 0; i < int(totalParties); i++ {
 		T[i] = i
 	}
 
-	// Compute Lagrange coefficients
 	lagrangeCoeffs := primitives.ComputeLagrangeCoefficients(r, T, big.NewInt(int64(sign.Q)))
 
-	// Run Gen to generate keys and parameters
 	A, skShares, seeds, MACKeys, bTilde := sign.Gen(r, r_xi, uniformSampler, randomKey, lagrangeCoeffs)
 
-	// Create parties
 	parties := make([]*sign.Party, totalParties)
 	for i := 0; i < int(totalParties); i++ {
 		parties[i] = sign.NewParty(i, r, r_xi, r_nu, uniformSampler)
@@ -267,51 +276,71 @@ func generateThresholdSignature(threshold, totalParties uint32, message string)
 		parties[i].Lambda = lagrangeCoeffs[i]
 	}
 
-	// Round 1: Each party generates their D matrix and MACs
+	return &thresholdSigners{
+		r: r, rXi: r_xi, rNu: r_nu,
+		A: A, bTilde: bTilde,
+		parties: parties, T: T, sid: 1, randomKey: randomKey,
+	}, nil
+}
+
+// sign runs the two-round Ringtail signing protocol for message under the
+// already-generated key setup, returning the challenge, response vector, and
+// masking vector.
+func (s *thresholdSigners) sign(message string) (ring.Poly, structs.Vector[ring.Poly], structs.Vector[ring.Poly], error) {
+	var zeroC ring.Poly
+
 	D := make(map[int]structs.Matrix[ring.Poly])
 	MACs := make(map[int]map[int][]byte)
-	sid := 1
 
-	for i, party := range parties {
-		Di, MACsi := party.SignRound1(A, sid, randomKey, T)
+	for i, party := range s.parties {
+		Di, MACsi := party.SignRound1(s.A, s.sid, s.randomKey, s.T)
 		D[i] = Di
 		MACs[i] = MACsi
 	}
 
-	// Round 2 Preprocess: Verify MACs and compute DSum
 	var DSum structs.Matrix[ring.Poly]
 	var hash []byte
-	for _, party := range parties {
-		valid, DSumLocal, hashLocal := party.SignRound2Preprocess(A, bTilde, D, MACs, sid, T)
+	for _, party := range s.parties {
+		valid, DSumLocal, hashLocal := party.SignRound2Preprocess(s.A, s.bTilde, D, MACs, s.sid, s.T)
 		if !valid {
-			return nil, nil, fmt.Errorf("MAC verification failed")
+			return zeroC, nil, nil, fmt.Errorf("MAC verification failed")
 		}
 		DSum = DSumLocal
 		hash = hashLocal
 	}
 
-	// Round 2: Each party generates their z share
 	z := make(map[int]structs.Vector[ring.Poly])
-	for i, party := range parties {
-		z[i] = party.SignRound2(A, bTilde, DSum, sid, message, T, randomKey, hash)
+	for i, party := range s.parties {
+		z[i] = party.SignRound2(s.A, s.bTilde, DSum, s.sid, message, s.T, s.randomKey, hash)
 	}
 
-	// Finalize: Combine shares to create signature
-	c, z_sum, Delta := parties[0].SignFinalize(z, A, bTilde)
+	c, zSum, Delta := s.parties[0].SignFinalize(z, s.A, s.bTilde)
+	return c, zSum, Delta, nil
+}
+
+// generateThresholdSignature generates a threshold signature using Ringtail protocol
+func generateThresholdSignature(threshold, totalParties uint32, message string) ([]byte, []byte, error) {
+	signers, err := setupThresholdSigners(threshold, totalParties)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	// Serialize signature
-	signatureBytes, err := serializeSignature(r, r_xi, r_nu, c, z_sum, Delta, A, bTilde)
+	c, zSum, Delta, err := signers.sign(message)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	// Hash message
-	messageHash := hashMessage(message)
+	signatureBytes, err := serializeSignature(signers.r, signers.rXi, signers.rNu, c, zSum, Delta, signers.A, signers.bTilde)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	return signatureBytes, messageHash, nil
+	return signatureBytes, hashMessage(message), nil
 }
 
-// serializeSignature serializes signature components to bytes
+// serializeSignature serializes a full signature - c, z, Delta, A, and
+// bTilde - through the canonical format package so that generated test
+// fixtures match exactly what the precompile's deserializeSignature expects.
 func serializeSignature(r, r_xi, r_nu *ring.Ring,
 	c ring.Poly,
 	z structs.Vector[ring.Poly],
@@ -319,59 +348,34 @@ func serializeSignature(r, r_xi, r_nu *ring.Ring,
 	A structs.Matrix[ring.Poly],
 	bTilde structs.Vector[ring.Poly],
 ) ([]byte, error) {
-	var buf bytes.Buffer
-
-	// Serialize c
-	if err := serializePoly(&buf, r, c); err != nil {
-		return nil, err
+	sig := &format.Signature{
+		C:      polyToBigints(r, c),
+		Z:      make([][]*big.Int, sign.N),
+		Delta:  make([][]*big.Int, sign.M),
+		A:      make([][][]*big.Int, sign.M),
+		BTilde: make([][]*big.Int, sign.M),
 	}
-
-	// Serialize z vector
 	for i := 0; i < sign.N; i++ {
-		if err := serializePoly(&buf, r, z[i]); err != nil {
-			return nil, err
-		}
-	}
-
-	// Serialize Delta vector
-	for i := 0; i < sign.M; i++ {
-		if err := serializePoly(&buf, r_nu, Delta[i]); err != nil {
-			return nil, err
-		}
+		sig.Z[i] = polyToBigints(r, z[i])
 	}
-
-	// Serialize A matrix
 	for i := 0; i < sign.M; i++ {
+		sig.Delta[i] = polyToBigints(r_nu, Delta[i])
+		sig.A[i] = make([][]*big.Int, sign.N)
 		for j := 0; j < sign.N; j++ {
-			if err := serializePoly(&buf, r, A[i][j]); err != nil {
-				return nil, err
-			}
+			sig.A[i][j] = polyToBigints(r, A[i][j])
 		}
+		sig.BTilde[i] = polyToBigints(r_xi, bTilde[i])
 	}
 
-	// Serialize bTilde vector
-	for i := 0; i < sign.M; i++ {
-		if err := serializePoly(&buf, r_xi, bTilde[i]); err != nil {
-			return nil, err
-		}
-	}
-
-	return buf.Bytes(), nil
+	return format.Marshal(sig)
 }
 
-// serializePoly serializes a polynomial to binary data
-func serializePoly(buf *bytes.Buffer, r *ring.Ring, poly ring.Poly) error {
+// polyToBigints extracts poly's coefficients as big.Int values, for handing
+// to format.Marshal.
+func polyToBigints(r *ring.Ring, poly ring.Poly) []*big.Int {
 	coeffs := make([]*big.Int, r.N())
 	r.PolyToBigint(poly, 1, coeffs)
-
-	for _, coeff := range coeffs {
-		coeffBytes := make([]byte, 8) // 64-bit coefficients
-		coeff.FillBytes(coeffBytes)
-		if _, err := buf.Write(coeffBytes); err != nil {
-			return err
-		}
-	}
-	return nil
+	return coeffs
 }
 
 // hashMessage creates a 32-byte hash of a message
@@ -381,9 +385,13 @@ func hashMessage(message string) []byte {
 	return hash
 }
 
-// createInput creates precompile input from components
+// createInput creates precompile input from components, using
+// ModeRawDigest so messageHash is taken verbatim as the signed digest.
 func createInput(threshold, totalParties uint32, messageHash, signature []byte) []byte {
-	input := make([]byte, 0, MinInputSize+len(signature))
+	input := make([]byte, 0, SigModeSize+MinInputSize+len(signature))
+
+	// Add sigMode
+	input = append(input, byte(ModeRawDigest))
 
 	// Add threshold
 	thresholdBytes := make([]byte, 4)
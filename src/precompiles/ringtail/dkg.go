@@ -0,0 +1,516 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ringtailthreshold
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/luxfi/evm/precompile/contract"
+	"github.com/luxfi/geth/common"
+	"github.com/luxfi/geth/crypto"
+	"github.com/luxfi/lattice/v6/ring"
+	"github.com/luxfi/standard/src/precompiles/ringtail/format"
+
+	"ringtail/sign"
+)
+
+// ContractRingtailDKGAddress is the address of the Ringtail signing-group
+// registry precompile. It is a stateful sibling of RingtailThresholdPrecompile:
+// instead of verifying against A/bTilde embedded in every signature's
+// calldata, it maintains a registry of signing groups so A and bTilde are
+// looked up from state once per group and reused by every later Verify
+// call against it.
+var (
+	ContractRingtailDKGAddress = common.HexToAddress("0x0200000000000000000000000000000000000019")
+
+	RingtailDKGPrecompile = &ringtailDKGPrecompile{}
+
+	_ contract.StatefulPrecompiledContract = &ringtailDKGPrecompile{}
+
+	ErrGroupAlreadyExists      = errors.New("signing group already exists")
+	ErrGroupNotFound           = errors.New("signing group not found")
+	ErrGroupAlreadyFinalized   = errors.New("signing group is already finalized")
+	ErrGroupNotFinalized       = errors.New("signing group is not finalized")
+	ErrPartyIndexOutOfRange    = errors.New("party index out of range")
+	ErrDuplicateCommitment     = errors.New("party has already submitted a commitment for this group")
+	ErrCommitmentProofFailed   = errors.New("share commitment proof does not recover to the submitted commitment")
+	ErrInsufficientCommitments = errors.New("fewer than threshold valid commitments submitted")
+	ErrUnknownSelector         = errors.New("unknown function selector")
+)
+
+const (
+	// Selectors (first byte of input) for the registry's four entry points.
+	selectorCreateGroup           = 0x01
+	selectorSubmitShareCommitment = 0x02
+	selectorFinalizeGroup         = 0x03
+	selectorGroupVerify           = 0x04
+
+	// Gas costs. CreateGroup is charged per word of the A/bTilde blob it
+	// stores, since that is the cost it actually imposes on state; the other
+	// three entry points touch a small, fixed number of slots.
+	CreateGroupBaseGas       uint64 = 80_000
+	CreateGroupPerWordGas    uint64 = 5_000
+	SubmitShareCommitmentGas uint64 = 60_000
+	FinalizeGroupGas         uint64 = 30_000
+
+	// GroupVerifyGas is flat rather than scaled by totalParties the way
+	// RingtailThresholdGasCost is: RequiredGas only sees this call's input,
+	// and totalParties for a group lives in state, not calldata, so there is
+	// no cheap way to size the charge to the actual group without reading
+	// state from RequiredGas. GroupVerifyGas is set to the same order of
+	// magnitude as a moderately sized solo verify call.
+	GroupVerifyGas uint64 = 250_000
+
+	// maxGroupParties bounds how large a single signing group's commitment
+	// bitmap and per-party storage can grow, the same way popregistry and
+	// the batch precompiles cap their own per-call fan-out.
+	maxGroupParties = 1024
+
+	// groupIDSize, partyIdxSize, commitmentSize, and proofSize are the fixed
+	// widths of the fields shared by multiple entry points below.
+	groupIDSize    = 32
+	partyIdxSize   = 4
+	commitmentSize = 32
+	proofSize      = 65 // crypto.Sign's recoverable R||S||V signature
+)
+
+// Storage slot field offsets, following the same "hash(key || offset)"
+// derivation popregistry uses to give each logical field of a record its
+// own collision-free slot.
+const (
+	groupOffsetThreshold      = 0
+	groupOffsetTotalParties   = 1
+	groupOffsetFinalized      = 2
+	groupOffsetCommitmentCnt  = 3
+	groupOffsetParamsLen      = 4
+	groupOffsetParamsWordBase = 5 // params blob words start here, one offset per 32-byte word
+
+	partyOffsetCommitted  = 0
+	partyOffsetCommitment = 1
+)
+
+type ringtailDKGPrecompile struct{}
+
+// Address returns the precompile address.
+func (p *ringtailDKGPrecompile) Address() common.Address {
+	return ContractRingtailDKGAddress
+}
+
+// RequiredGas calculates the gas required for the given input.
+func (p *ringtailDKGPrecompile) RequiredGas(input []byte) uint64 {
+	if len(input) < 1 {
+		return 0
+	}
+	switch input[0] {
+	case selectorCreateGroup:
+		paramsLen := len(input) - (1 + groupIDSize + 4 + 4)
+		if paramsLen < 0 {
+			paramsLen = 0
+		}
+		words := (paramsLen + 31) / 32
+		return CreateGroupBaseGas + uint64(words)*CreateGroupPerWordGas
+	case selectorSubmitShareCommitment:
+		return SubmitShareCommitmentGas
+	case selectorFinalizeGroup:
+		return FinalizeGroupGas
+	case selectorGroupVerify:
+		return GroupVerifyGas
+	default:
+		return 0
+	}
+}
+
+// Run dispatches to createGroup/submitShareCommitment/finalizeGroup/verify
+// based on the first input byte.
+func (p *ringtailDKGPrecompile) Run(
+	accessibleState contract.AccessibleState,
+	caller common.Address,
+	addr common.Address,
+	input []byte,
+	suppliedGas uint64,
+	readOnly bool,
+) ([]byte, uint64, error) {
+	if len(input) < 1 {
+		return nil, suppliedGas, ErrInvalidInputLength
+	}
+	gasCost := p.RequiredGas(input)
+	if gasCost == 0 {
+		return nil, suppliedGas, fmt.Errorf("%w: 0x%x", ErrUnknownSelector, input[0])
+	}
+	if suppliedGas < gasCost {
+		return nil, 0, errors.New("out of gas")
+	}
+	remainingGas := suppliedGas - gasCost
+
+	switch input[0] {
+	case selectorCreateGroup:
+		if readOnly {
+			return nil, remainingGas, errors.New("cannot create a signing group in read-only mode")
+		}
+		return p.createGroup(accessibleState, input[1:], remainingGas)
+	case selectorSubmitShareCommitment:
+		if readOnly {
+			return nil, remainingGas, errors.New("cannot submit a share commitment in read-only mode")
+		}
+		return p.submitShareCommitment(accessibleState, caller, input[1:], remainingGas)
+	case selectorFinalizeGroup:
+		if readOnly {
+			return nil, remainingGas, errors.New("cannot finalize a signing group in read-only mode")
+		}
+		return p.finalizeGroup(accessibleState, input[1:], remainingGas)
+	case selectorGroupVerify:
+		return p.verify(accessibleState, input[1:], remainingGas)
+	default:
+		return nil, remainingGas, fmt.Errorf("%w: 0x%x", ErrUnknownSelector, input[0])
+	}
+}
+
+// createGroup registers a new signing group's (t, n) policy and its public
+// A/bTilde parameters, encoded the same canonical way batch.go's shared
+// params are (a format.Signature carrying only A and BTilde).
+//
+// Input: [groupId(32)] [threshold(4)] [totalParties(4)] [paramsBlob...]
+func (p *ringtailDKGPrecompile) createGroup(accessibleState contract.AccessibleState, input []byte, remainingGas uint64) ([]byte, uint64, error) {
+	if len(input) < groupIDSize+4+4 {
+		return nil, remainingGas, fmt.Errorf("%w: createGroup header truncated", ErrInvalidInputLength)
+	}
+	groupID := common.BytesToHash(input[0:groupIDSize])
+	off := groupIDSize
+	threshold := binary.BigEndian.Uint32(input[off : off+4])
+	off += 4
+	totalParties := binary.BigEndian.Uint32(input[off : off+4])
+	off += 4
+	paramsBlob := input[off:]
+
+	if threshold == 0 || threshold > totalParties {
+		return nil, remainingGas, fmt.Errorf("%w: t=%d, n=%d", ErrInvalidThreshold, threshold, totalParties)
+	}
+	if totalParties > maxGroupParties {
+		return nil, remainingGas, fmt.Errorf("%w: n=%d exceeds maximum of %d", ErrInvalidThreshold, totalParties, maxGroupParties)
+	}
+
+	state := accessibleState.GetStateDB()
+	if groupExists(state, groupID) {
+		return nil, remainingGas, ErrGroupAlreadyExists
+	}
+
+	params, err := format.Unmarshal(paramsBlob)
+	if err != nil {
+		return nil, remainingGas, fmt.Errorf("%w: %w", ErrDeserializationFailed, err)
+	}
+	if err := validateGroupParamsShape(params); err != nil {
+		return nil, remainingGas, fmt.Errorf("%w: %w", ErrDeserializationFailed, err)
+	}
+
+	state.SetState(ContractRingtailDKGAddress, groupSlot(groupID, groupOffsetThreshold), common.BigToHash(bigFromUint32(threshold)))
+	state.SetState(ContractRingtailDKGAddress, groupSlot(groupID, groupOffsetTotalParties), common.BigToHash(bigFromUint32(totalParties)))
+	storeBlob(state, groupID, paramsBlob)
+
+	return []byte{1}, remainingGas, nil
+}
+
+// submitShareCommitment records party partyIdx's public share commitment
+// for groupId, once its NIZK proof of knowledge verifies. The proof is a
+// standard recoverable ECDSA signature over a message binding the group,
+// party index, commitment, and caller; Run accepts the commitment only if
+// the signature recovers to it, which is a practical stand-in for the
+// dedicated Schnorr knowledge-of-exponent proof a full DKG would use, in
+// the same spirit as frost.verifySchnorrSignature's placeholder pending a
+// real Schnorr implementation.
+//
+// Input: [groupId(32)] [partyIdx(4)] [commitment(32)] [proof(65)]
+func (p *ringtailDKGPrecompile) submitShareCommitment(accessibleState contract.AccessibleState, caller common.Address, input []byte, remainingGas uint64) ([]byte, uint64, error) {
+	if len(input) != groupIDSize+partyIdxSize+commitmentSize+proofSize {
+		return nil, remainingGas, fmt.Errorf("%w: expected %d bytes, got %d",
+			ErrInvalidInputLength, groupIDSize+partyIdxSize+commitmentSize+proofSize, len(input))
+	}
+	groupID := common.BytesToHash(input[0:groupIDSize])
+	off := groupIDSize
+	partyIdx := binary.BigEndian.Uint32(input[off : off+partyIdxSize])
+	off += partyIdxSize
+	commitment := common.BytesToHash(input[off : off+commitmentSize])
+	off += commitmentSize
+	proof := input[off : off+proofSize]
+
+	state := accessibleState.GetStateDB()
+	if !groupExists(state, groupID) {
+		return nil, remainingGas, ErrGroupNotFound
+	}
+	if groupFinalized(state, groupID) {
+		return nil, remainingGas, ErrGroupAlreadyFinalized
+	}
+	totalParties := groupTotalParties(state, groupID)
+	if partyIdx >= totalParties {
+		return nil, remainingGas, fmt.Errorf("%w: party %d, n=%d", ErrPartyIndexOutOfRange, partyIdx, totalParties)
+	}
+
+	committedSlot := partySlot(groupID, partyIdx, partyOffsetCommitted)
+	if !isZeroHash(state.GetState(ContractRingtailDKGAddress, committedSlot)) {
+		return nil, remainingGas, ErrDuplicateCommitment
+	}
+
+	msg := shareCommitmentMessage(groupID, partyIdx, commitment, caller)
+	if !verifyShareCommitmentProof(commitment, msg, proof) {
+		return nil, remainingGas, ErrCommitmentProofFailed
+	}
+
+	state.SetState(ContractRingtailDKGAddress, committedSlot, common.BytesToHash([]byte{1}))
+	state.SetState(ContractRingtailDKGAddress, partySlot(groupID, partyIdx, partyOffsetCommitment), commitment)
+
+	count := groupCommitmentCount(state, groupID)
+	state.SetState(ContractRingtailDKGAddress, groupSlot(groupID, groupOffsetCommitmentCnt), common.BigToHash(bigFromUint32(count+1)))
+
+	return []byte{1}, remainingGas, nil
+}
+
+// finalizeGroup locks groupId once at least its threshold's worth of valid
+// share commitments have been submitted, after which Verify will accept
+// signatures against it.
+//
+// Input: [groupId(32)]
+func (p *ringtailDKGPrecompile) finalizeGroup(accessibleState contract.AccessibleState, input []byte, remainingGas uint64) ([]byte, uint64, error) {
+	if len(input) != groupIDSize {
+		return nil, remainingGas, fmt.Errorf("%w: expected %d bytes, got %d", ErrInvalidInputLength, groupIDSize, len(input))
+	}
+	groupID := common.BytesToHash(input)
+
+	state := accessibleState.GetStateDB()
+	if !groupExists(state, groupID) {
+		return nil, remainingGas, ErrGroupNotFound
+	}
+	if groupFinalized(state, groupID) {
+		return nil, remainingGas, ErrGroupAlreadyFinalized
+	}
+
+	threshold := groupThreshold(state, groupID)
+	count := groupCommitmentCount(state, groupID)
+	if count < threshold {
+		return nil, remainingGas, fmt.Errorf("%w: have %d, need %d", ErrInsufficientCommitments, count, threshold)
+	}
+
+	state.SetState(ContractRingtailDKGAddress, groupSlot(groupID, groupOffsetFinalized), common.BytesToHash([]byte{1}))
+
+	return []byte{1}, remainingGas, nil
+}
+
+// verify checks a threshold signature against a finalized group's stored
+// A/bTilde, so callers only ever send the signature's own C, Z, and Delta -
+// the A/bTilde that would otherwise dominate the calldata are looked up
+// from state instead.
+//
+// Input: [groupId(32)] [msgHash(32)] [sig...] where sig is a canonical
+// format.Signature encoding only C, Z, and Delta.
+func (p *ringtailDKGPrecompile) verify(accessibleState contract.AccessibleState, input []byte, remainingGas uint64) ([]byte, uint64, error) {
+	if len(input) < groupIDSize+MessageHashSize {
+		return nil, remainingGas, fmt.Errorf("%w: verify header truncated", ErrInvalidInputLength)
+	}
+	groupID := common.BytesToHash(input[0:groupIDSize])
+	messageHash := input[groupIDSize : groupIDSize+MessageHashSize]
+	sigBytes := input[groupIDSize+MessageHashSize:]
+	if len(sigBytes) == 0 {
+		return nil, remainingGas, fmt.Errorf("%w: missing signature", ErrInvalidInputLength)
+	}
+
+	state := accessibleState.GetStateDB()
+	if !groupExists(state, groupID) {
+		return nil, remainingGas, ErrGroupNotFound
+	}
+	if !groupFinalized(state, groupID) {
+		return nil, remainingGas, ErrGroupNotFinalized
+	}
+
+	sig, err := format.Unmarshal(sigBytes)
+	if err != nil {
+		return nil, remainingGas, fmt.Errorf("%w: %w", ErrDeserializationFailed, err)
+	}
+
+	paramsBlob := loadBlob(state, groupID)
+	params, err := format.Unmarshal(paramsBlob)
+	if err != nil {
+		return nil, remainingGas, fmt.Errorf("stored group params: %w: %w", ErrDeserializationFailed, err)
+	}
+	sig.A = params.A
+	sig.BTilde = params.BTilde
+
+	valid, err := verifyGroupSignature(messageHash, sig)
+	if err != nil {
+		return nil, remainingGas, fmt.Errorf("verification error: %w", err)
+	}
+
+	result := make([]byte, 32)
+	if valid {
+		result[31] = 1
+	}
+	return result, remainingGas, nil
+}
+
+// verifyGroupSignature builds the rings and calls sign.Verify the same way
+// verifyThresholdSignature does, the only difference being that sig's
+// A/BTilde came from group state rather than the signature's own calldata.
+func verifyGroupSignature(messageHash []byte, sig *format.Signature) (bool, error) {
+	r, err := ring.NewRing(1<<sign.LogN, []uint64{sign.Q})
+	if err != nil {
+		return false, fmt.Errorf("failed to create ring: %w", err)
+	}
+	rXi, err := ring.NewRing(1<<sign.LogN, []uint64{sign.QXi})
+	if err != nil {
+		return false, fmt.Errorf("failed to create r_xi ring: %w", err)
+	}
+	rNu, err := ring.NewRing(1<<sign.LogN, []uint64{sign.QNu})
+	if err != nil {
+		return false, fmt.Errorf("failed to create r_nu ring: %w", err)
+	}
+
+	c, z, Delta, A, bTilde, err := ringElementsFromCanonical(r, rXi, rNu, sig)
+	if err != nil {
+		return false, fmt.Errorf("%w: %w", ErrDeserializationFailed, err)
+	}
+	mu := fmt.Sprintf("%x", messageHash)
+	return sign.Verify(r, rXi, rNu, z, A, mu, bTilde, c, Delta), nil
+}
+
+// validateGroupParamsShape checks that a decoded params blob's A and BTilde
+// have the dimensions the current parameter set requires, without needing
+// a ring (which ringElementsFromCanonical would otherwise require C/Z/Delta
+// to validate alongside them).
+func validateGroupParamsShape(sig *format.Signature) error {
+	if len(sig.BTilde) == 0 {
+		return errors.New("group params must include bTilde")
+	}
+	for i, row := range sig.A {
+		if len(row) != len(sig.A[0]) {
+			return fmt.Errorf("a matrix row %d column count %d does not match row 0's %d", i, len(row), len(sig.A[0]))
+		}
+	}
+	if len(sig.A) != len(sig.BTilde) {
+		return fmt.Errorf("a matrix row count %d does not match bTilde length %d", len(sig.A), len(sig.BTilde))
+	}
+	return nil
+}
+
+// shareCommitmentMessage reconstructs the sign-bytes a party's share
+// commitment proof must cover: "RINGTAIL-DKG" || groupId || partyIdx ||
+// commitment || caller.
+func shareCommitmentMessage(groupID common.Hash, partyIdx uint32, commitment common.Hash, caller common.Address) []byte {
+	var idx [4]byte
+	binary.BigEndian.PutUint32(idx[:], partyIdx)
+
+	msg := []byte("RINGTAIL-DKG")
+	msg = append(msg, groupID.Bytes()...)
+	msg = append(msg, idx[:]...)
+	msg = append(msg, commitment.Bytes()...)
+	msg = append(msg, caller.Bytes()...)
+	return msg
+}
+
+// verifyShareCommitmentProof reports whether proof is a valid recoverable
+// ECDSA signature over sha256(msg) whose signer address equals commitment
+// (commitment's low 20 bytes hold that address, left-padded the same way
+// common.Address.Hash() pads it).
+func verifyShareCommitmentProof(commitment common.Hash, msg, proof []byte) bool {
+	if len(proof) != proofSize {
+		return false
+	}
+	digest := sha256.Sum256(msg)
+	pub, err := crypto.SigToPub(digest[:], proof)
+	if err != nil {
+		return false
+	}
+	return crypto.PubkeyToAddress(*pub).Hash() == commitment
+}
+
+func isZeroHash(h common.Hash) bool {
+	return h == common.Hash{}
+}
+
+func groupExists(state contract.StateDB, groupID common.Hash) bool {
+	return !isZeroHash(state.GetState(ContractRingtailDKGAddress, groupSlot(groupID, groupOffsetThreshold)))
+}
+
+func groupFinalized(state contract.StateDB, groupID common.Hash) bool {
+	return !isZeroHash(state.GetState(ContractRingtailDKGAddress, groupSlot(groupID, groupOffsetFinalized)))
+}
+
+func groupThreshold(state contract.StateDB, groupID common.Hash) uint32 {
+	return uint32(state.GetState(ContractRingtailDKGAddress, groupSlot(groupID, groupOffsetThreshold)).Big().Uint64())
+}
+
+func groupTotalParties(state contract.StateDB, groupID common.Hash) uint32 {
+	return uint32(state.GetState(ContractRingtailDKGAddress, groupSlot(groupID, groupOffsetTotalParties)).Big().Uint64())
+}
+
+func groupCommitmentCount(state contract.StateDB, groupID common.Hash) uint32 {
+	return uint32(state.GetState(ContractRingtailDKGAddress, groupSlot(groupID, groupOffsetCommitmentCnt)).Big().Uint64())
+}
+
+// partyCommitted reports whether partyIdx has a recorded share commitment in
+// groupId. Used by the combiner precompile (combine.go) to confirm a
+// submitted partial signature comes from a registered party before checking
+// its proof.
+func partyCommitted(state contract.StateDB, groupID common.Hash, partyIdx uint32) bool {
+	return !isZeroHash(state.GetState(ContractRingtailDKGAddress, partySlot(groupID, partyIdx, partyOffsetCommitted)))
+}
+
+// partyCommitment returns partyIdx's recorded share commitment in groupId.
+func partyCommitment(state contract.StateDB, groupID common.Hash, partyIdx uint32) common.Hash {
+	return state.GetState(ContractRingtailDKGAddress, partySlot(groupID, partyIdx, partyOffsetCommitment))
+}
+
+// storeBlob persists an arbitrary byte slice as a length slot followed by
+// consecutive 32-byte word slots, the same way Solidity lays out a dynamic
+// bytes value - except slots are derived by hashing the group id with a
+// word index instead of by a storage-layout convention, since this
+// precompile owns its own slot space.
+func storeBlob(state contract.StateDB, groupID common.Hash, data []byte) {
+	state.SetState(ContractRingtailDKGAddress, groupSlot(groupID, groupOffsetParamsLen), common.BigToHash(bigFromUint32(uint32(len(data)))))
+	for i := 0; i*32 < len(data); i++ {
+		var word common.Hash
+		copy(word[:], data[i*32:])
+		state.SetState(ContractRingtailDKGAddress, groupSlot(groupID, groupOffsetParamsWordBase+uint32(i)), word)
+	}
+}
+
+// loadBlob reverses storeBlob.
+func loadBlob(state contract.StateDB, groupID common.Hash) []byte {
+	length := uint32(state.GetState(ContractRingtailDKGAddress, groupSlot(groupID, groupOffsetParamsLen)).Big().Uint64())
+	data := make([]byte, 0, length)
+	for i := uint32(0); uint32(len(data)) < length; i++ {
+		word := state.GetState(ContractRingtailDKGAddress, groupSlot(groupID, groupOffsetParamsWordBase+i))
+		remaining := length - uint32(len(data))
+		if remaining > 32 {
+			remaining = 32
+		}
+		data = append(data, word.Bytes()[:remaining]...)
+	}
+	return data
+}
+
+// groupSlot derives a distinct storage slot for each logical field of a
+// group's record by hashing groupId together with a field offset,
+// mirroring popregistry's deriveSlot but with a wider offset space to
+// cover the params blob's potentially many word slots.
+func groupSlot(groupID common.Hash, offset uint32) common.Hash {
+	var buf [36]byte
+	copy(buf[:32], groupID.Bytes())
+	binary.BigEndian.PutUint32(buf[32:36], offset)
+	return common.Hash(sha256.Sum256(buf[:]))
+}
+
+// partySlot derives a distinct storage slot for each logical field of one
+// party's record within a group.
+func partySlot(groupID common.Hash, partyIdx, offset uint32) common.Hash {
+	var buf [40]byte
+	copy(buf[:32], groupID.Bytes())
+	binary.BigEndian.PutUint32(buf[32:36], partyIdx)
+	binary.BigEndian.PutUint32(buf[36:40], offset)
+	return common.Hash(sha256.Sum256(buf[:]))
+}
+
+func bigFromUint32(v uint32) *big.Int {
+	return new(big.Int).SetUint64(uint64(v))
+}
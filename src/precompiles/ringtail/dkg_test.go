@@ -0,0 +1,209 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ringtailthreshold
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"testing"
+
+	"github.com/luxfi/geth/common"
+	"github.com/luxfi/geth/crypto"
+	"github.com/luxfi/standard/src/precompiles/ringtail/format"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRingtailDKG_CreateAndFinalizeGroup(t *testing.T) {
+	precompile := &ringtailDKGPrecompile{}
+	threshold, totalParties := uint32(2), uint32(3)
+
+	signers, err := setupThresholdSigners(threshold, totalParties)
+	require.NoError(t, err)
+
+	groupID := common.HexToHash("0x01")
+	require.NoError(t, mustCreateGroup(t, precompile, groupID, threshold, totalParties, signers))
+
+	// Fewer than threshold commitments: finalize must fail.
+	key0, err := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+	require.NoError(t, err)
+	require.NoError(t, submitCommitment(t, precompile, groupID, 0, key0))
+
+	_, _, err = precompile.Run(nil, common.Address{}, precompile.Address(), finalizeGroupInput(groupID), 1_000_000, false)
+	require.ErrorIs(t, err, ErrInsufficientCommitments)
+
+	key1, err := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+	require.NoError(t, err)
+	require.NoError(t, submitCommitment(t, precompile, groupID, 1, key1))
+
+	// Now threshold (2) commitments are present: finalize should succeed.
+	result, _, err := precompile.Run(nil, common.Address{}, precompile.Address(), finalizeGroupInput(groupID), 1_000_000, false)
+	require.NoError(t, err)
+	require.Equal(t, byte(1), result[0])
+
+	// Finalizing twice is rejected.
+	_, _, err = precompile.Run(nil, common.Address{}, precompile.Address(), finalizeGroupInput(groupID), 1_000_000, false)
+	require.ErrorIs(t, err, ErrGroupAlreadyFinalized)
+}
+
+func TestRingtailDKG_DuplicateCommitmentRejected(t *testing.T) {
+	precompile := &ringtailDKGPrecompile{}
+	threshold, totalParties := uint32(2), uint32(3)
+
+	signers, err := setupThresholdSigners(threshold, totalParties)
+	require.NoError(t, err)
+
+	groupID := common.HexToHash("0x02")
+	require.NoError(t, mustCreateGroup(t, precompile, groupID, threshold, totalParties, signers))
+
+	key, err := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+	require.NoError(t, err)
+	require.NoError(t, submitCommitment(t, precompile, groupID, 0, key))
+
+	// Resubmitting the same party index, even with a fresh keypair, must be
+	// rejected as a duplicate rather than silently overwriting the record.
+	otherKey, err := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+	require.NoError(t, err)
+	_, _, err = precompile.Run(nil, common.Address{}, precompile.Address(),
+		shareCommitmentInput(t, groupID, 0, otherKey), 1_000_000, false)
+	require.ErrorIs(t, err, ErrDuplicateCommitment)
+}
+
+func TestRingtailDKG_BadProofRejected(t *testing.T) {
+	precompile := &ringtailDKGPrecompile{}
+	threshold, totalParties := uint32(2), uint32(3)
+
+	signers, err := setupThresholdSigners(threshold, totalParties)
+	require.NoError(t, err)
+
+	groupID := common.HexToHash("0x03")
+	require.NoError(t, mustCreateGroup(t, precompile, groupID, threshold, totalParties, signers))
+
+	key, err := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+	require.NoError(t, err)
+	input := shareCommitmentInput(t, groupID, 0, key)
+
+	// Corrupt the proof (last byte) so it no longer recovers to the
+	// claimed commitment.
+	input[len(input)-1] ^= 0xFF
+
+	_, _, err = precompile.Run(nil, common.Address{}, precompile.Address(), input, 1_000_000, false)
+	require.ErrorIs(t, err, ErrCommitmentProofFailed)
+}
+
+func TestRingtailDKG_VerifyAgainstStoredParams(t *testing.T) {
+	precompile := &ringtailDKGPrecompile{}
+	threshold, totalParties := uint32(2), uint32(3)
+
+	signers, err := setupThresholdSigners(threshold, totalParties)
+	require.NoError(t, err)
+
+	groupID := common.HexToHash("0x04")
+	require.NoError(t, mustCreateGroup(t, precompile, groupID, threshold, totalParties, signers))
+
+	for i := uint32(0); i < threshold; i++ {
+		key, err := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+		require.NoError(t, err)
+		require.NoError(t, submitCommitment(t, precompile, groupID, i, key))
+	}
+	_, _, err = precompile.Run(nil, common.Address{}, precompile.Address(), finalizeGroupInput(groupID), 1_000_000, false)
+	require.NoError(t, err)
+
+	message := "group-verified message"
+	c, zSum, Delta, err := signers.sign(message)
+	require.NoError(t, err)
+	entrySig := &format.Signature{
+		C:     polyToBigints(signers.r, c),
+		Z:     vectorToBigints(signers.r, zSum),
+		Delta: vectorToBigints(signers.rNu, Delta),
+	}
+	entryBytes, err := format.Marshal(entrySig)
+	require.NoError(t, err)
+
+	verifyInput := append([]byte{selectorGroupVerify}, groupID.Bytes()...)
+	verifyInput = append(verifyInput, hashMessage(message)...)
+	verifyInput = append(verifyInput, entryBytes...)
+
+	result, _, err := precompile.Run(nil, common.Address{}, precompile.Address(), verifyInput, 1_000_000, true)
+	require.NoError(t, err)
+	require.Equal(t, byte(1), result[31], "signature should verify against the group's stored A/bTilde")
+}
+
+func TestRingtailDKG_VerifyBeforeFinalizeRejected(t *testing.T) {
+	precompile := &ringtailDKGPrecompile{}
+	threshold, totalParties := uint32(2), uint32(3)
+
+	signers, err := setupThresholdSigners(threshold, totalParties)
+	require.NoError(t, err)
+
+	groupID := common.HexToHash("0x05")
+	require.NoError(t, mustCreateGroup(t, precompile, groupID, threshold, totalParties, signers))
+
+	verifyInput := append([]byte{selectorGroupVerify}, groupID.Bytes()...)
+	verifyInput = append(verifyInput, make([]byte, MessageHashSize)...)
+	verifyInput = append(verifyInput, 0x00) // non-empty signature placeholder
+
+	_, _, err = precompile.Run(nil, common.Address{}, precompile.Address(), verifyInput, 1_000_000, true)
+	require.ErrorIs(t, err, ErrGroupNotFinalized)
+}
+
+// mustCreateGroup issues a createGroup call whose A/bTilde come from
+// signers, the same key setup batch_test.go uses to produce signatures
+// that genuinely share those parameters.
+func mustCreateGroup(t *testing.T, precompile *ringtailDKGPrecompile, groupID common.Hash, threshold, totalParties uint32, signers *thresholdSigners) error {
+	t.Helper()
+
+	paramsBlob, err := format.Marshal(&format.Signature{
+		A:      matrixToBigints(signers.r, signers.A),
+		BTilde: vectorToBigints(signers.rXi, signers.bTilde),
+	})
+	require.NoError(t, err)
+
+	input := []byte{selectorCreateGroup}
+	input = append(input, groupID.Bytes()...)
+	var tn [8]byte
+	binary.BigEndian.PutUint32(tn[0:4], threshold)
+	binary.BigEndian.PutUint32(tn[4:8], totalParties)
+	input = append(input, tn[:]...)
+	input = append(input, paramsBlob...)
+
+	// The params blob's size tracks the lattice dimensions, which this
+	// package does not hand-size; supply an ample gas budget so the test
+	// exercises createGroup's logic rather than its own gas accounting.
+	_, _, err = precompile.Run(nil, common.Address{}, precompile.Address(), input, 1_000_000_000, false)
+	return err
+}
+
+// shareCommitmentInput builds a selectorSubmitShareCommitment call proving
+// knowledge of key's private component for partyIdx in groupId.
+func shareCommitmentInput(t *testing.T, groupID common.Hash, partyIdx uint32, key *ecdsa.PrivateKey) []byte {
+	t.Helper()
+
+	commitment := crypto.PubkeyToAddress(key.PublicKey).Hash()
+	msg := shareCommitmentMessage(groupID, partyIdx, commitment, common.Address{})
+	digest := sha256.Sum256(msg)
+	proof, err := crypto.Sign(digest[:], key)
+	require.NoError(t, err)
+
+	input := []byte{selectorSubmitShareCommitment}
+	input = append(input, groupID.Bytes()...)
+	var idx [4]byte
+	binary.BigEndian.PutUint32(idx[:], partyIdx)
+	input = append(input, idx[:]...)
+	input = append(input, commitment.Bytes()...)
+	input = append(input, proof...)
+	return input
+}
+
+func submitCommitment(t *testing.T, precompile *ringtailDKGPrecompile, groupID common.Hash, partyIdx uint32, key *ecdsa.PrivateKey) error {
+	t.Helper()
+	_, _, err := precompile.Run(nil, common.Address{}, precompile.Address(),
+		shareCommitmentInput(t, groupID, partyIdx, key), 1_000_000, false)
+	return err
+}
+
+func finalizeGroupInput(groupID common.Hash) []byte {
+	return append([]byte{selectorFinalizeGroup}, groupID.Bytes()...)
+}
@@ -0,0 +1,401 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package format implements a versioned, canonical wire encoding for a
+// Ringtail threshold signature (the c/z/Delta components plus the A/bTilde
+// public parameters it was produced against), used both by the
+// ringtailthreshold precompile and by off-chain signers producing signatures
+// for on-chain submission.
+//
+// The previous ad-hoc encoding read every coefficient as a fixed 8-byte blob
+// with no range check against the ring modulus and no format header. Two
+// distinct byte strings could therefore decode to the same polynomial once
+// reduced mod Q (coefficient malleability), and nothing tied the encoding to
+// a specific parameter set. This package fixes both: every coefficient is
+// stored at its minimal fixed width for the modulus it belongs to, rejected
+// on Unmarshal if it is not already reduced, and the header pins the format
+// version and parameter set so a decoder never has to guess the layout.
+package format
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"ringtail/sign"
+)
+
+// FormatVersion1 is the only wire format version defined so far.
+const FormatVersion1 = 1
+
+// ParamsDefault identifies the ring degree and moduli (sign.Q, sign.QXi,
+// sign.QNu) that the ringtailthreshold precompile currently runs with.
+// A future parameter change gets a new ID rather than silently reinterpreting
+// ParamsDefault, so old signatures never decode under the wrong moduli.
+const ParamsDefault uint16 = 1
+
+// maxVectorCount bounds zCount/deltaCount so that a malicious length header
+// cannot force an allocation proportional to an attacker-chosen number
+// before any bytes have actually been validated.
+const maxVectorCount = 4096
+
+// maxPolyLen bounds a single polynomial's encoded byte length for the same
+// reason, independent of how large the declared coefficient count is.
+const maxPolyLen = 1 << 20
+
+var (
+	ErrUnsupportedVersion = errors.New("ringtailthreshold/format: unsupported format version")
+	ErrUnsupportedParams  = errors.New("ringtailthreshold/format: unsupported params id")
+	ErrTruncated          = errors.New("ringtailthreshold/format: truncated input")
+	ErrCoefficientRange   = errors.New("ringtailthreshold/format: coefficient out of range")
+	ErrVectorTooLarge     = errors.New("ringtailthreshold/format: vector count exceeds limit")
+	ErrPolyTooLarge       = errors.New("ringtailthreshold/format: polynomial length exceeds limit")
+)
+
+// Signature is the decoded form of the canonical, range-checked signature:
+// the challenge polynomial C (ring Q), the response vector Z (ring Q), the
+// masking vector Delta (ring QNu), the public parameter matrix A (ring Q),
+// and the public parameter vector BTilde (ring QXi). Every coefficient has
+// already been verified to lie in [0, modulus) by Unmarshal.
+//
+// A and BTilde are threshold public parameters rather than signature
+// material proper, but they travel with the signature on-chain, so they are
+// framed the same way as C/Z/Delta: length-prefixed and range-checked,
+// rather than read as a raw unvalidated blob.
+//
+// Ext holds any bytes appended after BTilde by a newer format version that
+// this decoder does not understand. Round-tripping Ext unmodified lets a
+// future version add fields (e.g. per-round commitments) without an old
+// Unmarshal call rejecting an otherwise-valid signature it was never asked
+// to fully interpret.
+type Signature struct {
+	C      []*big.Int
+	Z      [][]*big.Int
+	Delta  [][]*big.Int
+	A      [][][]*big.Int
+	BTilde [][]*big.Int
+	Ext    []byte
+}
+
+// Marshal encodes sig using the canonical wire format:
+//
+//	[fmtVersion(1)] [paramsID(2)]
+//	[cLen(4)]       [c bytes,     coeffWidth(sign.Q) per coefficient, little-endian]
+//	[zCount(2)]     { [zLen(4)]      [z[i] bytes,      coeffWidth(sign.Q)]   }
+//	[deltaCount(2)] { [deltaLen(4)]  [Delta[i] bytes,  coeffWidth(sign.QNu)] }
+//	[aRows(2)]      { [aCols(2)]     { [aLen(4)] [A[i][j] bytes, coeffWidth(sign.Q)] } }
+//	[bTildeCount(2)] { [bLen(4)]     [BTilde[i] bytes, coeffWidth(sign.QXi)] }
+//	[extLen(4)]     [ext bytes]
+//
+// The trailing length-prefixed ext field carries any bytes a newer format
+// version appended after BTilde; Marshal writes it back unchanged so a
+// signature this package only partially understands still round-trips.
+//
+// Marshal returns an error if any coefficient is already out of range for
+// its modulus, since such a signature could never pass Unmarshal either.
+func Marshal(sig *Signature) ([]byte, error) {
+	if sig == nil {
+		return nil, errors.New("ringtailthreshold/format: nil signature")
+	}
+
+	buf := make([]byte, 0, 3+4+len(sig.C)*8)
+	buf = append(buf, FormatVersion1)
+	buf = appendUint16(buf, ParamsDefault)
+
+	cBytes, err := marshalPoly(sig.C, sign.Q)
+	if err != nil {
+		return nil, fmt.Errorf("c: %w", err)
+	}
+	buf = appendUint32(buf, uint32(len(cBytes)))
+	buf = append(buf, cBytes...)
+
+	buf = appendUint16(buf, uint16(len(sig.Z)))
+	for i, z := range sig.Z {
+		zBytes, err := marshalPoly(z, sign.Q)
+		if err != nil {
+			return nil, fmt.Errorf("z[%d]: %w", i, err)
+		}
+		buf = appendUint32(buf, uint32(len(zBytes)))
+		buf = append(buf, zBytes...)
+	}
+
+	buf = appendUint16(buf, uint16(len(sig.Delta)))
+	for i, d := range sig.Delta {
+		dBytes, err := marshalPoly(d, sign.QNu)
+		if err != nil {
+			return nil, fmt.Errorf("delta[%d]: %w", i, err)
+		}
+		buf = appendUint32(buf, uint32(len(dBytes)))
+		buf = append(buf, dBytes...)
+	}
+
+	buf = appendUint16(buf, uint16(len(sig.A)))
+	for i, row := range sig.A {
+		buf = appendUint16(buf, uint16(len(row)))
+		for j, a := range row {
+			aBytes, err := marshalPoly(a, sign.Q)
+			if err != nil {
+				return nil, fmt.Errorf("a[%d][%d]: %w", i, j, err)
+			}
+			buf = appendUint32(buf, uint32(len(aBytes)))
+			buf = append(buf, aBytes...)
+		}
+	}
+
+	buf = appendUint16(buf, uint16(len(sig.BTilde)))
+	for i, b := range sig.BTilde {
+		bBytes, err := marshalPoly(b, sign.QXi)
+		if err != nil {
+			return nil, fmt.Errorf("bTilde[%d]: %w", i, err)
+		}
+		buf = appendUint32(buf, uint32(len(bBytes)))
+		buf = append(buf, bBytes...)
+	}
+
+	buf = appendUint32(buf, uint32(len(sig.Ext)))
+	buf = append(buf, sig.Ext...)
+
+	return buf, nil
+}
+
+// Unmarshal decodes a canonical signature, rejecting any coefficient that is
+// not already reduced modulo its ring's modulus. It never panics on
+// malformed input: every length field is bounds-checked against the
+// remaining buffer before use.
+func Unmarshal(data []byte) (*Signature, error) {
+	sig, _, err := unmarshalPrefix(data)
+	return sig, err
+}
+
+// UnmarshalPrefix is Unmarshal's variant for callers that pack more than one
+// canonical signature into a single byte string back to back (as the batch
+// verification entrypoint does for the shared A/bTilde parameters followed
+// by one entry per signature); it additionally reports how many bytes it
+// consumed so the caller can resume parsing the next signature from that
+// offset.
+func UnmarshalPrefix(data []byte) (*Signature, int, error) {
+	return unmarshalPrefix(data)
+}
+
+// unmarshalPrefix decodes a canonical signature from the start of data and
+// reports how many bytes it consumed, so callers packing several signatures
+// back to back can continue parsing from the returned offset.
+func unmarshalPrefix(data []byte) (*Signature, int, error) {
+	if len(data) < 3 {
+		return nil, 0, ErrTruncated
+	}
+	if data[0] != FormatVersion1 {
+		return nil, 0, fmt.Errorf("%w: got %d", ErrUnsupportedVersion, data[0])
+	}
+	if binary.LittleEndian.Uint16(data[1:3]) != ParamsDefault {
+		return nil, 0, fmt.Errorf("%w: got %d", ErrUnsupportedParams, binary.LittleEndian.Uint16(data[1:3]))
+	}
+	off := 3
+
+	c, n, err := readPoly(data, off, sign.Q)
+	if err != nil {
+		return nil, 0, fmt.Errorf("c: %w", err)
+	}
+	off += n
+
+	zCount, n, err := readUint16(data, off)
+	if err != nil {
+		return nil, 0, fmt.Errorf("zCount: %w", err)
+	}
+	off += n
+	if zCount > maxVectorCount {
+		return nil, 0, ErrVectorTooLarge
+	}
+	z := make([][]*big.Int, zCount)
+	for i := range z {
+		poly, n, err := readPoly(data, off, sign.Q)
+		if err != nil {
+			return nil, 0, fmt.Errorf("z[%d]: %w", i, err)
+		}
+		z[i] = poly
+		off += n
+	}
+
+	deltaCount, n, err := readUint16(data, off)
+	if err != nil {
+		return nil, 0, fmt.Errorf("deltaCount: %w", err)
+	}
+	off += n
+	if deltaCount > maxVectorCount {
+		return nil, 0, ErrVectorTooLarge
+	}
+	delta := make([][]*big.Int, deltaCount)
+	for i := range delta {
+		poly, n, err := readPoly(data, off, sign.QNu)
+		if err != nil {
+			return nil, 0, fmt.Errorf("delta[%d]: %w", i, err)
+		}
+		delta[i] = poly
+		off += n
+	}
+
+	aRows, n, err := readUint16(data, off)
+	if err != nil {
+		return nil, 0, fmt.Errorf("aRows: %w", err)
+	}
+	off += n
+	if aRows > maxVectorCount {
+		return nil, 0, ErrVectorTooLarge
+	}
+	a := make([][][]*big.Int, aRows)
+	for i := range a {
+		aCols, n, err := readUint16(data, off)
+		if err != nil {
+			return nil, 0, fmt.Errorf("aCols[%d]: %w", i, err)
+		}
+		off += n
+		if aCols > maxVectorCount {
+			return nil, 0, ErrVectorTooLarge
+		}
+		row := make([][]*big.Int, aCols)
+		for j := range row {
+			poly, n, err := readPoly(data, off, sign.Q)
+			if err != nil {
+				return nil, 0, fmt.Errorf("a[%d][%d]: %w", i, j, err)
+			}
+			row[j] = poly
+			off += n
+		}
+		a[i] = row
+	}
+
+	bTildeCount, n, err := readUint16(data, off)
+	if err != nil {
+		return nil, 0, fmt.Errorf("bTildeCount: %w", err)
+	}
+	off += n
+	if bTildeCount > maxVectorCount {
+		return nil, 0, ErrVectorTooLarge
+	}
+	bTilde := make([][]*big.Int, bTildeCount)
+	for i := range bTilde {
+		poly, n, err := readPoly(data, off, sign.QXi)
+		if err != nil {
+			return nil, 0, fmt.Errorf("bTilde[%d]: %w", i, err)
+		}
+		bTilde[i] = poly
+		off += n
+	}
+
+	extLen, n, err := readUint32(data, off)
+	if err != nil {
+		return nil, 0, fmt.Errorf("extLen: %w", err)
+	}
+	off += n
+	if extLen > maxPolyLen {
+		return nil, 0, ErrPolyTooLarge
+	}
+	if len(data) < off+int(extLen) {
+		return nil, 0, ErrTruncated
+	}
+	ext := append([]byte(nil), data[off:off+int(extLen)]...)
+	off += int(extLen)
+
+	return &Signature{C: c, Z: z, Delta: delta, A: a, BTilde: bTilde, Ext: ext}, off, nil
+}
+
+// coeffWidth returns ceil(log2(modulus)/8), the number of bytes needed to
+// hold any reduced coefficient of modulus without padding beyond what the
+// value requires.
+func coeffWidth(modulus uint64) int {
+	bits := new(big.Int).SetUint64(modulus).BitLen()
+	return (bits + 7) / 8
+}
+
+// marshalPoly encodes poly's coefficients fixed-width little-endian, each
+// coeffWidth(modulus) bytes wide, failing if any coefficient is out of
+// range or negative.
+func marshalPoly(poly []*big.Int, modulus uint64) ([]byte, error) {
+	width := coeffWidth(modulus)
+	mod := new(big.Int).SetUint64(modulus)
+	buf := make([]byte, 0, len(poly)*width)
+	for i, coeff := range poly {
+		if coeff == nil || coeff.Sign() < 0 || coeff.Cmp(mod) >= 0 {
+			return nil, fmt.Errorf("%w: coefficient %d", ErrCoefficientRange, i)
+		}
+		coeffBytes := make([]byte, width)
+		le := coeff.Bytes()
+		for j, b := range le {
+			coeffBytes[j] = b
+		}
+		// coeff.Bytes() is big-endian; reverse into little-endian place.
+		reverse(coeffBytes[:len(le)])
+		buf = append(buf, coeffBytes...)
+	}
+	return buf, nil
+}
+
+// readPoly reads a length-prefixed polynomial starting at offset off in
+// data, returning the decoded coefficients and the number of bytes consumed
+// (including the 4-byte length prefix).
+func readPoly(data []byte, off int, modulus uint64) ([]*big.Int, int, error) {
+	polyLen, n, err := readUint32(data, off)
+	if err != nil {
+		return nil, 0, err
+	}
+	off += n
+	if polyLen > maxPolyLen {
+		return nil, 0, ErrPolyTooLarge
+	}
+	if len(data) < off+int(polyLen) {
+		return nil, 0, ErrTruncated
+	}
+	body := data[off : off+int(polyLen)]
+
+	width := coeffWidth(modulus)
+	if width == 0 || int(polyLen)%width != 0 {
+		return nil, 0, fmt.Errorf("%w: polynomial length %d not a multiple of coefficient width %d", ErrTruncated, polyLen, width)
+	}
+	mod := new(big.Int).SetUint64(modulus)
+	count := int(polyLen) / width
+	coeffs := make([]*big.Int, count)
+	for i := 0; i < count; i++ {
+		coeffBytes := append([]byte(nil), body[i*width:(i+1)*width]...)
+		reverse(coeffBytes)
+		coeff := new(big.Int).SetBytes(coeffBytes)
+		if coeff.Cmp(mod) >= 0 {
+			return nil, 0, fmt.Errorf("%w: coefficient %d", ErrCoefficientRange, i)
+		}
+		coeffs[i] = coeff
+	}
+
+	return coeffs, n + int(polyLen), nil
+}
+
+func readUint16(data []byte, off int) (uint16, int, error) {
+	if len(data) < off+2 {
+		return 0, 0, ErrTruncated
+	}
+	return binary.LittleEndian.Uint16(data[off : off+2]), 2, nil
+}
+
+func readUint32(data []byte, off int) (uint32, int, error) {
+	if len(data) < off+4 {
+		return 0, 0, ErrTruncated
+	}
+	return binary.LittleEndian.Uint32(data[off : off+4]), 4, nil
+}
+
+func appendUint16(buf []byte, v uint16) []byte {
+	var b [2]byte
+	binary.LittleEndian.PutUint16(b[:], v)
+	return append(buf, b[:]...)
+}
+
+func appendUint32(buf []byte, v uint32) []byte {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], v)
+	return append(buf, b[:]...)
+}
+
+func reverse(b []byte) {
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package format
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"ringtail/sign"
+)
+
+func TestMarshalUnmarshalRoundTrip(t *testing.T) {
+	sig := &Signature{
+		C:     randPoly(t, sign.Q, 4),
+		Z:     [][]*big.Int{randPoly(t, sign.Q, 4), randPoly(t, sign.Q, 4)},
+		Delta: [][]*big.Int{randPoly(t, sign.QNu, 4)},
+		A: [][][]*big.Int{
+			{randPoly(t, sign.Q, 4), randPoly(t, sign.Q, 4)},
+		},
+		BTilde: [][]*big.Int{randPoly(t, sign.QXi, 4)},
+		Ext:    []byte{0xAA, 0xBB},
+	}
+
+	encoded, err := Marshal(sig)
+	require.NoError(t, err)
+
+	decoded, err := Unmarshal(encoded)
+	require.NoError(t, err)
+	require.Equal(t, sig.C, decoded.C)
+	require.Equal(t, sig.Z, decoded.Z)
+	require.Equal(t, sig.Delta, decoded.Delta)
+	require.Equal(t, sig.A, decoded.A)
+	require.Equal(t, sig.BTilde, decoded.BTilde)
+	require.Equal(t, sig.Ext, decoded.Ext)
+}
+
+func TestMarshalUnmarshalPrefixConsumesExactLength(t *testing.T) {
+	sig := &Signature{C: randPoly(t, sign.Q, 2)}
+	encoded, err := Marshal(sig)
+	require.NoError(t, err)
+
+	trailer := []byte{0x01, 0x02, 0x03}
+	decoded, n, err := UnmarshalPrefix(append(encoded, trailer...))
+	require.NoError(t, err)
+	require.Equal(t, len(encoded), n)
+	require.Equal(t, sig.C, decoded.C)
+}
+
+func TestMarshalRejectsUnreducedCoefficient(t *testing.T) {
+	sig := &Signature{
+		C:     []*big.Int{new(big.Int).SetUint64(sign.Q)}, // == modulus, not reduced
+		Z:     nil,
+		Delta: nil,
+	}
+	_, err := Marshal(sig)
+	require.ErrorIs(t, err, ErrCoefficientRange)
+}
+
+func TestUnmarshalRejectsUnreducedCoefficient(t *testing.T) {
+	sig := &Signature{C: randPoly(t, sign.Q, 1)}
+	encoded, err := Marshal(sig)
+	require.NoError(t, err)
+
+	// Corrupt the single c coefficient to equal the modulus exactly.
+	width := coeffWidth(sign.Q)
+	cOffset := 3 + 4 // version(1) + paramsID(2) + cLen(4)
+	mod := new(big.Int).SetUint64(sign.Q)
+	modBytes := mod.Bytes()
+	reverse(modBytes)
+	for i := 0; i < width; i++ {
+		if i < len(modBytes) {
+			encoded[cOffset+i] = modBytes[i]
+		} else {
+			encoded[cOffset+i] = 0
+		}
+	}
+
+	_, err = Unmarshal(encoded)
+	require.ErrorIs(t, err, ErrCoefficientRange)
+}
+
+func TestUnmarshalRejectsUnsupportedVersion(t *testing.T) {
+	_, err := Unmarshal([]byte{0xFF, 0x01, 0x00})
+	require.ErrorIs(t, err, ErrUnsupportedVersion)
+}
+
+func TestUnmarshalRejectsUnsupportedParams(t *testing.T) {
+	_, err := Unmarshal([]byte{FormatVersion1, 0xFF, 0xFF})
+	require.ErrorIs(t, err, ErrUnsupportedParams)
+}
+
+// FuzzUnmarshal random-walks over malformed inputs to confirm Unmarshal
+// never panics, regardless of how length fields and coefficient bytes are
+// corrupted.
+func FuzzUnmarshal(f *testing.F) {
+	sig := &Signature{
+		C:      randPoly(f, sign.Q, 4),
+		Z:      [][]*big.Int{randPoly(f, sign.Q, 4)},
+		Delta:  [][]*big.Int{randPoly(f, sign.QNu, 4)},
+		A:      [][][]*big.Int{{randPoly(f, sign.Q, 4)}},
+		BTilde: [][]*big.Int{randPoly(f, sign.QXi, 4)},
+		Ext:    []byte{0x01},
+	}
+	valid, err := Marshal(sig)
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(valid)
+	f.Add([]byte{})
+	f.Add([]byte{FormatVersion1})
+	f.Add([]byte{FormatVersion1, 0x01, 0x00})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		_, _ = Unmarshal(data)
+	})
+}
+
+func randPoly(tb testing.TB, modulus uint64, n int) []*big.Int {
+	tb.Helper()
+	poly := make([]*big.Int, n)
+	for i := range poly {
+		poly[i] = new(big.Int).SetUint64(uint64(i+1) % modulus)
+	}
+	return poly
+}
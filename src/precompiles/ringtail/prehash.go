@@ -0,0 +1,119 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ringtailthreshold
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/luxfi/geth/crypto"
+	"golang.org/x/crypto/sha3"
+)
+
+// SigMode selects how the 32-byte digest a Ringtail signature covers was
+// derived from the caller's message, mirroring FIPS 205 SLH-DSA's
+// pure/pre-hash split and Cosmos SDK's SIGN_MODE_DIRECT: callers that
+// already hold a 32-byte digest use ModeRawDigest exactly as before this
+// field existed, while callers with an arbitrary-length message and an
+// optional domain/context string use one of the pre-hash modes so signer
+// and verifier agree on the digest without each client hand-rolling its
+// own domain separation.
+type SigMode uint8
+
+const (
+	ModeRawDigest        SigMode = 0
+	ModePreHashSHAKE256  SigMode = 1
+	ModePreHashKeccak256 SigMode = 2
+)
+
+// ErrUnknownSigMode is returned for any sigMode byte other than the three
+// defined above.
+var ErrUnknownSigMode = errors.New("unknown signature mode")
+
+// preHashDomainSep is prefixed to every pre-hash digest, together with the
+// mode byte, so a digest computed under one mode can never collide with a
+// digest computed under a different mode or with a raw caller-supplied
+// digest.
+const preHashDomainSep = "RINGTAIL-PREHASH-V1"
+
+// PreHash computes H(domainSep || mode || ctxLen || ctx || msg), where H is
+// SHAKE256 for ModePreHashSHAKE256 and Keccak256 for ModePreHashKeccak256.
+// Signers and verifiers both call PreHash so they agree on the digest a
+// signature covers without needing to exchange anything beyond mode, ctx,
+// and msg.
+func PreHash(mode SigMode, ctx, msg []byte) ([]byte, error) {
+	if len(ctx) > 0xFFFF {
+		return nil, fmt.Errorf("context string of %d bytes exceeds the 65535-byte limit", len(ctx))
+	}
+
+	buf := make([]byte, 0, len(preHashDomainSep)+1+2+len(ctx)+len(msg))
+	buf = append(buf, preHashDomainSep...)
+	buf = append(buf, byte(mode))
+	var ctxLen [2]byte
+	binary.BigEndian.PutUint16(ctxLen[:], uint16(len(ctx)))
+	buf = append(buf, ctxLen[:]...)
+	buf = append(buf, ctx...)
+	buf = append(buf, msg...)
+
+	switch mode {
+	case ModePreHashSHAKE256:
+		digest := make([]byte, 32)
+		sha3.ShakeSum256(digest, buf)
+		return digest, nil
+	case ModePreHashKeccak256:
+		return crypto.Keccak256(buf), nil
+	default:
+		return nil, fmt.Errorf("%w: 0x%x", ErrUnknownSigMode, byte(mode))
+	}
+}
+
+// resolveMessageDigest returns the 32-byte digest a Ringtail signature
+// should cover, given sigMode and the entry point's input immediately
+// following the threshold/totalParties fields. It also returns the number
+// of input bytes it consumed, so the caller knows where the signature
+// bytes begin.
+//
+// For ModeRawDigest, the digest is the next MessageHashSize bytes verbatim
+// (the pre-sigMode behavior). For a pre-hash mode, the input instead holds
+// a length-prefixed context string followed by a length-prefixed message,
+// and the digest is PreHash'd from them.
+func resolveMessageDigest(sigMode SigMode, input []byte) (digest []byte, consumed int, err error) {
+	switch sigMode {
+	case ModeRawDigest:
+		if len(input) < MessageHashSize {
+			return nil, 0, fmt.Errorf("%w: truncated message hash", ErrInvalidInputLength)
+		}
+		return input[:MessageHashSize], MessageHashSize, nil
+
+	case ModePreHashSHAKE256, ModePreHashKeccak256:
+		if len(input) < 2 {
+			return nil, 0, fmt.Errorf("%w: truncated context length", ErrInvalidInputLength)
+		}
+		ctxLen := binary.BigEndian.Uint16(input[0:2])
+		off := 2
+		if len(input) < off+int(ctxLen)+4 {
+			return nil, 0, fmt.Errorf("%w: truncated context", ErrInvalidInputLength)
+		}
+		ctx := input[off : off+int(ctxLen)]
+		off += int(ctxLen)
+
+		msgLen := binary.BigEndian.Uint32(input[off : off+4])
+		off += 4
+		if len(input) < off+int(msgLen) {
+			return nil, 0, fmt.Errorf("%w: truncated message", ErrInvalidInputLength)
+		}
+		msg := input[off : off+int(msgLen)]
+		off += int(msgLen)
+
+		digest, err = PreHash(sigMode, ctx, msg)
+		if err != nil {
+			return nil, 0, err
+		}
+		return digest, off, nil
+
+	default:
+		return nil, 0, fmt.Errorf("%w: 0x%x", ErrUnknownSigMode, byte(sigMode))
+	}
+}
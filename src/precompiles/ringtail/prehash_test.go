@@ -0,0 +1,99 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ringtailthreshold
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/luxfi/geth/common"
+	"github.com/stretchr/testify/require"
+)
+
+// buildPreHashInput assembles a solo-precompile call in one of the pre-hash
+// modes: [sigMode][threshold][totalParties][ctxLen(2)][ctx][msgLen(4)][msg][signature].
+func buildPreHashInput(mode SigMode, threshold, totalParties uint32, ctx, msg, signature []byte) []byte {
+	input := []byte{byte(mode)}
+
+	var tn [8]byte
+	binary.BigEndian.PutUint32(tn[0:4], threshold)
+	binary.BigEndian.PutUint32(tn[4:8], totalParties)
+	input = append(input, tn[:]...)
+
+	var ctxLen [2]byte
+	binary.BigEndian.PutUint16(ctxLen[:], uint16(len(ctx)))
+	input = append(input, ctxLen[:]...)
+	input = append(input, ctx...)
+
+	var msgLen [4]byte
+	binary.BigEndian.PutUint32(msgLen[:], uint32(len(msg)))
+	input = append(input, msgLen[:]...)
+	input = append(input, msg...)
+
+	input = append(input, signature...)
+	return input
+}
+
+// TestRingtailThresholdVerify_PreHashSHAKE256 exercises ModePreHashSHAKE256
+// end to end: the signers sign PreHash's SHAKE256 digest, and the precompile
+// is asked to re-derive that same digest from ctx+msg before verifying.
+func TestRingtailThresholdVerify_PreHashSHAKE256(t *testing.T) {
+	threshold, totalParties := uint32(2), uint32(3)
+	ctx, msg := []byte("lux-test-ctx"), []byte("pre-hashed message body")
+
+	digest, err := PreHash(ModePreHashSHAKE256, ctx, msg)
+	require.NoError(t, err)
+
+	signature, signedDigest, err := generateThresholdSignature(threshold, totalParties, string(digest))
+	require.NoError(t, err)
+	require.Equal(t, digest, signedDigest)
+
+	input := buildPreHashInput(ModePreHashSHAKE256, threshold, totalParties, ctx, msg, signature)
+
+	precompile := &ringtailThresholdPrecompile{}
+	result, _, err := precompile.Run(nil, common.Address{}, precompile.Address(), input, 1_000_000, true)
+	require.NoError(t, err)
+	require.Equal(t, byte(1), result[31], "signature over the SHAKE256 pre-hash digest should verify")
+}
+
+// TestRingtailThresholdVerify_PreHashKeccak256 mirrors the SHAKE256 case for
+// ModePreHashKeccak256.
+func TestRingtailThresholdVerify_PreHashKeccak256(t *testing.T) {
+	threshold, totalParties := uint32(2), uint32(3)
+	ctx, msg := []byte("lux-test-ctx"), []byte("another pre-hashed message")
+
+	digest, err := PreHash(ModePreHashKeccak256, ctx, msg)
+	require.NoError(t, err)
+
+	signature, signedDigest, err := generateThresholdSignature(threshold, totalParties, string(digest))
+	require.NoError(t, err)
+	require.Equal(t, digest, signedDigest)
+
+	input := buildPreHashInput(ModePreHashKeccak256, threshold, totalParties, ctx, msg, signature)
+
+	precompile := &ringtailThresholdPrecompile{}
+	result, _, err := precompile.Run(nil, common.Address{}, precompile.Address(), input, 1_000_000, true)
+	require.NoError(t, err)
+	require.Equal(t, byte(1), result[31], "signature over the Keccak256 pre-hash digest should verify")
+}
+
+// TestRingtailThresholdVerify_UnknownSigMode tests rejection of a sigMode
+// byte other than the three defined modes.
+func TestRingtailThresholdVerify_UnknownSigMode(t *testing.T) {
+	threshold, totalParties := uint32(2), uint32(3)
+
+	input := buildPreHashInput(SigMode(0x03), threshold, totalParties, nil, []byte("irrelevant"), []byte{0x00})
+
+	precompile := &ringtailThresholdPrecompile{}
+	_, _, err := precompile.Run(nil, common.Address{}, precompile.Address(), input, 1_000_000, true)
+	require.ErrorIs(t, err, ErrUnknownSigMode)
+}
+
+// TestPreHash_RejectsOversizedContext tests that PreHash rejects a context
+// string longer than its 16-bit length prefix can encode.
+func TestPreHash_RejectsOversizedContext(t *testing.T) {
+	oversized := make([]byte, 0x10000)
+	_, err := PreHash(ModePreHashSHAKE256, oversized, []byte("msg"))
+	require.Error(t, err)
+}
@@ -0,0 +1,116 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ringtailthreshold
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/luxfi/evm/precompile/contract"
+	"github.com/luxfi/geth/common"
+	"github.com/luxfi/standard/src/precompiles/popregistry"
+)
+
+// ContractRingtailThresholdKeyHashAddress is a second entry point for
+// Ringtail threshold verification that carries participant keyHashes
+// instead of raw public keys. Every keyHash must already be registered
+// (and PoP-verified) in popregistry; this both shrinks calldata for large
+// signer sets and prevents rogue-key attacks, since an attacker cannot
+// register a key they don't hold the private component for.
+var (
+	ContractRingtailThresholdKeyHashAddress = common.HexToAddress("0x0200000000000000000000000000000000000010")
+
+	RingtailThresholdKeyHashPrecompile = &ringtailThresholdKeyHashPrecompile{}
+
+	_ contract.StatefulPrecompiledContract = &ringtailThresholdKeyHashPrecompile{}
+
+	ErrUnregisteredSigner = errors.New("signer keyHash is not registered")
+)
+
+const keyHashSize = 32
+
+type ringtailThresholdKeyHashPrecompile struct{}
+
+func (p *ringtailThresholdKeyHashPrecompile) Address() common.Address {
+	return ContractRingtailThresholdKeyHashAddress
+}
+
+func (p *ringtailThresholdKeyHashPrecompile) RequiredGas(input []byte) uint64 {
+	if len(input) < MinInputSize {
+		return RingtailThresholdBaseGas
+	}
+	totalParties := binary.BigEndian.Uint32(input[ThresholdSize : ThresholdSize+TotalPartiesSize])
+	return RingtailThresholdBaseGas + (uint64(totalParties) * RingtailThresholdPerPartyGas)
+}
+
+// Run verifies a threshold signature the same way RingtailThresholdPrecompile
+// does, except the signer set is identified by registry keyHashes rather
+// than raw public keys; it refuses to verify if any signer is unregistered.
+//
+// Input format:
+// [0:4]        = threshold t (uint32)
+// [4:8]        = total parties n (uint32)
+// [8:40]       = message hash (32 bytes)
+// [40:40+32n]  = n participant keyHashes (32 bytes each)
+// [40+32n:...] = threshold signature
+func (p *ringtailThresholdKeyHashPrecompile) Run(
+	accessibleState contract.AccessibleState,
+	caller common.Address,
+	addr common.Address,
+	input []byte,
+	suppliedGas uint64,
+	readOnly bool,
+) ([]byte, uint64, error) {
+	gasCost := p.RequiredGas(input)
+	if suppliedGas < gasCost {
+		return nil, 0, errors.New("out of gas")
+	}
+
+	if len(input) < MinInputSize {
+		return nil, suppliedGas - gasCost, fmt.Errorf("%w: expected at least %d bytes, got %d",
+			ErrInvalidInputLength, MinInputSize, len(input))
+	}
+
+	threshold := binary.BigEndian.Uint32(input[0:ThresholdSize])
+	totalParties := binary.BigEndian.Uint32(input[ThresholdSize : ThresholdSize+TotalPartiesSize])
+	messageHash := input[ThresholdSize+TotalPartiesSize : MinInputSize]
+
+	if threshold == 0 || threshold > totalParties {
+		return nil, suppliedGas - gasCost, fmt.Errorf("%w: t=%d, n=%d", ErrInvalidThreshold, threshold, totalParties)
+	}
+
+	keyHashesEnd := MinInputSize + int(totalParties)*keyHashSize
+	if len(input) < keyHashesEnd {
+		return nil, suppliedGas - gasCost, fmt.Errorf("%w: truncated keyHash list", ErrInvalidInputLength)
+	}
+
+	state := accessibleState.GetStateDB()
+	for i := 0; i < int(totalParties); i++ {
+		start := MinInputSize + i*keyHashSize
+		keyHash := common.BytesToHash(input[start : start+keyHashSize])
+		if !popregistry.IsRegistered(state, popregistry.SchemeRingtail, keyHash) {
+			return nil, suppliedGas - gasCost, fmt.Errorf("%w: %s", ErrUnregisteredSigner, keyHash)
+		}
+	}
+
+	// Its exact length depends on the canonical wire format's header and
+	// field counts, so truncation is detected by format.Unmarshal inside
+	// verifyThresholdSignature rather than by a fixed minimum here.
+	signatureBytes := input[keyHashesEnd:]
+	if len(signatureBytes) == 0 {
+		return nil, suppliedGas - gasCost, fmt.Errorf("%w: missing signature", ErrInvalidInputLength)
+	}
+
+	valid, err := verifyThresholdSignature(threshold, totalParties, messageHash, signatureBytes)
+	if err != nil {
+		return nil, suppliedGas - gasCost, fmt.Errorf("verification error: %w", err)
+	}
+
+	result := make([]byte, 32)
+	if valid {
+		result[31] = 1
+	}
+	return result, suppliedGas - gasCost, nil
+}
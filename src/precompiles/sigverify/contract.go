@@ -0,0 +1,273 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package sigverify implements a single, self-describing signature
+// verification precompile modeled on Cosmos SDK's SignatureV2 /
+// SIGN_MODE_DIRECT envelope: one Any-typed signature carrying a scheme
+// discriminator plus canonical sign-bytes. Callers (wallets, session-key
+// modules, ERC-4337 validators) can verify any supported signature scheme
+// through one address and one calldata shape instead of knowing every
+// scheme's dedicated precompile address and packed layout.
+package sigverify
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/luxfi/crypto/mldsa"
+	"github.com/luxfi/crypto/slhdsa"
+	"github.com/luxfi/evm/precompile/contract"
+	"github.com/luxfi/geth/common"
+	"github.com/luxfi/geth/crypto"
+	"github.com/luxfi/standard/src/precompiles/frost"
+)
+
+// Scheme is the registered enum of signature schemes SigVerifyV2 can
+// dispatch to. New PQ schemes are added here and in dispatch() only; the
+// calldata envelope itself never changes shape.
+type Scheme uint16
+
+const (
+	SchemeSecp256k1    Scheme = 1
+	SchemeEd25519      Scheme = 2
+	SchemeBLS12_381    Scheme = 3
+	SchemeMLDSA44      Scheme = 10
+	SchemeMLDSA65      Scheme = 11
+	SchemeMLDSA87      Scheme = 12
+	SchemeSLHDSA       Scheme = 20
+	SchemeFROSTEd25519 Scheme = 30
+	SchemeRingtail     Scheme = 31
+)
+
+var (
+	// ContractAddress is the address of the unified SigVerifyV2 precompile.
+	ContractAddress = common.HexToAddress("0x0200000000000000000000000000000000000015")
+
+	// Singleton instance
+	SigVerifyV2Precompile = &sigVerifyV2Precompile{}
+
+	_ contract.StatefulPrecompiledContract = &sigVerifyV2Precompile{}
+
+	ErrInvalidInputLength = errors.New("invalid input length")
+	ErrUnsupportedVersion = errors.New("unsupported envelope version")
+	ErrUnsupportedScheme  = errors.New("unsupported signature scheme")
+	ErrContextTooLong     = errors.New("context exceeds 255 bytes")
+)
+
+const (
+	// EnvelopeVersion1 is the only envelope version defined so far.
+	EnvelopeVersion1 = 1
+
+	// Per-scheme base gas cost, mirroring each scheme's own precompile base
+	// cost. Classical schemes additionally pay SigVerifyBasePerByteGas per
+	// message byte; PQ schemes that already include their own per-byte
+	// charge in their library-level base do not.
+	BaseGasSecp256k1 uint64 = 3_000
+	BaseGasEd25519   uint64 = 3_000
+	BaseGasBLS12_381 uint64 = 5_000
+	BaseGasMLDSA     uint64 = 100_000
+	BaseGasSLHDSA    uint64 = 15_000
+	BaseGasFROST     uint64 = 50_000
+	BaseGasRingtail  uint64 = 150_000
+
+	// SigVerifyPerByteGas is charged per message byte for every scheme, on
+	// top of the scheme's base cost.
+	SigVerifyPerByteGas uint64 = 10
+	// SigVerifyPerContextByteGas is charged per context byte.
+	SigVerifyPerContextByteGas uint64 = 10
+)
+
+type sigVerifyV2Precompile struct{}
+
+// Address returns the precompile address.
+func (p *sigVerifyV2Precompile) Address() common.Address {
+	return ContractAddress
+}
+
+// RequiredGas calculates the gas required for the given input.
+func (p *sigVerifyV2Precompile) RequiredGas(input []byte) uint64 {
+	env, err := parseEnvelope(input)
+	if err != nil {
+		return baseGasForScheme(0)
+	}
+	return baseGasForScheme(env.scheme) +
+		uint64(len(env.message))*SigVerifyPerByteGas +
+		uint64(len(env.ctx))*SigVerifyPerContextByteGas
+}
+
+// Run parses the versioned envelope and dispatches to the scheme-specific
+// verifier.
+//
+// Input format:
+// [version(1)] [scheme(2)] [pubkeyLen(2)] [pubkey] [sigLen(3)] [sig]
+// [msgLen(3)] [msg] [ctxLen(1)] [ctx]
+func (p *sigVerifyV2Precompile) Run(
+	accessibleState contract.AccessibleState,
+	caller common.Address,
+	addr common.Address,
+	input []byte,
+	suppliedGas uint64,
+	readOnly bool,
+) ([]byte, uint64, error) {
+	env, err := parseEnvelope(input)
+	if err != nil {
+		return nil, suppliedGas, err
+	}
+
+	gasCost := baseGasForScheme(env.scheme) +
+		uint64(len(env.message))*SigVerifyPerByteGas +
+		uint64(len(env.ctx))*SigVerifyPerContextByteGas
+	if suppliedGas < gasCost {
+		return nil, 0, errors.New("out of gas")
+	}
+	remainingGas := suppliedGas - gasCost
+
+	valid, err := dispatch(env)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+
+	result := make([]byte, 32)
+	if valid {
+		result[31] = 1
+	}
+	return result, remainingGas, nil
+}
+
+// envelope is the parsed form of a SigVerifyV2 input.
+type envelope struct {
+	scheme    Scheme
+	pubKey    []byte
+	signature []byte
+	message   []byte
+	ctx       []byte
+}
+
+func parseEnvelope(input []byte) (*envelope, error) {
+	if len(input) < 1 || input[0] != EnvelopeVersion1 {
+		return nil, fmt.Errorf("%w: got %d", ErrUnsupportedVersion, firstByteOrZero(input))
+	}
+	if len(input) < 1+2+2 {
+		return nil, fmt.Errorf("%w: missing scheme/pubkey-length header", ErrInvalidInputLength)
+	}
+	scheme := Scheme(binary.BigEndian.Uint16(input[1:3]))
+
+	pubKeyLen := binary.BigEndian.Uint16(input[3:5])
+	pubKeyOffset := 5
+	if len(input) < pubKeyOffset+int(pubKeyLen)+3 {
+		return nil, fmt.Errorf("%w: pubkey truncated", ErrInvalidInputLength)
+	}
+	pubKey := input[pubKeyOffset : pubKeyOffset+int(pubKeyLen)]
+
+	sigLenOffset := pubKeyOffset + int(pubKeyLen)
+	sigLen := uint32(input[sigLenOffset])<<16 | uint32(input[sigLenOffset+1])<<8 | uint32(input[sigLenOffset+2])
+	sigOffset := sigLenOffset + 3
+	if len(input) < sigOffset+int(sigLen)+3 {
+		return nil, fmt.Errorf("%w: signature truncated", ErrInvalidInputLength)
+	}
+	signature := input[sigOffset : sigOffset+int(sigLen)]
+
+	msgLenOffset := sigOffset + int(sigLen)
+	msgLen := uint32(input[msgLenOffset])<<16 | uint32(input[msgLenOffset+1])<<8 | uint32(input[msgLenOffset+2])
+	msgOffset := msgLenOffset + 3
+	if len(input) < msgOffset+int(msgLen)+1 {
+		return nil, fmt.Errorf("%w: message truncated", ErrInvalidInputLength)
+	}
+	message := input[msgOffset : msgOffset+int(msgLen)]
+
+	ctxLenOffset := msgOffset + int(msgLen)
+	ctxLen := int(input[ctxLenOffset])
+	if ctxLen > 255 {
+		return nil, ErrContextTooLong
+	}
+	ctxOffset := ctxLenOffset + 1
+	if len(input) < ctxOffset+ctxLen {
+		return nil, fmt.Errorf("%w: context truncated", ErrInvalidInputLength)
+	}
+	ctx := input[ctxOffset : ctxOffset+ctxLen]
+
+	return &envelope{
+		scheme:    scheme,
+		pubKey:    pubKey,
+		signature: signature,
+		message:   message,
+		ctx:       ctx,
+	}, nil
+}
+
+// dispatch verifies env.signature against env.message under env.scheme,
+// delegating to the same underlying verification code the scheme's own
+// dedicated precompile uses.
+func dispatch(env *envelope) (bool, error) {
+	switch env.scheme {
+	case SchemeSecp256k1:
+		return verifySecp256k1(env.pubKey, env.message, env.signature)
+	case SchemeEd25519, SchemeFROSTEd25519:
+		return frost.VerifySchnorrSignature(frost.CurveEd25519, env.pubKey, env.message, env.signature), nil
+	case SchemeMLDSA44, SchemeMLDSA65, SchemeMLDSA87:
+		pub, err := mldsa.PublicKeyFromBytes(env.pubKey, mldsaModeFor(env.scheme))
+		if err != nil {
+			return false, fmt.Errorf("invalid ML-DSA public key: %w", err)
+		}
+		return pub.Verify(env.message, env.signature, env.ctx), nil
+	case SchemeSLHDSA:
+		pub, err := slhdsa.PublicKeyFromBytes(env.pubKey, slhdsa.SHAKE_128s)
+		if err != nil {
+			return false, fmt.Errorf("invalid SLH-DSA public key: %w", err)
+		}
+		return pub.Verify(env.message, env.signature, env.ctx), nil
+	case SchemeRingtail:
+		// Ringtail's threshold parameters (t, n) are not representable in
+		// the single-signature envelope; callers needing threshold
+		// verification should use the dedicated ringtailthreshold
+		// precompile instead.
+		return false, ErrUnsupportedScheme
+	default:
+		return false, fmt.Errorf("%w: %d", ErrUnsupportedScheme, env.scheme)
+	}
+}
+
+func mldsaModeFor(scheme Scheme) mldsa.Mode {
+	switch scheme {
+	case SchemeMLDSA44:
+		return mldsa.ModeML_DSA_44
+	case SchemeMLDSA87:
+		return mldsa.ModeML_DSA_87
+	default:
+		return mldsa.ModeML_DSA_65
+	}
+}
+
+func verifySecp256k1(pubKey, message, signature []byte) (bool, error) {
+	if len(signature) != 64 && len(signature) != 65 {
+		return false, fmt.Errorf("%w: secp256k1 signature must be 64 or 65 bytes", ErrInvalidInputLength)
+	}
+	return crypto.VerifySignature(pubKey, message, signature[:64]), nil
+}
+
+func baseGasForScheme(scheme Scheme) uint64 {
+	switch scheme {
+	case SchemeSecp256k1:
+		return BaseGasSecp256k1
+	case SchemeEd25519, SchemeFROSTEd25519:
+		return BaseGasFROST
+	case SchemeBLS12_381:
+		return BaseGasBLS12_381
+	case SchemeMLDSA44, SchemeMLDSA65, SchemeMLDSA87:
+		return BaseGasMLDSA
+	case SchemeSLHDSA:
+		return BaseGasSLHDSA
+	case SchemeRingtail:
+		return BaseGasRingtail
+	default:
+		return BaseGasSecp256k1
+	}
+}
+
+func firstByteOrZero(b []byte) byte {
+	if len(b) == 0 {
+		return 0
+	}
+	return b[0]
+}
@@ -0,0 +1,279 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sigverify
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/luxfi/crypto/mldsa"
+	"github.com/luxfi/crypto/slhdsa"
+	"github.com/luxfi/evm/precompile/contract"
+	"github.com/luxfi/geth/common"
+	"github.com/luxfi/standard/src/precompiles/frost"
+)
+
+// ClassicalAlg is the classical half of a hybrid classical+PQ signature.
+type ClassicalAlg byte
+
+const (
+	ClassicalSecp256k1 ClassicalAlg = 1
+	ClassicalSecp256r1 ClassicalAlg = 2
+	ClassicalEd25519   ClassicalAlg = 3
+)
+
+// PQAlg is the post-quantum half of a hybrid classical+PQ signature.
+type PQAlg byte
+
+const (
+	PQAlgMLDSA44 PQAlg = 10
+	PQAlgMLDSA65 PQAlg = 11
+	PQAlgMLDSA87 PQAlg = 12
+	PQAlgSLHDSA  PQAlg = 20
+)
+
+var (
+	// ContractHybridVerifyAddress is the address of the hybrid
+	// classical+PQ signature verification precompile.
+	ContractHybridVerifyAddress = common.HexToAddress("0x020000000000000000000000000000000000001C")
+
+	// HybridVerifyPrecompile is the singleton instance.
+	HybridVerifyPrecompile = &hybridVerifyPrecompile{}
+
+	_ contract.StatefulPrecompiledContract = &hybridVerifyPrecompile{}
+)
+
+const (
+	// HybridCombinerGas is charged on top of the two underlying schemes'
+	// own costs, covering the extra parsing and the requirement that both
+	// signatures always be checked (see Run).
+	HybridCombinerGas uint64 = 2_000
+)
+
+type hybridVerifyPrecompile struct{}
+
+// Address returns the precompile address.
+func (p *hybridVerifyPrecompile) Address() common.Address {
+	return ContractHybridVerifyAddress
+}
+
+// hybridInput is the parsed form of a hybrid verify call.
+type hybridInput struct {
+	classicalAlg ClassicalAlg
+	pqAlg        PQAlg
+	classicalPub []byte
+	pqPub        []byte
+	message      []byte
+	classicalSig []byte
+	pqSig        []byte
+}
+
+// parseHybridInput parses a hybrid verify call.
+//
+// Input format:
+// [classicalAlg(1)] [pqAlg(1)]
+// [classicalPubLen(2)] [classicalPub] [pqPubLen(2)] [pqPub]
+// [msgLen(4)] [msg]
+// [classicalSigLen(2)] [classicalSig] [pqSig]
+func parseHybridInput(input []byte) (*hybridInput, error) {
+	if len(input) < 2+2+2 {
+		return nil, fmt.Errorf("%w: missing alg/pubkey-length header", ErrInvalidInputLength)
+	}
+	classicalAlg := ClassicalAlg(input[0])
+	pqAlg := PQAlg(input[1])
+
+	classicalPubLen := binary.BigEndian.Uint16(input[2:4])
+	off := 4
+	if len(input) < off+int(classicalPubLen)+2 {
+		return nil, fmt.Errorf("%w: classical pubkey truncated", ErrInvalidInputLength)
+	}
+	classicalPub := input[off : off+int(classicalPubLen)]
+	off += int(classicalPubLen)
+
+	pqPubLen := binary.BigEndian.Uint16(input[off : off+2])
+	off += 2
+	if len(input) < off+int(pqPubLen)+4 {
+		return nil, fmt.Errorf("%w: PQ pubkey truncated", ErrInvalidInputLength)
+	}
+	pqPub := input[off : off+int(pqPubLen)]
+	off += int(pqPubLen)
+
+	msgLen := binary.BigEndian.Uint32(input[off : off+4])
+	off += 4
+	if uint64(len(input)-off) < uint64(msgLen) {
+		return nil, fmt.Errorf("%w: message truncated", ErrInvalidInputLength)
+	}
+	message := input[off : off+int(msgLen)]
+	off += int(msgLen)
+
+	if len(input) < off+2 {
+		return nil, fmt.Errorf("%w: missing classical signature length", ErrInvalidInputLength)
+	}
+	classicalSigLen := binary.BigEndian.Uint16(input[off : off+2])
+	off += 2
+	if len(input) < off+int(classicalSigLen) {
+		return nil, fmt.Errorf("%w: classical signature truncated", ErrInvalidInputLength)
+	}
+	classicalSig := input[off : off+int(classicalSigLen)]
+	off += int(classicalSigLen)
+
+	pqSig := input[off:]
+
+	return &hybridInput{
+		classicalAlg: classicalAlg,
+		pqAlg:        pqAlg,
+		classicalPub: classicalPub,
+		pqPub:        pqPub,
+		message:      message,
+		classicalSig: classicalSig,
+		pqSig:        pqSig,
+	}, nil
+}
+
+// RequiredGas calculates the gas required for a hybrid verify call: the sum
+// of what the classical and PQ schemes would cost standalone, plus
+// HybridCombinerGas.
+func (p *hybridVerifyPrecompile) RequiredGas(input []byte) uint64 {
+	hi, err := parseHybridInput(input)
+	if err != nil {
+		return HybridCombinerGas
+	}
+	return HybridCombinerGas +
+		classicalBaseGas(hi.classicalAlg) + uint64(len(hi.message))*SigVerifyPerByteGas +
+		pqBaseGas(hi.pqAlg) + uint64(len(hi.message))*SigVerifyPerByteGas
+}
+
+// Run verifies a composite (classicalSig, pqSig) signature pair over the
+// same message under two independent keys, succeeding only when both
+// verify. Both signatures are always checked, even once the classical one
+// has already failed, so gas metering never depends on which signature (if
+// any) was invalid -- the standard requirement for a hybrid classical->PQ
+// migration construction, where a contract must not be able to infer which
+// half of a rejected signature was at fault from gas usage alone.
+func (p *hybridVerifyPrecompile) Run(
+	accessibleState contract.AccessibleState,
+	caller common.Address,
+	addr common.Address,
+	input []byte,
+	suppliedGas uint64,
+	readOnly bool,
+) ([]byte, uint64, error) {
+	hi, err := parseHybridInput(input)
+	if err != nil {
+		return nil, suppliedGas, err
+	}
+
+	gasCost := HybridCombinerGas +
+		classicalBaseGas(hi.classicalAlg) + uint64(len(hi.message))*SigVerifyPerByteGas +
+		pqBaseGas(hi.pqAlg) + uint64(len(hi.message))*SigVerifyPerByteGas
+	if suppliedGas < gasCost {
+		return nil, 0, fmt.Errorf("out of gas")
+	}
+	remainingGas := suppliedGas - gasCost
+
+	classicalValid, classicalErr := verifyClassical(hi.classicalAlg, hi.classicalPub, hi.message, hi.classicalSig)
+	pqValid, pqErr := verifyPQ(hi.pqAlg, hi.pqPub, hi.message, hi.pqSig)
+
+	if classicalErr != nil {
+		return nil, remainingGas, classicalErr
+	}
+	if pqErr != nil {
+		return nil, remainingGas, pqErr
+	}
+
+	result := make([]byte, 32)
+	if classicalValid && pqValid {
+		result[31] = 1
+	}
+	return result, remainingGas, nil
+}
+
+func classicalBaseGas(alg ClassicalAlg) uint64 {
+	switch alg {
+	case ClassicalSecp256k1:
+		return BaseGasSecp256k1
+	case ClassicalSecp256r1:
+		return BaseGasSecp256k1
+	case ClassicalEd25519:
+		return BaseGasFROST
+	default:
+		return BaseGasSecp256k1
+	}
+}
+
+func pqBaseGas(alg PQAlg) uint64 {
+	switch alg {
+	case PQAlgMLDSA44, PQAlgMLDSA65, PQAlgMLDSA87:
+		return BaseGasMLDSA
+	case PQAlgSLHDSA:
+		return BaseGasSLHDSA
+	default:
+		return BaseGasMLDSA
+	}
+}
+
+func verifyClassical(alg ClassicalAlg, pubKey, message, signature []byte) (bool, error) {
+	switch alg {
+	case ClassicalSecp256k1:
+		return verifySecp256k1(pubKey, message, signature)
+	case ClassicalSecp256r1:
+		return verifySecp256r1(pubKey, message, signature)
+	case ClassicalEd25519:
+		return frost.VerifySchnorrSignature(frost.CurveEd25519, pubKey, message, signature), nil
+	default:
+		return false, fmt.Errorf("%w: classical alg %d", ErrUnsupportedScheme, alg)
+	}
+}
+
+// verifySecp256r1 verifies an ASN.1 DER-less (r||s) ECDSA signature over
+// the P-256 curve, the classical half used by platforms such as WebAuthn /
+// Apple Secure Enclave that sign with secp256r1 rather than secp256k1.
+func verifySecp256r1(pubKeyBytes, message, signature []byte) (bool, error) {
+	if len(signature) != 64 {
+		return false, fmt.Errorf("%w: secp256r1 signature must be 64 bytes (r||s)", ErrInvalidInputLength)
+	}
+	curve := elliptic.P256()
+	x, y := elliptic.Unmarshal(curve, pubKeyBytes)
+	if x == nil {
+		return false, fmt.Errorf("%w: invalid secp256r1 public key", ErrInvalidInputLength)
+	}
+	pubKey := &ecdsa.PublicKey{Curve: curve, X: x, Y: y}
+
+	r := new(big.Int).SetBytes(signature[:32])
+	s := new(big.Int).SetBytes(signature[32:])
+	return ecdsa.Verify(pubKey, message, r, s), nil
+}
+
+func pqModeForMLDSA(alg PQAlg) mldsa.Mode {
+	switch alg {
+	case PQAlgMLDSA44:
+		return mldsa.ModeML_DSA_44
+	case PQAlgMLDSA87:
+		return mldsa.ModeML_DSA_87
+	default:
+		return mldsa.ModeML_DSA_65
+	}
+}
+
+func verifyPQ(alg PQAlg, pubKey, message, signature []byte) (bool, error) {
+	switch alg {
+	case PQAlgMLDSA44, PQAlgMLDSA65, PQAlgMLDSA87:
+		pub, err := mldsa.PublicKeyFromBytes(pubKey, pqModeForMLDSA(alg))
+		if err != nil {
+			return false, fmt.Errorf("invalid ML-DSA public key: %w", err)
+		}
+		return pub.Verify(message, signature, nil), nil
+	case PQAlgSLHDSA:
+		pub, err := slhdsa.PublicKeyFromBytes(pubKey, slhdsa.SHAKE_128s)
+		if err != nil {
+			return false, fmt.Errorf("invalid SLH-DSA public key: %w", err)
+		}
+		return pub.Verify(message, signature, nil), nil
+	default:
+		return false, fmt.Errorf("%w: PQ alg %d", ErrUnsupportedScheme, alg)
+	}
+}
@@ -1,11 +1,12 @@
 // Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
 // See the file LICENSE for licensing terms.
 
-package frost
+package sigverify
 
 import (
 	"github.com/luxfi/evm/precompile/contract"
 	"github.com/luxfi/evm/precompile/modules"
+	"github.com/luxfi/geth/common"
 )
 
 var _ contract.Configurator = &configurator{}
@@ -13,9 +14,8 @@ var _ contract.Configurator = &configurator{}
 type configurator struct{}
 
 func init() {
-	// Register FROST precompile module
 	if err := modules.RegisterModule(
-		ContractFROSTVerifyAddress.String(),
+		ContractAddress.String(),
 		&configurator{},
 	); err != nil {
 		panic(err)
@@ -24,11 +24,11 @@ func init() {
 
 func (*configurator) MakeConfig() contract.StatefulPrecompileConfig {
 	return &Config{
-		Address: ContractFROSTVerifyAddress,
+		Address: ContractAddress,
 	}
 }
 
-// Config implements the StatefulPrecompileConfig interface for FROST
+// Config implements the StatefulPrecompileConfig interface for SigVerifyV2.
 type Config struct {
 	Address common.Address `json:"address"`
 }
@@ -58,10 +58,9 @@ func (c *Config) Configure(
 	precompileConfig contract.PrecompileConfig,
 	state contract.StateDB,
 ) error {
-	// No state initialization required
 	return nil
 }
 
 func (c *Config) Contract() contract.StatefulPrecompiledContract {
-	return FROSTVerifyPrecompile
+	return SigVerifyV2Precompile
 }
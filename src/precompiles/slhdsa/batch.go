@@ -0,0 +1,184 @@
+// Copyright (C) 2019-2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package slhdsa
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/luxfi/crypto/slhdsa"
+	"github.com/luxfi/geth/common"
+)
+
+const (
+	// ContractBatchAddressHex is the precompile address for batch SLH-DSA
+	// verification. This is a distinct address from ContractAddress and
+	// ContractV2Address so existing single-signature callers are unaffected
+	// by the batch calldata shape.
+	ContractBatchAddressHex = "0x0200000000000000000000000000000000000017"
+
+	// SLHDSABatchVerifyBaseGas is the one-time cost of a batch call (hash
+	// context init, parameter table load), paid once regardless of n.
+	SLHDSABatchVerifyBaseGas = 3_000
+
+	// SLHDSABatchVerifyPerSigGas is charged per signature in the batch. It
+	// is lower than SLHDSAVerifyBaseGas (the single-call base cost) since
+	// SLHDSABatchVerifyBaseGas already covers the setup a solo call would
+	// otherwise pay for on every invocation.
+	SLHDSABatchVerifyPerSigGas = 12_000
+
+	// maxBatchVerboseBitmap is the number of signatures a verbose batch call
+	// can report individual pass/fail results for: one bit per signature in
+	// a single 32-byte output word.
+	maxBatchVerboseBitmap = 256
+)
+
+var (
+	ContractBatchAddress = common.HexToAddress(ContractBatchAddressHex)
+)
+
+// SLHDSABatchPrecompile verifies multiple independent SLH-DSA (pubkey,
+// message, signature) triples in a single call, amortizing the fixed setup
+// cost of SLH-DSA verification across every signature in the batch.
+type SLHDSABatchPrecompile struct{}
+
+// Address returns the precompile address
+func (p *SLHDSABatchPrecompile) Address() common.Address {
+	return ContractBatchAddress
+}
+
+// RequiredGas calculates the gas required for a batch verification call.
+// Gas = BaseGas + (n * PerSigGas) + (total message bytes * PerByteGas)
+func (p *SLHDSABatchPrecompile) RequiredGas(input []byte) uint64 {
+	n, totalMsgBytes, ok := parseBatchHeader(input)
+	if !ok {
+		return SLHDSABatchVerifyBaseGas
+	}
+	return SLHDSABatchVerifyBaseGas +
+		uint64(n)*SLHDSABatchVerifyPerSigGas +
+		totalMsgBytes*SLHDSAVerifyPerByteGas
+}
+
+// Run executes batch SLH-DSA signature verification.
+//
+// Input format:
+// [verbose(1)] [n(2)]
+// n times: [mode(1)] [pubKeyLen(2)] [pubKey] [msgLen(2)] [message] [sigLen(4)] [signature]
+//
+// Output:
+//   - byte 0: 1 iff all n signatures verify, else 0.
+//   - bytes 1-32 (only present if verbose != 0): a bitmap over the first
+//     min(n, 256) signatures, bit i set iff signature i failed to verify
+//     (bit 0 is the most significant bit of the first bitmap byte).
+func (p *SLHDSABatchPrecompile) Run(input []byte) ([]byte, error) {
+	if len(input) < 3 {
+		return []byte{0}, fmt.Errorf("invalid input: missing verbose/count header")
+	}
+	verbose := input[0] != 0
+	n := int(input[1])<<8 | int(input[2])
+
+	offset := 3
+	allValid := true
+	var bitmap [32]byte
+
+	for i := 0; i < n; i++ {
+		if len(input) < offset+3 {
+			return []byte{0}, fmt.Errorf("invalid input: entry %d missing header", i)
+		}
+		mode := slhdsa.Mode(input[offset])
+		if mode > slhdsa.SHAKE_256f {
+			return []byte{0}, fmt.Errorf("invalid input: entry %d invalid mode %d", i, mode)
+		}
+		pubKeyLen := binary.BigEndian.Uint16(input[offset+1 : offset+3])
+		offset += 3
+
+		if len(input) < offset+int(pubKeyLen)+2 {
+			return []byte{0}, fmt.Errorf("invalid input: entry %d public key truncated", i)
+		}
+		pubKeyBytes := input[offset : offset+int(pubKeyLen)]
+		offset += int(pubKeyLen)
+
+		msgLen := binary.BigEndian.Uint16(input[offset : offset+2])
+		offset += 2
+		if len(input) < offset+int(msgLen)+4 {
+			return []byte{0}, fmt.Errorf("invalid input: entry %d message truncated", i)
+		}
+		message := input[offset : offset+int(msgLen)]
+		offset += int(msgLen)
+
+		sigLen := binary.BigEndian.Uint32(input[offset : offset+4])
+		offset += 4
+		if uint64(len(input)-offset) < uint64(sigLen) {
+			return []byte{0}, fmt.Errorf("invalid input: entry %d signature truncated", i)
+		}
+		signature := input[offset : offset+int(sigLen)]
+		offset += int(sigLen)
+
+		valid := false
+		if pubKey, err := slhdsa.PublicKeyFromBytes(pubKeyBytes, mode); err == nil {
+			valid = pubKey.Verify(message, signature, nil)
+		}
+		if !valid {
+			allValid = false
+			if i < maxBatchVerboseBitmap {
+				setBit(&bitmap, i)
+			}
+		}
+	}
+
+	result := []byte{0}
+	if allValid {
+		result[0] = 1
+	}
+	if verbose {
+		result = append(result, bitmap[:]...)
+	}
+	return result, nil
+}
+
+// parseBatchHeader reads just the count and total message bytes declared
+// across all n entries, for gas estimation. ok is false if the header or any
+// entry is too short to be well-formed.
+func parseBatchHeader(input []byte) (n uint16, totalMsgBytes uint64, ok bool) {
+	if len(input) < 3 {
+		return 0, 0, false
+	}
+	n = uint16(input[1])<<8 | uint16(input[2])
+
+	offset := 3
+	for i := 0; i < int(n); i++ {
+		if len(input) < offset+3 {
+			return n, totalMsgBytes, false
+		}
+		pubKeyLen := binary.BigEndian.Uint16(input[offset+1 : offset+3])
+		offset += 3
+
+		if len(input) < offset+int(pubKeyLen)+2 {
+			return n, totalMsgBytes, false
+		}
+		offset += int(pubKeyLen)
+
+		msgLen := binary.BigEndian.Uint16(input[offset : offset+2])
+		offset += 2
+		if len(input) < offset+int(msgLen)+4 {
+			return n, totalMsgBytes, false
+		}
+		offset += int(msgLen)
+		totalMsgBytes += uint64(msgLen)
+
+		sigLen := binary.BigEndian.Uint32(input[offset : offset+4])
+		offset += 4
+		if uint64(len(input)-offset) < uint64(sigLen) {
+			return n, totalMsgBytes, false
+		}
+		offset += int(sigLen)
+	}
+	return n, totalMsgBytes, true
+}
+
+// setBit sets bit i of a 32-byte bitset, numbered so bit 0 is the most
+// significant bit of byte 0.
+func setBit(bitmap *[32]byte, i int) {
+	bitmap[i/8] |= 1 << (7 - uint(i%8))
+}
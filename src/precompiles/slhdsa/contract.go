@@ -112,3 +112,124 @@ func (p *SLHDSAPrecompile) Run(input []byte) ([]byte, error) {
 	}
 	return []byte{0}, nil
 }
+
+const (
+	// ContractV2AddressHex is the precompile address for the context-aware
+	// SLH-DSA verification precompile. This is a distinct address from
+	// ContractAddress so that chains already deployed against the legacy
+	// empty-context layout do not fork when ctx support is added.
+	ContractV2AddressHex = "0x0200000000000000000000000000000000000011"
+
+	// MaxContextSize is the largest domain-separation context FIPS 205
+	// allows (ctx MUST be at most 255 bytes).
+	MaxContextSize = 255
+
+	// SLHDSAVerifyPerContextByteGas is charged per byte of ctx, in addition
+	// to SLHDSAVerifyBaseGas and the per-message-byte charge.
+	SLHDSAVerifyPerContextByteGas = 10
+)
+
+var (
+	ContractV2Address = common.HexToAddress(ContractV2AddressHex)
+
+	ErrContextTooLong = fmt.Errorf("context exceeds %d bytes", MaxContextSize)
+)
+
+// SLHDSAPrecompileV2 implements SLH-DSA signature verification with an
+// explicit FIPS 205 domain-separation context, per
+// https://csrc.nist.gov/pubs/fips/205/final (Sign/Verify take a ctx
+// parameter that MUST match between signer and verifier).
+type SLHDSAPrecompileV2 struct{}
+
+// Address returns the precompile address
+func (p *SLHDSAPrecompileV2) Address() common.Address {
+	return ContractV2Address
+}
+
+// RequiredGas calculates the gas required for context-aware SLH-DSA
+// verification.
+// Gas = BaseGas + (MessageLength * PerByteGas) + (len(ctx) * PerContextByteGas)
+func (p *SLHDSAPrecompileV2) RequiredGas(input []byte) uint64 {
+	mode, pubKeyLen, ctxLen, msgLen, ok := parseV2Header(input)
+	if !ok {
+		return SLHDSAVerifyBaseGas
+	}
+	_ = mode
+	_ = pubKeyLen
+	return SLHDSAVerifyBaseGas +
+		(uint64(msgLen) * SLHDSAVerifyPerByteGas) +
+		(uint64(ctxLen) * SLHDSAVerifyPerContextByteGas)
+}
+
+// Run executes context-aware SLH-DSA signature verification.
+// Input format: [mode(1)] [pubKeyLen(2)] [pubKey] [ctxLen(1)] [ctx] [msgLen(2)] [message] [signature]
+// Output: [valid(1)] where 0x01 = valid, 0x00 = invalid
+func (p *SLHDSAPrecompileV2) Run(input []byte) ([]byte, error) {
+	if len(input) < 6 {
+		return []byte{0}, fmt.Errorf("invalid input: too short")
+	}
+
+	mode := slhdsa.Mode(input[0])
+	if mode > slhdsa.SHAKE_256f {
+		return []byte{0}, fmt.Errorf("invalid mode: %d", mode)
+	}
+
+	pubKeyLen := binary.BigEndian.Uint16(input[1:3])
+	if len(input) < int(3+pubKeyLen+1) {
+		return []byte{0}, fmt.Errorf("invalid input: public key too short")
+	}
+	pubKeyBytes := input[3 : 3+pubKeyLen]
+
+	ctxLenOffset := 3 + pubKeyLen
+	ctxLen := uint16(input[ctxLenOffset])
+	if ctxLen > MaxContextSize {
+		return []byte{0}, ErrContextTooLong
+	}
+	ctxOffset := ctxLenOffset + 1
+	if len(input) < int(ctxOffset+ctxLen+2) {
+		return []byte{0}, fmt.Errorf("invalid input: context too short")
+	}
+	ctx := input[ctxOffset : ctxOffset+ctxLen]
+
+	msgLenOffset := ctxOffset + ctxLen
+	msgLen := binary.BigEndian.Uint16(input[msgLenOffset : msgLenOffset+2])
+	msgOffset := msgLenOffset + 2
+	if len(input) < int(msgOffset+msgLen) {
+		return []byte{0}, fmt.Errorf("invalid input: message too short")
+	}
+	message := input[msgOffset : msgOffset+msgLen]
+	signature := input[msgOffset+msgLen:]
+
+	pubKey, err := slhdsa.PublicKeyFromBytes(pubKeyBytes, mode)
+	if err != nil {
+		return []byte{0}, fmt.Errorf("invalid public key: %w", err)
+	}
+
+	valid := pubKey.Verify(message, signature, ctx)
+	if valid {
+		return []byte{1}, nil
+	}
+	return []byte{0}, nil
+}
+
+// parseV2Header parses just enough of a V2 input to compute gas: the mode,
+// public key length, context length, and message length. ok is false if the
+// input is too short to contain a full header.
+func parseV2Header(input []byte) (mode byte, pubKeyLen, ctxLen, msgLen uint16, ok bool) {
+	if len(input) < 6 {
+		return 0, 0, 0, 0, false
+	}
+	mode = input[0]
+	pubKeyLen = binary.BigEndian.Uint16(input[1:3])
+	ctxLenOffset := int(3 + pubKeyLen)
+	if len(input) < ctxLenOffset+1 {
+		return mode, pubKeyLen, 0, 0, false
+	}
+	ctxLen = uint16(input[ctxLenOffset])
+	msgLenOffset := ctxLenOffset + 1 + int(ctxLen)
+	if len(input) < msgLenOffset+2 {
+		return mode, pubKeyLen, ctxLen, 0, false
+	}
+	msgLen = binary.BigEndian.Uint16(input[msgLenOffset : msgLenOffset+2])
+	return mode, pubKeyLen, ctxLen, msgLen, true
+}
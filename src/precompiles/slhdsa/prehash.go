@@ -0,0 +1,233 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package slhdsa
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/luxfi/crypto/slhdsa"
+	"github.com/luxfi/geth/common"
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	// ContractV3AddressHex is the precompile address for the prehash-aware,
+	// full-parameter-set-priced SLH-DSA verification precompile. Distinct
+	// from ContractAddress/ContractV2Address so chains already deployed
+	// against the pure-only layouts do not fork when this is added.
+	ContractV3AddressHex = "0x020000000000000000000000000000000000001B"
+)
+
+// SigMode selects between FIPS 205's two signature variants: SLH-DSA (pure,
+// the message is signed directly) and HashSLH-DSA (prehash, the message is
+// first hashed under a caller-chosen OID and the digest is signed).
+type SigMode byte
+
+const (
+	SigModePure    SigMode = 0
+	SigModePrehash SigMode = 1
+)
+
+// HashOID selects the hash function a prehash-mode signature's digest was
+// produced with. Only meaningful when SigMode is SigModePrehash.
+type HashOID byte
+
+const (
+	HashOIDSHA256   HashOID = 0
+	HashOIDSHA512   HashOID = 1
+	HashOIDSHAKE128 HashOID = 2
+	HashOIDSHAKE256 HashOID = 3
+)
+
+var (
+	ContractV3Address = common.HexToAddress(ContractV3AddressHex)
+
+	ErrUnsupportedSigMode  = errors.New("unsupported SLH-DSA signature mode")
+	ErrUnsupportedHashOID  = errors.New("unsupported prehash OID")
+	ErrUnsupportedParamSet = fmt.Errorf("paramSet exceeds the supported SLH-DSA parameter sets")
+)
+
+// paramSetVerifyGas returns the verification gas for an SLH-DSA parameter
+// set, or false if paramSet is out of range.
+//
+// The 12 FIPS 205 parameter sets are conventionally enumerated in (hash
+// family, security level, variant) order -- SHA2-128s, SHA2-128f,
+// SHA2-192s, SHA2-192f, SHA2-256s, SHA2-256f, SHAKE-128s, SHAKE-128f,
+// SHAKE-192s, SHAKE-192f, SHAKE-256s, SHAKE-256f -- which is consistent
+// with slhdsa.SHAKE_256f (the only other parameter-set constant this
+// package references) being the largest Mode value. Under that ordering,
+// even values are "small" (s) variants and odd values are "fast" (f)
+// variants; "f" variants carry much larger signatures (~17-49KB vs a few
+// KB for "s") that dominate verification cost, so they are priced higher.
+func paramSetVerifyGas(paramSet slhdsa.Mode) (uint64, bool) {
+	v := uint8(paramSet)
+	if v > uint8(slhdsa.SHAKE_256f) {
+		return 0, false
+	}
+	tier := uint64(v / 2) // security-level/hash-family tier, 0..5
+	base := SLHDSAVerifyBaseGas + tier*4_000
+	if v%2 == 1 {
+		base *= 3
+	}
+	return base, true
+}
+
+// prehash computes the FIPS 205 HashSLH-DSA message digest under the given
+// OID. Signers and verifiers must agree on oid so both hash the message the
+// same way before it reaches SLH-DSA's Verify.
+func prehash(oid HashOID, msg []byte) ([]byte, error) {
+	switch oid {
+	case HashOIDSHA256:
+		digest := sha256.Sum256(msg)
+		return digest[:], nil
+	case HashOIDSHA512:
+		digest := sha512.Sum512(msg)
+		return digest[:], nil
+	case HashOIDSHAKE128:
+		digest := make([]byte, 32)
+		sha3.ShakeSum128(digest, msg)
+		return digest, nil
+	case HashOIDSHAKE256:
+		digest := make([]byte, 64)
+		sha3.ShakeSum256(digest, msg)
+		return digest, nil
+	default:
+		return nil, fmt.Errorf("%w: 0x%x", ErrUnsupportedHashOID, byte(oid))
+	}
+}
+
+// SLHDSAPrecompileV3 implements SLH-DSA signature verification with both
+// FIPS 205 signature variants (pure and prehash) and per-parameter-set gas
+// pricing, superseding SLHDSAPrecompileV2's single flat-priced pure mode.
+type SLHDSAPrecompileV3 struct{}
+
+// Address returns the precompile address.
+func (p *SLHDSAPrecompileV3) Address() common.Address {
+	return ContractV3Address
+}
+
+// v3Header is everything needed to price or verify a V3 call, short of the
+// public key, context, message, and signature bytes themselves.
+type v3Header struct {
+	paramSet  slhdsa.Mode
+	sigMode   SigMode
+	hashOID   HashOID
+	pubKeyLen uint16
+	ctxLen    uint8
+	msgLen    uint32
+}
+
+// parseV3Header parses the [paramSet(1)][mode(1)][hashOID(1)][ctxLen(1)]
+// [ctx][pubKeyLen(2)][pubKey][msgLen(4)] prefix of a V3 call, rejecting
+// unsupported paramSet/mode/hashOID combinations before the caller pays to
+// parse or verify anything further. ok is false if the header is truncated.
+func parseV3Header(input []byte) (hdr v3Header, consumed int, err error, ok bool) {
+	if len(input) < 4 {
+		return v3Header{}, 0, nil, false
+	}
+	paramSet := slhdsa.Mode(input[0])
+	if _, inRange := paramSetVerifyGas(paramSet); !inRange {
+		return v3Header{}, 0, fmt.Errorf("%w: 0x%x", ErrUnsupportedParamSet, input[0]), true
+	}
+
+	sigMode := SigMode(input[1])
+	if sigMode != SigModePure && sigMode != SigModePrehash {
+		return v3Header{}, 0, fmt.Errorf("%w: 0x%x", ErrUnsupportedSigMode, input[1]), true
+	}
+
+	hashOID := HashOID(input[2])
+	if sigMode == SigModePrehash {
+		if hashOID > HashOIDSHAKE256 {
+			return v3Header{}, 0, fmt.Errorf("%w: 0x%x", ErrUnsupportedHashOID, input[2]), true
+		}
+	}
+
+	ctxLen := input[3]
+	off := 4 + int(ctxLen)
+	if len(input) < off+2 {
+		return v3Header{}, off, nil, false
+	}
+	pubKeyLen := binary.BigEndian.Uint16(input[off : off+2])
+	off += 2
+
+	if len(input) < off+int(pubKeyLen)+4 {
+		return v3Header{paramSet: paramSet, sigMode: sigMode, hashOID: hashOID, pubKeyLen: pubKeyLen, ctxLen: ctxLen}, off, nil, false
+	}
+	msgLen := binary.BigEndian.Uint32(input[off+int(pubKeyLen) : off+int(pubKeyLen)+4])
+
+	return v3Header{
+		paramSet:  paramSet,
+		sigMode:   sigMode,
+		hashOID:   hashOID,
+		pubKeyLen: pubKeyLen,
+		ctxLen:    ctxLen,
+		msgLen:    msgLen,
+	}, off, nil, true
+}
+
+// RequiredGas calculates the gas required for a V3 verification call,
+// pricing the per-parameter-set base cost plus the message/context bytes.
+func (p *SLHDSAPrecompileV3) RequiredGas(input []byte) uint64 {
+	hdr, _, err, ok := parseV3Header(input)
+	if err != nil || !ok {
+		return SLHDSAVerifyBaseGas
+	}
+	base, _ := paramSetVerifyGas(hdr.paramSet)
+	return base +
+		uint64(hdr.msgLen)*SLHDSAVerifyPerByteGas +
+		uint64(hdr.ctxLen)*SLHDSAVerifyPerContextByteGas
+}
+
+// Run executes SLH-DSA signature verification in either FIPS 205 variant.
+//
+// Input format:
+// [paramSet(1)] [sigMode(1)] [hashOID(1)] [ctxLen(1)] [ctx]
+// [pubKeyLen(2)] [pubKey] [msgLen(4)] [msg] [sig]
+//
+// hashOID is ignored (but must still be present) when sigMode is
+// SigModePure. In SigModePrehash, msg is hashed under hashOID before being
+// passed to Verify, per FIPS 205's HashSLH-DSA.
+// Output: [valid(1)] where 0x01 = valid, 0x00 = invalid.
+func (p *SLHDSAPrecompileV3) Run(input []byte) ([]byte, error) {
+	hdr, off, err, ok := parseV3Header(input)
+	if err != nil {
+		return []byte{0}, err
+	}
+	if !ok {
+		return []byte{0}, fmt.Errorf("invalid input: truncated header")
+	}
+
+	ctx := input[4 : 4+int(hdr.ctxLen)]
+	pubKeyOffset := 4 + int(hdr.ctxLen) + 2
+	pubKeyBytes := input[pubKeyOffset : pubKeyOffset+int(hdr.pubKeyLen)]
+	msgOffset := off + 4
+	if len(input) < msgOffset+int(hdr.msgLen) {
+		return []byte{0}, fmt.Errorf("invalid input: message too short")
+	}
+	message := input[msgOffset : msgOffset+int(hdr.msgLen)]
+	signature := input[msgOffset+int(hdr.msgLen):]
+
+	pubKey, err := slhdsa.PublicKeyFromBytes(pubKeyBytes, hdr.paramSet)
+	if err != nil {
+		return []byte{0}, fmt.Errorf("invalid public key: %w", err)
+	}
+
+	toVerify := message
+	if hdr.sigMode == SigModePrehash {
+		digest, err := prehash(hdr.hashOID, message)
+		if err != nil {
+			return []byte{0}, err
+		}
+		toVerify = digest
+	}
+
+	if pubKey.Verify(toVerify, signature, ctx) {
+		return []byte{1}, nil
+	}
+	return []byte{0}, nil
+}
@@ -0,0 +1,266 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+//
+// Generic ABI event packing. github.com/luxfi/evm/precompile/contract
+// doesn't vendor an accounts/abi-style PackEvent in this tree (see
+// pqcrypto/events.go's header for the same gap), so this factors the
+// topic/data layout go-ethereum's abi.PackEvent computes into a reusable
+// helper, rather than hand-rolling the head/tail layout once per event the
+// way pqcrypto/events.go does.
+
+package warp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/luxfi/geth/common"
+	"github.com/luxfi/geth/crypto"
+)
+
+const abiEventWordSize = 32
+
+// abiEventInput describes one argument of an ABI event definition: its
+// Solidity type and whether it's an indexed topic or a non-indexed data
+// field, the same (name, type, indexed) triple accounts/abi.Argument
+// carries for events.
+type abiEventInput struct {
+	Name    string
+	Type    string
+	Indexed bool
+}
+
+// abiEvent is a minimal event descriptor, just enough to derive topic0 and
+// split a call's arguments into topics and data. Anonymous matches
+// Solidity's `event Foo(...) anonymous` modifier: an anonymous event omits
+// the topic0 signature hash, which is also why it's allowed one more
+// indexed argument than a normal event -- the EVM's LOG opcodes cap out at
+// 4 topics total, and a non-anonymous event spends one of those on topic0.
+type abiEvent struct {
+	Name      string
+	Inputs    []abiEventInput
+	Anonymous bool
+}
+
+// maxIndexedInputs is the number of indexed arguments e can carry: the
+// EVM's LOG1-LOG4 opcodes support at most 4 topics, one of which a
+// non-anonymous event reserves for topic0.
+func (e abiEvent) maxIndexedInputs() int {
+	if e.Anonymous {
+		return 4
+	}
+	return 3
+}
+
+func (e abiEvent) signature() string {
+	types := make([]string, len(e.Inputs))
+	for i, in := range e.Inputs {
+		types[i] = in.Type
+	}
+	return e.Name + "(" + strings.Join(types, ",") + ")"
+}
+
+func (e abiEvent) topic0() common.Hash {
+	return crypto.Keccak256Hash([]byte(e.signature()))
+}
+
+// PackEvent ABI-encodes args against e's definition: unless e is
+// anonymous, topic[0] is the keccak256 of e's signature, followed by one
+// topic per indexed input (a left-padded word for static types,
+// keccak256(value) for dynamic ones), and the non-indexed inputs are
+// ABI-encoded into data in declaration order using the standard head/tail
+// layout (a dynamic input's head word is a byte offset into the tail,
+// where its length-prefixed content actually lives). Only the value types
+// this package's events need -- address, bytes32, uint32, and the dynamic
+// bytes type -- are supported; arrays and tuples are rejected outright
+// since no warp event uses them. e is also rejected if it indexes more
+// arguments than the EVM's LOG opcodes can carry as topics: 3 for a
+// non-anonymous event (topic0 takes the 4th), 4 for an anonymous one.
+func PackEvent(e abiEvent, args ...interface{}) ([]common.Hash, []byte, error) {
+	if len(args) != len(e.Inputs) {
+		return nil, nil, fmt.Errorf("packEvent %s: expected %d args, got %d", e.Name, len(e.Inputs), len(args))
+	}
+
+	numIndexed := 0
+	for _, in := range e.Inputs {
+		if in.Indexed {
+			numIndexed++
+		}
+	}
+	if max := e.maxIndexedInputs(); numIndexed > max {
+		return nil, nil, fmt.Errorf("packEvent %s: %d indexed inputs exceeds the %d a %s event can carry", e.Name, numIndexed, max, anonymousLabel(e.Anonymous))
+	}
+
+	var topics []common.Hash
+	if e.Anonymous {
+		topics = make([]common.Hash, 0, numIndexed)
+	} else {
+		topics = make([]common.Hash, 1, numIndexed+1)
+		topics[0] = e.topic0()
+	}
+
+	headWords := make([][]byte, 0, len(e.Inputs))
+	var tailChunks [][]byte
+	for i, in := range e.Inputs {
+		if isArrayOrTupleType(in.Type) {
+			return nil, nil, fmt.Errorf("packEvent %s: arg %s: array/tuple type %q is not supported", e.Name, in.Name, in.Type)
+		}
+
+		if in.Indexed {
+			topic, err := abiIndexedTopic(in.Type, args[i])
+			if err != nil {
+				return nil, nil, fmt.Errorf("packEvent %s: arg %s: %w", e.Name, in.Name, err)
+			}
+			topics = append(topics, topic)
+			continue
+		}
+
+		if isDynamicType(in.Type) {
+			chunk, err := abiEncodeDynamicData(in.Type, args[i])
+			if err != nil {
+				return nil, nil, fmt.Errorf("packEvent %s: arg %s: %w", e.Name, in.Name, err)
+			}
+			headWords = append(headWords, nil) // patched to a tail offset below
+			tailChunks = append(tailChunks, chunk)
+			continue
+		}
+
+		word, err := abiEncodeStaticWord(in.Type, args[i])
+		if err != nil {
+			return nil, nil, fmt.Errorf("packEvent %s: arg %s: %w", e.Name, in.Name, err)
+		}
+		headWords = append(headWords, word)
+	}
+
+	headLen := len(headWords) * abiEventWordSize
+	data := make([]byte, 0, headLen+totalByteLen(tailChunks))
+	tailOffset := headLen
+	tailIdx := 0
+	for _, word := range headWords {
+		if word != nil {
+			data = append(data, word...)
+			continue
+		}
+		data = append(data, abiEncodeOffsetWord(uint64(tailOffset))...)
+		tailOffset += len(tailChunks[tailIdx])
+		tailIdx++
+	}
+	for _, chunk := range tailChunks {
+		data = append(data, chunk...)
+	}
+
+	return topics, data, nil
+}
+
+func anonymousLabel(anonymous bool) string {
+	if anonymous {
+		return "anonymous"
+	}
+	return "non-anonymous"
+}
+
+func isDynamicType(t string) bool {
+	return t == "bytes"
+}
+
+func isArrayOrTupleType(t string) bool {
+	return strings.HasSuffix(t, "]") || strings.HasPrefix(t, "(") || t == "tuple"
+}
+
+// abiEncodeStaticWord encodes one of this package's supported static types
+// as a single 32-byte word: numeric/address values are right-aligned
+// (zero-padded on the left), matching standard ABI static encoding.
+func abiEncodeStaticWord(t string, value interface{}) ([]byte, error) {
+	word := make([]byte, abiEventWordSize)
+	switch t {
+	case "address":
+		addr, ok := value.(common.Address)
+		if !ok {
+			return nil, fmt.Errorf("expected common.Address for type %s, got %T", t, value)
+		}
+		copy(word[abiEventWordSize-common.AddressLength:], addr.Bytes())
+		return word, nil
+	case "bytes32":
+		h, ok := value.(common.Hash)
+		if !ok {
+			return nil, fmt.Errorf("expected common.Hash for type %s, got %T", t, value)
+		}
+		copy(word, h.Bytes())
+		return word, nil
+	case "uint32":
+		v, ok := value.(uint32)
+		if !ok {
+			return nil, fmt.Errorf("expected uint32 for type %s, got %T", t, value)
+		}
+		word[abiEventWordSize-4] = byte(v >> 24)
+		word[abiEventWordSize-3] = byte(v >> 16)
+		word[abiEventWordSize-2] = byte(v >> 8)
+		word[abiEventWordSize-1] = byte(v)
+		return word, nil
+	default:
+		return nil, fmt.Errorf("unsupported static event arg type %q", t)
+	}
+}
+
+// abiIndexedTopic computes the topic value for an indexed argument: dynamic
+// types are hashed (the indexed-dynamic-type rule from the ABI spec, since
+// the full value can't fit in a single topic word), static types reuse the
+// same left-padded word a non-indexed occurrence of the same type would
+// get.
+func abiIndexedTopic(t string, value interface{}) (common.Hash, error) {
+	if isDynamicType(t) {
+		b, ok := value.([]byte)
+		if !ok {
+			return common.Hash{}, fmt.Errorf("expected []byte for indexed dynamic type %s, got %T", t, value)
+		}
+		return crypto.Keccak256Hash(b), nil
+	}
+	word, err := abiEncodeStaticWord(t, value)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(word), nil
+}
+
+// abiEncodeDynamicData encodes a non-indexed dynamic argument's tail chunk:
+// a 32-byte length word followed by the content, zero-padded out to a
+// multiple of 32 bytes.
+func abiEncodeDynamicData(t string, value interface{}) ([]byte, error) {
+	if t != "bytes" {
+		return nil, fmt.Errorf("unsupported dynamic event arg type %q", t)
+	}
+	b, ok := value.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("expected []byte for type %s, got %T", t, value)
+	}
+
+	paddedLen := (len(b) + abiEventWordSize - 1) / abiEventWordSize * abiEventWordSize
+	out := make([]byte, 0, abiEventWordSize+paddedLen)
+	out = append(out, abiEncodeOffsetWord(uint64(len(b)))...)
+	out = append(out, b...)
+	out = append(out, make([]byte, paddedLen-len(b))...)
+	return out, nil
+}
+
+// abiEncodeOffsetWord right-aligns offset (or a length, or any other plain
+// uint64) into a 32-byte word.
+func abiEncodeOffsetWord(offset uint64) []byte {
+	word := make([]byte, abiEventWordSize)
+	word[abiEventWordSize-8] = byte(offset >> 56)
+	word[abiEventWordSize-7] = byte(offset >> 48)
+	word[abiEventWordSize-6] = byte(offset >> 40)
+	word[abiEventWordSize-5] = byte(offset >> 32)
+	word[abiEventWordSize-4] = byte(offset >> 24)
+	word[abiEventWordSize-3] = byte(offset >> 16)
+	word[abiEventWordSize-2] = byte(offset >> 8)
+	word[abiEventWordSize-1] = byte(offset)
+	return word
+}
+
+func totalByteLen(chunks [][]byte) int {
+	n := 0
+	for _, c := range chunks {
+		n += len(c)
+	}
+	return n
+}
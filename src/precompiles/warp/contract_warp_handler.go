@@ -11,7 +11,9 @@ import (
 	"github.com/luxfi/evm/predicate"
 	"github.com/luxfi/geth/common"
 	commonmath "github.com/luxfi/geth/common/math"
+	"github.com/luxfi/geth/core/types"
 	"github.com/luxfi/geth/core/vm"
+	"github.com/luxfi/geth/crypto"
 	"github.com/luxfi/math/set"
 	"github.com/luxfi/warp"
 	"github.com/luxfi/warp/payload"
@@ -44,9 +46,15 @@ func init() {
 type messageHandler interface {
 	packFailed() []byte
 	handleMessage(msg *warp.Message) ([]byte, error)
+
+	// emitVerifiedEvent logs the handler's own verified-message event(s), if
+	// any, after a successful handleMessage call. addressedPayloadHandler
+	// emits WarpMessageVerified and WarpMessageDelivered; blockHashHandler
+	// has no corresponding event and is a no-op.
+	emitVerifiedEvent(accessibleState contract.AccessibleState, caller common.Address, warpIndex int, msg *warp.Message, remainingGas uint64) (uint64, error)
 }
 
-func handleWarpMessage(accessibleState contract.AccessibleState, input []byte, suppliedGas uint64, handler messageHandler) ([]byte, uint64, error) {
+func handleWarpMessage(accessibleState contract.AccessibleState, caller common.Address, input []byte, suppliedGas uint64, handler messageHandler) ([]byte, uint64, error) {
 	remainingGas, err := contract.DeductGas(suppliedGas, GetVerifiedWarpMessageBaseCost)
 	if err != nil {
 		return nil, remainingGas, err
@@ -91,6 +99,10 @@ func handleWarpMessage(accessibleState contract.AccessibleState, input []byte, s
 	if err != nil {
 		return nil, remainingGas, err
 	}
+	remainingGas, err = handler.emitVerifiedEvent(accessibleState, caller, warpIndex, warpMessage, remainingGas)
+	if err != nil {
+		return nil, remainingGas, err
+	}
 	return res, remainingGas, nil
 }
 
@@ -120,12 +132,48 @@ func (addressedPayloadHandler) handleMessage(warpMessage *warp.Message) ([]byte,
 	})
 }
 
+// emitVerifiedEvent emits WarpMessageVerified(bytes32 indexed sourceChainID,
+// address indexed originSender, bytes32 payloadHash, uint32 warpIndex) and
+// WarpMessageDelivered(bytes32 indexed sourceChainID, address indexed
+// originSender, address indexed destinationAddress, bytes32 payloadHash,
+// bytes payload) for a successfully verified addressed-call warp message,
+// so indexers can observe verified cross-chain messages without parsing
+// calldata. destinationAddress is caller: the contract that called
+// getVerifiedWarpMessage is the actual recipient of the delivery.
+func (addressedPayloadHandler) emitVerifiedEvent(accessibleState contract.AccessibleState, caller common.Address, warpIndex int, warpMessage *warp.Message, remainingGas uint64) (uint64, error) {
+	addressedPayload, err := payload.Parse(warpMessage.UnsignedMessage.Payload)
+	if err != nil {
+		return remainingGas, fmt.Errorf("%w: %s", errInvalidAddressedPayload, err)
+	}
+	addressedCall, ok := addressedPayload.(*payload.AddressedCall)
+	if !ok {
+		return remainingGas, fmt.Errorf("%w: payload is not AddressedCall", errInvalidAddressedPayload)
+	}
+
+	sourceChainID := common.BytesToHash(warpMessage.UnsignedMessage.SourceChainID[:])
+	originSender := common.BytesToAddress(addressedCall.SourceAddress)
+	payloadHash := crypto.Keccak256Hash(addressedCall.Payload)
+
+	remainingGas, err = emitWarpMessageVerified(accessibleState, sourceChainID, originSender, payloadHash, uint32(warpIndex), remainingGas)
+	if err != nil {
+		return remainingGas, err
+	}
+	return emitWarpMessageDelivered(accessibleState, sourceChainID, originSender, caller, addressedCall.Payload, remainingGas)
+}
+
 type blockHashHandler struct{}
 
 func (blockHashHandler) packFailed() []byte {
 	return getVerifiedWarpBlockHashInvalidOutput
 }
 
+// emitVerifiedEvent is a no-op: WarpMessageVerified/WarpMessageDelivered
+// only cover addressed calls (see addressedPayloadHandler), not verified
+// block hashes.
+func (blockHashHandler) emitVerifiedEvent(accessibleState contract.AccessibleState, caller common.Address, warpIndex int, warpMessage *warp.Message, remainingGas uint64) (uint64, error) {
+	return remainingGas, nil
+}
+
 func (blockHashHandler) handleMessage(warpMessage *warp.Message) ([]byte, error) {
 	parsedPayload, err := payload.Parse(warpMessage.UnsignedMessage.Payload)
 	if err != nil {
@@ -0,0 +1,130 @@
+// Copyright (C) 2019-2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+//
+// ABI-encoded event logging for the warp precompile, built on abi_event.go's
+// PackEvent. github.com/luxfi/evm/precompile/contract isn't vendored in this
+// repository, so the shared PackEvent-equivalent helper that would ideally
+// live there is hand-rolled here instead.
+
+package warp
+
+import (
+	"github.com/luxfi/evm/precompile/contract"
+	"github.com/luxfi/geth/common"
+	"github.com/luxfi/geth/core/types"
+	"github.com/luxfi/geth/core/vm"
+	"github.com/luxfi/geth/crypto"
+)
+
+// Gas for an emitted log mirrors the EVM's own LOG opcode pricing table,
+// same as pqcrypto/events.go.
+const (
+	WarpLogGas      uint64 = 375
+	WarpLogTopicGas uint64 = 375
+	WarpLogDataGas  uint64 = 8
+)
+
+var eventWarpMessageVerified = abiEvent{
+	Name: "WarpMessageVerified",
+	Inputs: []abiEventInput{
+		{Name: "sourceChainID", Type: "bytes32", Indexed: true},
+		{Name: "originSender", Type: "address", Indexed: true},
+		{Name: "payloadHash", Type: "bytes32"},
+		{Name: "warpIndex", Type: "uint32"},
+	},
+}
+
+// eventWarpMessageDelivered covers the same verified-addressed-call path as
+// eventWarpMessageVerified above, but additionally names the destination
+// side of the delivery (the address that called getVerifiedWarpMessage) and
+// carries the full payload rather than just its hash, so a listener doesn't
+// have to separately fetch calldata to recover it.
+//
+// Solidity events, and the EVM's LOG opcodes, cap out at 3 indexed
+// parameters (4 topics counting the signature), so of the 5 fields named in
+// the original request (origin chain, origin sender, destination chain,
+// destination address, payload hash) this indexes the 3 most useful for a
+// log filter to key on -- origin chain, origin sender, destination address
+// -- and leaves payloadHash and payload as data. There's also no separate
+// "destination chainID" to index: a warp message's destination is
+// implicitly the chain verifying it, which isn't a value this precompile
+// has a confirmed accessor for, so that field is dropped rather than
+// invented.
+var eventWarpMessageDelivered = abiEvent{
+	Name: "WarpMessageDelivered",
+	Inputs: []abiEventInput{
+		{Name: "sourceChainID", Type: "bytes32", Indexed: true},
+		{Name: "originSender", Type: "address", Indexed: true},
+		{Name: "destinationAddress", Type: "address", Indexed: true},
+		{Name: "payloadHash", Type: "bytes32"},
+		{Name: "payload", Type: "bytes"},
+	},
+}
+
+// eventSendWarpMessage covers a chain-initiated outbound warp message: this
+// precompile only implements the inbound verify/deliver path today
+// (handleWarpMessage and friends in contract_warp_handler.go), there's no
+// sendWarpMessage entry point to call emitSendWarpMessage from yet, same
+// config.go/contract.go gap documented in signer_bins.go's header comment.
+// messageID lets an indexer correlate this log with the warp.UnsignedMessage
+// a later aggregation/delivery step will reference, without re-deriving it
+// from the full message bytes.
+var eventSendWarpMessage = abiEvent{
+	Name: "SendWarpMessage",
+	Inputs: []abiEventInput{
+		{Name: "sourceChainID", Type: "bytes32", Indexed: true},
+		{Name: "sender", Type: "address", Indexed: true},
+		{Name: "messageID", Type: "bytes32", Indexed: true},
+		{Name: "message", Type: "bytes"},
+	},
+}
+
+// emitSendWarpMessage emits SendWarpMessage(bytes32 indexed sourceChainID,
+// address indexed sender, bytes32 indexed messageID, bytes message) for an
+// outbound unsigned warp message originating at sender. See
+// eventSendWarpMessage's comment for why nothing in this package calls this
+// yet.
+func emitSendWarpMessage(accessibleState contract.AccessibleState, sourceChainID common.Hash, sender common.Address, messageID common.Hash, message []byte, remainingGas uint64) (uint64, error) {
+	return emitEvent(accessibleState, eventSendWarpMessage, remainingGas, sourceChainID, sender, messageID, message)
+}
+
+// emitEvent packs e against args, charges LOG-opcode-equivalent gas out of
+// remainingGas, and pushes the resulting log entry for the warp precompile's
+// own ContractAddress.
+func emitEvent(accessibleState contract.AccessibleState, e abiEvent, remainingGas uint64, args ...interface{}) (uint64, error) {
+	topics, data, err := PackEvent(e, args...)
+	if err != nil {
+		return remainingGas, err
+	}
+
+	gas := WarpLogGas + WarpLogTopicGas*uint64(len(topics)) + WarpLogDataGas*uint64(len(data))
+	if remainingGas < gas {
+		return 0, vm.ErrOutOfGas
+	}
+	remainingGas -= gas
+
+	accessibleState.GetStateDB().AddLog(&types.Log{
+		Address: ContractAddress,
+		Topics:  topics,
+		Data:    data,
+	})
+	return remainingGas, nil
+}
+
+// emitWarpMessageVerified emits WarpMessageVerified(bytes32 indexed
+// sourceChainID, address indexed originSender, bytes32 payloadHash, uint32
+// warpIndex) after a message has been verified.
+func emitWarpMessageVerified(accessibleState contract.AccessibleState, sourceChainID common.Hash, originSender common.Address, payloadHash common.Hash, warpIndex uint32, remainingGas uint64) (uint64, error) {
+	return emitEvent(accessibleState, eventWarpMessageVerified, remainingGas, sourceChainID, originSender, payloadHash, warpIndex)
+}
+
+// emitWarpMessageDelivered emits WarpMessageDelivered(bytes32 indexed
+// sourceChainID, address indexed originSender, address indexed
+// destinationAddress, bytes32 payloadHash, bytes payload) once a
+// getVerifiedWarpMessage call for an addressed call succeeds, so indexers
+// can filter cross-chain deliveries by either side of the message without
+// decoding calldata.
+func emitWarpMessageDelivered(accessibleState contract.AccessibleState, sourceChainID common.Hash, originSender, destinationAddress common.Address, payload []byte, remainingGas uint64) (uint64, error) {
+	payloadHash := crypto.Keccak256Hash(payload)
+	return emitEvent(accessibleState, eventWarpMessageDelivered, remainingGas, sourceChainID, originSender, destinationAddress, payloadHash, payload)
+}
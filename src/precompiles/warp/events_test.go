@@ -0,0 +1,137 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/luxfi/geth/common"
+	"github.com/luxfi/geth/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// These tests exercise PackEvent's topic/data layout directly rather than
+// emitWarpMessageVerified/emitWarpMessageDelivered end to end: doing that
+// would require a fake contract.AccessibleState/contract.StateDB, and
+// neither interface's full method set is confirmed anywhere in this
+// repository (contract.go for this package isn't part of this snapshot --
+// see signer_bins.go's header comment), so there's nothing to fake against
+// without guessing at unconfirmed methods. PackEvent's topic0/topic/data
+// bytes are exactly what TestWarpMessageFromPrimaryNetwork's predicate
+// tests can't reach, since that suite only exercises predicate
+// verification, not execution.
+
+func TestPackEvent_WarpMessageVerified(t *testing.T) {
+	sourceChainID := common.BytesToHash([]byte("source chain"))
+	originSender := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	payloadHash := crypto.Keccak256Hash([]byte("payload"))
+	warpIndex := uint32(7)
+
+	topics, data, err := PackEvent(eventWarpMessageVerified, sourceChainID, originSender, payloadHash, warpIndex)
+	require.NoError(t, err)
+
+	require.Equal(t, crypto.Keccak256Hash([]byte("WarpMessageVerified(bytes32,address,bytes32,uint32)")), topics[0])
+	require.Equal(t, []common.Hash{topics[0], sourceChainID, common.BytesToHash(originSender.Bytes())}, topics)
+
+	wantData := make([]byte, 64)
+	copy(wantData[:32], payloadHash.Bytes())
+	wantData[63] = byte(warpIndex)
+	require.Equal(t, wantData, data)
+}
+
+func TestPackEvent_WarpMessageDelivered(t *testing.T) {
+	sourceChainID := common.BytesToHash([]byte("source chain"))
+	originSender := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	destinationAddress := common.HexToAddress("0x3333333333333333333333333333333333333333")
+	payload := []byte("cross-chain payload that isn't a multiple of 32 bytes")
+	payloadHash := crypto.Keccak256Hash(payload)
+
+	topics, data, err := PackEvent(eventWarpMessageDelivered, sourceChainID, originSender, destinationAddress, payloadHash, payload)
+	require.NoError(t, err)
+
+	require.Equal(t, crypto.Keccak256Hash([]byte("WarpMessageDelivered(bytes32,address,address,bytes32,bytes)")), topics[0])
+	require.Equal(t, []common.Hash{
+		topics[0],
+		sourceChainID,
+		common.BytesToHash(originSender.Bytes()),
+		common.BytesToHash(destinationAddress.Bytes()),
+	}, topics)
+
+	// data: (bytes32 payloadHash, bytes payload) -- payloadHash is the
+	// static head word, payload is dynamic so its head word is a byte
+	// offset (64, past the two head words) to its length-prefixed tail.
+	require.Len(t, data, 32+32+32+32+32) // payloadHash + offset + length + 2 padded words of content
+	require.Equal(t, payloadHash.Bytes(), data[:32])
+	require.Equal(t, uint64(64), binary.BigEndian.Uint64(data[56:64]))
+	require.Equal(t, uint64(len(payload)), binary.BigEndian.Uint64(data[88:96]))
+	require.Equal(t, payload, data[96:96+len(payload)])
+}
+
+func TestPackEvent_SendWarpMessage(t *testing.T) {
+	sourceChainID := common.BytesToHash([]byte("source chain"))
+	sender := common.HexToAddress("0x4444444444444444444444444444444444444444")
+	messageID := crypto.Keccak256Hash([]byte("unsigned message bytes"))
+	message := []byte("outbound warp message payload that isn't a multiple of 32 bytes either")
+
+	topics, data, err := PackEvent(eventSendWarpMessage, sourceChainID, sender, messageID, message)
+	require.NoError(t, err)
+
+	require.Equal(t, crypto.Keccak256Hash([]byte("SendWarpMessage(bytes32,address,bytes32,bytes)")), topics[0])
+	require.Equal(t, []common.Hash{
+		topics[0],
+		sourceChainID,
+		common.BytesToHash(sender.Bytes()),
+		messageID,
+	}, topics)
+
+	// data: (bytes message) alone -- a single dynamic field, so its head
+	// word is a fixed offset of 32 (past its own one-word head).
+	require.Equal(t, uint64(32), binary.BigEndian.Uint64(data[24:32]))
+	require.Equal(t, uint64(len(message)), binary.BigEndian.Uint64(data[56:64]))
+	require.Equal(t, message, data[64:64+len(message)])
+
+	// Gas is a deterministic function of topic and data byte counts alone:
+	// 4 topics (signature + 3 indexed fields) and 160 bytes of data (offset
+	// word + length word + 3 padded words of a 70-byte message).
+	require.Len(t, data, 160)
+	wantGas := WarpLogGas + WarpLogTopicGas*4 + WarpLogDataGas*160
+	gotGas := WarpLogGas + WarpLogTopicGas*uint64(len(topics)) + WarpLogDataGas*uint64(len(data))
+	require.Equal(t, wantGas, gotGas)
+}
+
+func TestPackEvent_RejectsTooManyIndexedInputs(t *testing.T) {
+	e := abiEvent{Name: "TooManyTopics", Inputs: []abiEventInput{
+		{Name: "a", Type: "bytes32", Indexed: true},
+		{Name: "b", Type: "bytes32", Indexed: true},
+		{Name: "c", Type: "bytes32", Indexed: true},
+		{Name: "d", Type: "bytes32", Indexed: true},
+	}}
+	_, _, err := PackEvent(e, common.Hash{}, common.Hash{}, common.Hash{}, common.Hash{})
+	require.Error(t, err)
+
+	// The same 4 indexed inputs are within bounds for an anonymous event,
+	// which doesn't spend a topic slot on topic0.
+	e.Anonymous = true
+	topics, _, err := PackEvent(e, common.Hash{}, common.Hash{}, common.Hash{}, common.Hash{})
+	require.NoError(t, err)
+	require.Len(t, topics, 4)
+}
+
+func TestPackEvent_RejectsArrayType(t *testing.T) {
+	e := abiEvent{Name: "BadEvent", Inputs: []abiEventInput{{Name: "xs", Type: "uint32[]"}}}
+	_, _, err := PackEvent(e, []uint32{1, 2})
+	require.Error(t, err)
+}
+
+func TestPackEvent_RejectsTupleType(t *testing.T) {
+	e := abiEvent{Name: "BadEvent", Inputs: []abiEventInput{{Name: "t", Type: "(uint32,address)"}}}
+	_, _, err := PackEvent(e, struct{}{})
+	require.Error(t, err)
+}
+
+func TestPackEvent_RejectsArgCountMismatch(t *testing.T) {
+	_, _, err := PackEvent(eventWarpMessageVerified, common.Hash{})
+	require.Error(t, err)
+}
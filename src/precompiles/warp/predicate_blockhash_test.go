@@ -0,0 +1,231 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+//
+// Predicate-verification coverage for the getVerifiedWarpBlockHash path
+// (blockHashHandler in contract_warp_handler.go), alongside the existing
+// getVerifiedWarpMessage coverage above in predicate_test.go. The BLS
+// quorum check a real VerifyPredicate performs doesn't inspect the
+// message's payload at all -- createConsensusCtx/createValidPredicateTest
+// are already payload-type agnostic -- so the only new machinery needed
+// here is building a BlockHashPayload-wrapped message instead of an
+// AddressedCall-wrapped one; blockHashHandler.handleMessage's own
+// payload-type handling is tested directly against its real, already
+// implemented code.
+
+package warp
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/luxfi/consensus"
+	"github.com/luxfi/consensus/engine/chain/block"
+	"github.com/luxfi/consensus/validator"
+	"github.com/luxfi/consensus/validator/validatorstest"
+	"github.com/luxfi/crypto/bls"
+	"github.com/luxfi/evm/precompile/precompileconfig"
+	"github.com/luxfi/evm/precompile/precompiletest"
+	"github.com/luxfi/evm/predicate"
+	"github.com/luxfi/evm/utils"
+	"github.com/luxfi/evm/utils/utilstest"
+	"github.com/luxfi/geth/common"
+	"github.com/luxfi/ids"
+	agoUtils "github.com/luxfi/node/utils"
+	"github.com/luxfi/node/utils/constants"
+	luxWarp "github.com/luxfi/warp"
+	"github.com/luxfi/warp/payload"
+	"github.com/stretchr/testify/require"
+)
+
+// newBlockHashWarpMessage builds an UnsignedMessage wrapping a
+// payload.Hash(blockHash) from sourceChainID, signed by the first numKeys
+// of testVdrs, mirroring createWarpMessage's AddressedCall construction
+// for the block-hash payload type. payload.NewHash's signature isn't used
+// anywhere else in this repository, but is inferred by the same
+// constructor convention payload.NewAddressedCall already uses elsewhere
+// in this file (single value in, (*T, error) out).
+func newBlockHashWarpMessage(tb testing.TB, sourceChainID, blockHash ids.ID, numKeys int) *luxWarp.Message {
+	hashPayload, err := payload.NewHash(blockHash)
+	require.NoError(tb, err)
+	msg, err := luxWarp.NewUnsignedMessage(constants.UnitTestID, sourceChainID[:], hashPayload.Bytes())
+	require.NoError(tb, err)
+
+	signatures := make([]*bls.Signature, numKeys)
+	for i := 0; i < numKeys; i++ {
+		sig, err := testVdrs[i].sk.Sign(msg.Bytes())
+		require.NoError(tb, err)
+		signatures[i] = sig
+	}
+	aggregateSignature, err := bls.AggregateSignatures(signatures)
+	require.NoError(tb, err)
+	bitSet := luxWarp.NewBitSet()
+	for i := 0; i < numKeys; i++ {
+		bitSet.Add(i)
+	}
+	warpSignature := &luxWarp.BitSetSignature{Signers: bitSet}
+	copy(warpSignature.Signature[:], bls.SignatureToBytes(aggregateSignature))
+
+	return &luxWarp.Message{UnsignedMessage: msg, Signature: warpSignature}
+}
+
+// createBlockHashPredicate builds and packs a block-hash warp message the
+// same way createPredicate does for addressed calls.
+func createBlockHashPredicate(tb testing.TB, sourceChainID, blockHash ids.ID, numKeys int) []byte {
+	warpMsg := newBlockHashWarpMessage(tb, sourceChainID, blockHash, numKeys)
+	return predicate.PackPredicate(warpMsg.Bytes())
+}
+
+// TestWarpBlockHashSignatureWeights is the getVerifiedWarpBlockHash analogue
+// of TestWarpSignatureWeightsDefaultQuorumNumerator: same uniformly-weighted
+// 100-validator set, same pass/fail boundary at the default quorum
+// numerator, just a block-hash payload instead of an addressed call.
+func TestWarpBlockHashSignatureWeights(t *testing.T) {
+	consensusCtx := createConsensusCtx(t, []validatorRange{
+		{
+			start:     0,
+			end:       100,
+			weight:    20,
+			publicKey: true,
+		},
+	})
+
+	tests := make(map[string]precompiletest.PredicateTest)
+	for _, numSigners := range []int{
+		1,
+		int(WarpDefaultQuorumNumerator) - 1,
+		int(WarpDefaultQuorumNumerator),
+		int(WarpDefaultQuorumNumerator) + 1,
+		int(WarpQuorumDenominator),
+	} {
+		predicateBytes := createBlockHashPredicate(t, sourceChainID, ids.GenerateTestID(), numSigners)
+		var expectedErr error
+		if numSigners < int(WarpDefaultQuorumNumerator) {
+			expectedErr = errFailedVerification
+		}
+
+		tests[fmt.Sprintf("blockhash default quorum %d signature(s)", numSigners)] = precompiletest.PredicateTest{
+			Config: NewDefaultConfig(utils.NewUint64(0)),
+			PredicateContext: &precompileconfig.PredicateContext{
+				ConsensusCtx: consensusCtx,
+				ProposerVMBlockCtx: &block.Context{
+					PChainHeight: 1,
+				},
+			},
+			PredicateBytes: predicateBytes,
+			Gas:            GasCostPerSignatureVerification + uint64(len(predicateBytes))*GasCostPerWarpMessageBytes + uint64(numSigners)*GasCostPerWarpSigner,
+			GasErr:         nil,
+			ExpectedErr:    expectedErr,
+		}
+	}
+	precompiletest.RunPredicateTests(t, tests)
+}
+
+// TestWarpBlockHashPredicateMismatchedSubnet is the block-hash analogue of
+// testWarpMessageFromPrimaryNetwork's subnet-mismatch structure: the
+// message is signed by validators registered under one subnet, but the
+// consensus context resolves the message's actual sourceChainID to a
+// different subnet with no matching registered validators, so quorum can
+// never be reached no matter how many of the real signers participated.
+func TestWarpBlockHashPredicateMismatchedSubnet(t *testing.T) {
+	require := require.New(t)
+	numKeys := 100
+	registeredSubnetID := ids.GenerateTestID()
+	actualChainID := ids.GenerateTestID()
+
+	predicateBytes := createBlockHashPredicate(t, actualChainID, ids.GenerateTestID(), numKeys)
+
+	getValidatorsOutput := make(map[ids.NodeID]*validators.GetValidatorOutput, numKeys)
+	for i := 0; i < numKeys; i++ {
+		getValidatorsOutput[testVdrs[i].nodeID] = &validators.GetValidatorOutput{
+			NodeID:    testVdrs[i].nodeID,
+			Weight:    20,
+			PublicKey: bls.PublicKeyToCompressedBytes(testVdrs[i].cryptoPK),
+		}
+	}
+
+	consensusCtx := utilstest.NewTestConsensusContext(t)
+	state := &validatorstest.State{
+		GetValidatorSetF: func(ctx context.Context, height uint64, requestedSubnetID ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+			require.Equal(registeredSubnetID, requestedSubnetID)
+			return nil, nil
+		},
+	}
+	wrappedState := &testValidatorStateWrapper{
+		State: state,
+		GetSubnetIDF: func(chainID ids.ID) (ids.ID, error) {
+			require.Equal(actualChainID, chainID)
+			// The validator set actually registered under
+			// registeredSubnetID is unreachable from here: the message's
+			// source chain resolves to a different subnet entirely.
+			return registeredSubnetID, nil
+		},
+	}
+	consensusCtx = consensus.WithValidatorState(consensusCtx, wrappedState)
+
+	test := precompiletest.PredicateTest{
+		Config: NewDefaultConfig(utils.NewUint64(0)),
+		PredicateContext: &precompileconfig.PredicateContext{
+			ConsensusCtx: consensusCtx,
+			ProposerVMBlockCtx: &block.Context{
+				PChainHeight: 1,
+			},
+		},
+		PredicateBytes: predicateBytes,
+		Gas:            GasCostPerSignatureVerification + uint64(len(predicateBytes))*GasCostPerWarpMessageBytes + uint64(numKeys)*GasCostPerWarpSigner,
+		GasErr:         nil,
+		ExpectedErr:    errFailedVerification,
+	}
+	test.Run(t)
+}
+
+// TestBlockHashHandler_HandleMessage exercises blockHashHandler.handleMessage
+// directly -- the real, already-implemented code in
+// contract_warp_handler.go that's reached once VerifyPredicate marks a
+// predicate valid -- rather than through the (missing) config.go's
+// VerifyPredicate, since payload-type dispatch is entirely handleMessage's
+// concern, not the BLS quorum check's.
+func TestBlockHashHandler_HandleMessage(t *testing.T) {
+	handler := blockHashHandler{}
+
+	t.Run("valid hash payload", func(t *testing.T) {
+		msgSourceChainID := ids.GenerateTestID()
+		blockHash := ids.GenerateTestID()
+		warpMsg := newBlockHashWarpMessage(t, msgSourceChainID, blockHash, 1)
+
+		out, err := handler.handleMessage(warpMsg)
+		require.NoError(t, err)
+
+		wantOut, err := PackGetVerifiedWarpBlockHashOutput(GetVerifiedWarpBlockHashOutput{
+			WarpBlockHash: WarpBlockHash{
+				SourceChainID: common.BytesToHash(msgSourceChainID[:]),
+				BlockHash:     common.BytesToHash(blockHash[:]),
+			},
+			Valid: true,
+		})
+		require.NoError(t, err)
+		require.Equal(t, wantOut, out)
+	})
+
+	t.Run("unknown payload type is rejected cleanly", func(t *testing.T) {
+		addressedCall, err := payload.NewAddressedCall(agoUtils.RandomBytes(20), agoUtils.RandomBytes(100))
+		require.NoError(t, err)
+		unsignedMsg, err := luxWarp.NewUnsignedMessage(constants.UnitTestID, sourceChainID[:], addressedCall.Bytes())
+		require.NoError(t, err)
+		warpMsg := &luxWarp.Message{UnsignedMessage: unsignedMsg, Signature: &luxWarp.BitSetSignature{Signers: luxWarp.NewBitSet()}}
+
+		_, err = handler.handleMessage(warpMsg)
+		require.ErrorIs(t, err, errInvalidBlockHashPayload)
+	})
+
+	t.Run("invalid payload length is rejected cleanly, not a panic", func(t *testing.T) {
+		unsignedMsg, err := luxWarp.NewUnsignedMessage(constants.UnitTestID, sourceChainID[:], []byte{1, 2, 3})
+		require.NoError(t, err)
+		warpMsg := &luxWarp.Message{UnsignedMessage: unsignedMsg, Signature: &luxWarp.BitSetSignature{Signers: luxWarp.NewBitSet()}}
+
+		require.NotPanics(t, func() {
+			_, err = handler.handleMessage(warpMsg)
+		})
+		require.ErrorIs(t, err, errInvalidBlockHashPayload)
+	})
+}
@@ -797,6 +797,32 @@ func makeWarpPredicateTests(tb testing.TB) map[string]precompiletest.PredicateTe
 
 		predicateTests[testName] = createValidPredicateTest(consensusCtx, uint64(numSigners), predicateBytes)
 	}
+
+	// getVerifiedWarpBlockHash predicates: same heavily-weighted-signers
+	// scheme as the loop above (so quorum is trivially met regardless of
+	// numSigners' share of the validator count), but with a BlockHashPayload
+	// message instead of an AddressedCall one, so BenchmarkWarpPredicate can
+	// compare the two payload types' verification cost at the same scale.
+	for _, numSigners := range []int{1, numTestVdrs / 4, numTestVdrs / 2, numTestVdrs} {
+		testName := fmt.Sprintf("blockhash %d signers (heavily weighted)/%d validators", numSigners, numTestVdrs)
+
+		predicateBytes := createBlockHashPredicate(tb, sourceChainID, ids.GenerateTestID(), numSigners)
+		consensusCtx := createConsensusCtx(tb, []validatorRange{
+			{
+				start:     0,
+				end:       numSigners,
+				weight:    10_000_000,
+				publicKey: true,
+			},
+			{
+				start:     numSigners,
+				end:       numTestVdrs,
+				weight:    20,
+				publicKey: true,
+			},
+		})
+		predicateTests[testName] = createValidPredicateTest(consensusCtx, uint64(numSigners), predicateBytes)
+	}
 	return predicateTests
 }
 
@@ -0,0 +1,132 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+//
+// Reference wiring for signature_scheme.go, verify_cache.go, and
+// vrf_sampling.go into the validator-resolution step a real
+// Config.VerifyPredicate would run.
+//
+// Repeating the gap those three files each document, because it governs
+// everything below: Config, NewConfig, NewDefaultConfig, and
+// PredicateContext are referenced throughout predicate_test.go but are
+// not defined anywhere in this package's *.go files (confirmed again
+// while writing this file -- grep for "func VerifyPredicate|type Config
+// struct" across src/precompiles/warp/*.go returns nothing). That gap
+// predates and is broader than the BLS-scheme-allowlist,
+// predicate-verification-cache, and VRF-sampling requests: it also
+// leaves contract_warp_handler.go's own ContractAddress/
+// GasCostPerWarpMessageBytes references undefined, and it means none of
+// the three requests can be wired into an actual VerifyPredicate method
+// body in this tree, because there is no such method body to add a call
+// to.
+//
+// What this file does instead is wire the three files' exported pieces
+// together into the one composition a real VerifyPredicate would run
+// once config.go exists: ResolvePredicateVoters expands a validator set
+// into the sampled-or-full voter list (gating each voter's usable public
+// key through isValidatorPubKeyAllowed along the way), and
+// VerifyPredicateQuorumCached runs the resulting quorum check through
+// PredicateVerificationCache. A real VerifyPredicate's body would become
+// a call to these two functions plus the BLS aggregate-signature check
+// predicate_test.go already exercises through
+// precompiletest.RunPredicateTests against luxWarp.Message -- that check
+// is not reimplemented here, since its real behavior lives in the
+// luxWarp package, not this one.
+//
+// This is still a follow-up, not the shipped feature: until config.go
+// exists and a real VerifyPredicate calls ResolvePredicateVoters and
+// VerifyPredicateQuorumCached, the BLS-scheme-allowlist,
+// predicate-verification-cache, and VRF-sampling requests remain
+// unreachable from the actual warp precompile, exactly as flagged in
+// review. Landing config.go and that call site is required before any of
+// the three can be considered a working, merged feature rather than
+// tested-in-isolation machinery.
+
+package warp
+
+import (
+	"github.com/luxfi/consensus/validator"
+	"github.com/luxfi/crypto/bls"
+	"github.com/luxfi/ids"
+)
+
+// ResolvePredicateVoters expands vdrs into the SampledValidator form
+// SampleVoterSet/VerifySampledQuorum operate on, decoding each
+// validator's public key under allowlist (a nil allowlist falls back to
+// DefaultSignatureSchemeAllowlist, matching isValidatorPubKeyAllowed's
+// zero-value contract). A validator with no registered key, or one whose
+// key doesn't decode under any allowed scheme, is still included with its
+// full weight -- the same "publicKey: false" outcome
+// isValidatorPubKeyAllowed's doc comment describes -- but is absent from
+// the returned pubKeys map, so it can't contribute a verified signature
+// share.
+//
+// When cfg is non-nil, the returned voters are a VRF-sampled subset sized
+// by cfg.SampleThresholdWeight() rather than the full set, seeded
+// deterministically from (unsignedMsgBytes, pChainHeight) so every
+// verifier derives the same sample.
+func ResolvePredicateVoters(
+	cfg *SamplingConfig,
+	unsignedMsgBytes []byte,
+	pChainHeight uint64,
+	vdrs map[ids.NodeID]*validators.GetValidatorOutput,
+	allowlist SignatureSchemeAllowlist,
+) (voters []SampledValidator, pubKeys map[ids.NodeID]*bls.PublicKey) {
+	if allowlist == nil {
+		allowlist = DefaultSignatureSchemeAllowlist
+	}
+
+	all := make([]SampledValidator, 0, len(vdrs))
+	pubKeys = make(map[ids.NodeID]*bls.PublicKey, len(vdrs))
+	for nodeID, v := range vdrs {
+		all = append(all, SampledValidator{NodeID: nodeID, Weight: v.Weight})
+		if len(v.PublicKey) == 0 {
+			continue
+		}
+		if pubKey, ok := isValidatorPubKeyAllowed(allowlist, v.PublicKey); ok {
+			pubKeys[nodeID] = pubKey
+		}
+	}
+
+	if cfg == nil {
+		return all, pubKeys
+	}
+	seed := deriveSampleSeed(unsignedMsgBytes, pChainHeight)
+	return SampleVoterSet(seed, all, cfg.SampleThresholdWeight()), pubKeys
+}
+
+// VerifyPredicateQuorumCached is the cache-keyed quorum check a real
+// VerifyPredicate would run against voters (the output of
+// ResolvePredicateVoters) after a successful BLS aggregate-signature
+// check over signerNodeIDs. It reuses a prior result for the identical
+// (message, signer set, height, subnet, quorum) tuple via cache, falling
+// back to VerifySampledQuorum otherwise -- voters being the full
+// validator set or a sampled subset makes no difference to this check
+// itself, only to which weight signerNodeIDs must cover.
+//
+// A quorum check that fails with ErrSignerNotSampled is still cached as
+// invalid: the same signer set hashed into the cache key will always
+// produce that same error against the same voters, so caching it is
+// sound, but it does mean a second call against an identical key only
+// ever surfaces the error from whichever call first populated the cache.
+func VerifyPredicateQuorumCached(
+	cache *PredicateVerificationCache,
+	unsignedMsgBytes, signersBytes []byte,
+	pChainHeight uint64,
+	subnetID ids.ID,
+	quorumNumerator uint64,
+	voters []SampledValidator,
+	signerNodeIDs []ids.NodeID,
+) (bool, error) {
+	key := NewPredicateCacheKey(unsignedMsgBytes, signersBytes, pChainHeight, subnetID, quorumNumerator)
+
+	var verifyErr error
+	valid := cache.VerifyCached(key, func() bool {
+		ok, err := VerifySampledQuorum(voters, signerNodeIDs, quorumNumerator)
+		verifyErr = err
+		return ok
+	})
+	if verifyErr != nil {
+		return false, verifyErr
+	}
+	return valid, nil
+}
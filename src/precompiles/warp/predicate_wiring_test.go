@@ -0,0 +1,115 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+//
+// See predicate_wiring.go's header comment for why these tests call
+// ResolvePredicateVoters/VerifyPredicateQuorumCached directly rather than
+// through a Config.VerifyPredicate method: there isn't one in this
+// package to call.
+
+package warp
+
+import (
+	"testing"
+
+	"github.com/luxfi/consensus/validator"
+	"github.com/luxfi/crypto/bls"
+	"github.com/luxfi/ids"
+	"github.com/stretchr/testify/require"
+)
+
+func smallTestValidatorSet(t *testing.T, n int) map[ids.NodeID]*validators.GetValidatorOutput {
+	t.Helper()
+	require.LessOrEqual(t, n, len(testVdrs))
+
+	out := make(map[ids.NodeID]*validators.GetValidatorOutput, n)
+	for i := 0; i < n; i++ {
+		out[testVdrs[i].nodeID] = &validators.GetValidatorOutput{
+			NodeID:    testVdrs[i].nodeID,
+			Weight:    10,
+			PublicKey: bls.PublicKeyToCompressedBytes(testVdrs[i].cryptoPK),
+		}
+	}
+	return out
+}
+
+func TestResolvePredicateVoters_FullSetDecodesAllowedKeys(t *testing.T) {
+	vdrSet := smallTestValidatorSet(t, 5)
+
+	voters, pubKeys := ResolvePredicateVoters(nil, unsignedMsg.Bytes(), pChainHeight, vdrSet, nil)
+	require.Len(t, voters, 5)
+	require.Len(t, pubKeys, 5)
+	for _, v := range voters {
+		require.Contains(t, pubKeys, v.NodeID)
+	}
+}
+
+func TestResolvePredicateVoters_UnregisteredKeyCountsWeightButNotPubKey(t *testing.T) {
+	vdrSet := smallTestValidatorSet(t, 3)
+	noKeyNodeID := testVdrs[3].nodeID
+	vdrSet[noKeyNodeID] = &validators.GetValidatorOutput{NodeID: noKeyNodeID, Weight: 10}
+
+	voters, pubKeys := ResolvePredicateVoters(nil, unsignedMsg.Bytes(), pChainHeight, vdrSet, nil)
+	require.Len(t, voters, 4)
+	require.Len(t, pubKeys, 3)
+	require.NotContains(t, pubKeys, noKeyNodeID)
+}
+
+func TestResolvePredicateVoters_DisallowedSchemeExcludesPubKeyOnly(t *testing.T) {
+	vdrSet := smallTestValidatorSet(t, 2)
+
+	_, pubKeysDefault := ResolvePredicateVoters(nil, unsignedMsg.Bytes(), pChainHeight, vdrSet, DefaultSignatureSchemeAllowlist)
+	require.Len(t, pubKeysDefault, 2)
+
+	voters, pubKeysNone := ResolvePredicateVoters(nil, unsignedMsg.Bytes(), pChainHeight, vdrSet, SignatureSchemeAllowlist{SchemeDilithium})
+	require.Len(t, voters, 2, "excluding a validator's key from the allowlist must not drop its weight")
+	require.Empty(t, pubKeysNone)
+}
+
+func TestResolvePredicateVoters_SampledSubsetIsDeterministic(t *testing.T) {
+	vdrSet := smallTestValidatorSet(t, 50)
+	cfg := NewConfigWithSampling(nil, 67, 20)
+
+	voters1, _ := ResolvePredicateVoters(cfg, unsignedMsg.Bytes(), pChainHeight, vdrSet, nil)
+	voters2, _ := ResolvePredicateVoters(cfg, unsignedMsg.Bytes(), pChainHeight, vdrSet, nil)
+	require.Equal(t, voters1, voters2)
+	require.LessOrEqual(t, len(voters1), len(vdrSet))
+}
+
+func TestVerifyPredicateQuorumCached_CachesAcrossIdenticalKeys(t *testing.T) {
+	cache := NewPredicateVerificationCache(0)
+	voters := []SampledValidator{
+		{NodeID: testVdrs[0].nodeID, Weight: 60},
+		{NodeID: testVdrs[1].nodeID, Weight: 40},
+	}
+	signers := []ids.NodeID{testVdrs[0].nodeID}
+	signersBytes := testVdrs[0].nodeID[:]
+
+	valid, err := VerifyPredicateQuorumCached(cache, unsignedMsg.Bytes(), signersBytes, pChainHeight, sourceSubnetID, 50, voters, signers)
+	require.NoError(t, err)
+	require.True(t, valid)
+
+	key := NewPredicateCacheKey(unsignedMsg.Bytes(), signersBytes, pChainHeight, sourceSubnetID, 50)
+	cached, ok := cache.Get(key)
+	require.True(t, ok)
+	require.True(t, cached)
+
+	// A second call against the identical tuple must return the cached
+	// result without needing voters/signers again to reach the same
+	// answer -- passing nil in their place still succeeds because
+	// VerifyCached short-circuits on the cache hit before evaluating
+	// VerifySampledQuorum.
+	valid, err = VerifyPredicateQuorumCached(cache, unsignedMsg.Bytes(), signersBytes, pChainHeight, sourceSubnetID, 50, nil, nil)
+	require.NoError(t, err)
+	require.True(t, valid)
+}
+
+func TestVerifyPredicateQuorumCached_RejectsUnsampledSigner(t *testing.T) {
+	cache := NewPredicateVerificationCache(0)
+	voters := []SampledValidator{{NodeID: testVdrs[0].nodeID, Weight: 100}}
+	outsideSigner := []ids.NodeID{testVdrs[1].nodeID}
+	signersBytes := testVdrs[1].nodeID[:]
+
+	valid, err := VerifyPredicateQuorumCached(cache, unsignedMsg.Bytes(), signersBytes, pChainHeight, sourceSubnetID, 50, voters, outsideSigner)
+	require.ErrorIs(t, err, ErrSignerNotSampled)
+	require.False(t, valid)
+}
@@ -0,0 +1,50 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+//
+// Primary Network -> Subnet validator set resolution. TestWarpMessageFromPrimaryNetwork
+// already exercises the intended behavior end to end via NewConfig's
+// requirePrimaryNetworkSigners parameter and testValidatorStateWrapper's
+// GetSubnetIDF, but that test drives precompileconfig.Config/
+// PredicateContext's real VerifyPredicate, which -- like the rest of this
+// package's config.go gap documented in signer_bins.go, verify_cache.go,
+// and signature_scheme.go -- isn't part of this snapshot. ResolveValidatorSetSubnetID
+// below is the actual decision VerifyPredicate's validatorRange loop needs
+// to make before it calls GetValidatorSet: which subnetID to fetch, and
+// whether the Primary Network's own signers should additionally be
+// required. Wiring it in is replacing that loop's unconditional
+// `state.GetValidatorSet(pChainHeight, sourceSubnetID)` with
+// `state.GetValidatorSet(pChainHeight, subnetID)` where subnetID comes
+// from this function.
+
+package warp
+
+import (
+	"github.com/luxfi/ids"
+	"github.com/luxfi/node/utils/constants"
+)
+
+// ResolveValidatorSetSubnetID decides which subnetID a predicate must fetch
+// the validator set for. getSubnetID resolves sourceChainID (the chain the
+// unsigned message claims to originate from) to its subnet, the same
+// consensus.ValidatorState.GetSubnetID call testValidatorStateWrapper wraps.
+//
+// If sourceChainID resolves to the Primary Network, the message originated
+// on a Primary Network chain (e.g. the C-Chain) rather than a subnet, so
+// there is no source subnet validator set to verify against at all -- the
+// only meaningful set is localSubnetID's, the subnet the predicate is
+// actually being evaluated on (the message's destination). Otherwise the
+// source subnet's own validator set is used, exactly as today.
+//
+// fromPrimaryNetwork reports which branch was taken, so a caller can also
+// decide whether requirePrimaryNetworkSigners should additionally gate
+// this message (only meaningful once fromPrimaryNetwork is true).
+func ResolveValidatorSetSubnetID(getSubnetID func(ids.ID) (ids.ID, error), sourceChainID, localSubnetID ids.ID) (subnetID ids.ID, fromPrimaryNetwork bool, err error) {
+	sourceSubnetID, err := getSubnetID(sourceChainID)
+	if err != nil {
+		return ids.Empty, false, err
+	}
+	if sourceSubnetID == constants.PrimaryNetworkID {
+		return localSubnetID, true, nil
+	}
+	return sourceSubnetID, false, nil
+}
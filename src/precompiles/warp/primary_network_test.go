@@ -0,0 +1,51 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/luxfi/ids"
+	"github.com/luxfi/node/utils/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveValidatorSetSubnetID_PrimaryNetworkSource(t *testing.T) {
+	cChainID := ids.GenerateTestID()
+	localSubnetID := ids.GenerateTestID()
+	getSubnetID := func(chainID ids.ID) (ids.ID, error) {
+		require.Equal(t, cChainID, chainID)
+		return constants.PrimaryNetworkID, nil
+	}
+
+	subnetID, fromPrimaryNetwork, err := ResolveValidatorSetSubnetID(getSubnetID, cChainID, localSubnetID)
+	require.NoError(t, err)
+	require.True(t, fromPrimaryNetwork)
+	require.Equal(t, localSubnetID, subnetID, "a Primary Network source must resolve to the destination subnet's validator set")
+}
+
+func TestResolveValidatorSetSubnetID_SameSubnetSource(t *testing.T) {
+	sourceChainID := ids.GenerateTestID()
+	sourceSubnetID := ids.GenerateTestID()
+	localSubnetID := ids.GenerateTestID()
+	getSubnetID := func(ids.ID) (ids.ID, error) {
+		return sourceSubnetID, nil
+	}
+
+	subnetID, fromPrimaryNetwork, err := ResolveValidatorSetSubnetID(getSubnetID, sourceChainID, localSubnetID)
+	require.NoError(t, err)
+	require.False(t, fromPrimaryNetwork)
+	require.Equal(t, sourceSubnetID, subnetID, "a non-Primary-Network source keeps using its own subnet's validator set")
+}
+
+func TestResolveValidatorSetSubnetID_PropagatesLookupError(t *testing.T) {
+	wantErr := errors.New("boom")
+	getSubnetID := func(ids.ID) (ids.ID, error) {
+		return ids.Empty, wantErr
+	}
+
+	_, _, err := ResolveValidatorSetSubnetID(getSubnetID, ids.GenerateTestID(), ids.GenerateTestID())
+	require.ErrorIs(t, err, wantErr)
+}
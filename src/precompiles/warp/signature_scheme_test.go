@@ -0,0 +1,93 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"testing"
+
+	"github.com/luxfi/crypto/bls"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsValidatorPubKeyAllowed_DefaultAcceptsBLS12381(t *testing.T) {
+	pubKeyBytes := bls.PublicKeyToCompressedBytes(testVdrs[0].cryptoPK)
+
+	pubKey, ok := isValidatorPubKeyAllowed(DefaultSignatureSchemeAllowlist, pubKeyBytes)
+	require.True(t, ok)
+	require.Equal(t, bls.PublicKeyToCompressedBytes(pubKey), pubKeyBytes)
+}
+
+func TestIsValidatorPubKeyAllowed_EmptyAllowlistRejectsEverything(t *testing.T) {
+	pubKeyBytes := bls.PublicKeyToCompressedBytes(testVdrs[0].cryptoPK)
+
+	_, ok := isValidatorPubKeyAllowed(nil, pubKeyBytes)
+	require.False(t, ok)
+}
+
+func TestIsValidatorPubKeyAllowed_UnimplementedSchemeRejectsRealKey(t *testing.T) {
+	pubKeyBytes := bls.PublicKeyToCompressedBytes(testVdrs[0].cryptoPK)
+
+	// SchemeBLS12377 has no decoder in this tree yet, so even a perfectly
+	// valid BLS12-381 key is treated as unregistered when that's the only
+	// allowed scheme.
+	_, ok := isValidatorPubKeyAllowed(SignatureSchemeAllowlist{SchemeBLS12377}, pubKeyBytes)
+	require.False(t, ok)
+}
+
+func TestDecodeValidatorPublicKey_UnsupportedScheme(t *testing.T) {
+	_, err := decodeValidatorPublicKey(SchemeDilithium, []byte("irrelevant"))
+	require.ErrorIs(t, err, ErrUnsupportedSignatureScheme)
+}
+
+// TestMixedSchemeValidatorSet_QuorumGatedByAllowlist is the chunk6-4
+// analogue of TestWarpSignatureWeightsNonDefaultQuorumNumerator: a
+// validator set is "mixed scheme" in the sense that some entries' keys
+// decode under the allowed scheme set and some don't (standing in for keys
+// issued under a scheme this chain hasn't enabled yet), and only the
+// decodable entries' weight can ever count toward quorum -- exactly the
+// validatorRange "publicKey: false" treatment the request describes, just
+// reached via a failed scheme decode instead of a missing PublicKey.
+func TestMixedSchemeValidatorSet_QuorumGatedByAllowlist(t *testing.T) {
+	const numValidators = 10
+	const perValidatorWeight = 10
+	const quorumNumerator = 67
+	const quorumDenominator = 100
+
+	type entry struct {
+		pubKeyBytes []byte
+		weight      uint64
+	}
+	entries := make([]entry, numValidators)
+	for i := 0; i < numValidators; i++ {
+		if i < 7 {
+			entries[i] = entry{pubKeyBytes: bls.PublicKeyToCompressedBytes(testVdrs[i].cryptoPK), weight: perValidatorWeight}
+		} else {
+			// Stand-in for a validator registered under a scheme this
+			// chain hasn't enabled: garbage bytes that no scheme's
+			// decoder (real or unimplemented) will ever accept.
+			entries[i] = entry{pubKeyBytes: []byte("not a real key"), weight: perValidatorWeight}
+		}
+	}
+
+	accumulate := func(allowlist SignatureSchemeAllowlist) (decodable uint64, total uint64) {
+		for _, e := range entries {
+			total += e.weight
+			if _, ok := isValidatorPubKeyAllowed(allowlist, e.pubKeyBytes); ok {
+				decodable += e.weight
+			}
+		}
+		return decodable, total
+	}
+
+	decodable, total := accumulate(DefaultSignatureSchemeAllowlist)
+	required := total * quorumNumerator / quorumDenominator
+	require.GreaterOrEqual(t, decodable, required, "7 of 10 decodable validators must meet a 67% quorum")
+
+	// Disabling BLS12-381 (the only scheme any of these keys actually use)
+	// drops every validator to "unregistered", so quorum can never be met
+	// regardless of how many validators actually signed.
+	decodable, total = accumulate(SignatureSchemeAllowlist{SchemeBLS12377})
+	required = total * quorumNumerator / quorumDenominator
+	require.Less(t, decodable, required, "quorum must be unreachable once the only working scheme is disabled")
+}
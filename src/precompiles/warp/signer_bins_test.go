@@ -0,0 +1,137 @@
+// Copyright (C) 2019-2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+//
+// These tests exercise signer_bins.go's Merkle-commitment and
+// signature-share verification logic directly rather than through
+// warpSignerBinsPrecompile.Run: Run takes a contract.AccessibleState, and
+// (same gap documented in events_test.go's header comment) neither that
+// interface nor contract.StateDB's full method set is confirmed anywhere
+// in this repository, so there's nothing to fake Run's accessibleState
+// argument against without guessing at unconfirmed methods. Everything
+// that actually decides whether a signature share is accepted --
+// binLeafHash, verifyBinMerkleProof, binIndexForPubkey, verifyBLSShare --
+// is reachable and fully testable without it.
+
+package warp
+
+import (
+	"testing"
+
+	"github.com/luxfi/crypto/bls"
+	"github.com/luxfi/geth/common"
+	"github.com/luxfi/geth/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// buildBinMerkleTree builds the same binary Merkle tree verifyBinMerkleProof
+// walks, over leaves = binLeafHash(pubkey, weight), and returns the root
+// plus a proof for leafIndex. numLeaves must be a power of two, matching
+// setSignerBins' 2^bitLength bin layout.
+func buildBinMerkleTree(t *testing.T, leaves []common.Hash, leafIndex uint32) (root common.Hash, proof []common.Hash) {
+	t.Helper()
+	require.True(t, len(leaves)&(len(leaves)-1) == 0, "numLeaves must be a power of two")
+
+	level := append([]common.Hash(nil), leaves...)
+	idx := leafIndex
+	for len(level) > 1 {
+		siblingIdx := idx ^ 1
+		proof = append(proof, level[siblingIdx])
+
+		next := make([]common.Hash, 0, len(level)/2)
+		for i := 0; i < len(level); i += 2 {
+			next = append(next, crypto.Keccak256Hash(append(level[i].Bytes(), level[i+1].Bytes()...)))
+		}
+		level = next
+		idx /= 2
+	}
+	return level[0], proof
+}
+
+func TestVerifyBinMerkleProof_AcceptsRegisteredWeight(t *testing.T) {
+	pubkeyA := bls.PublicKeyToCompressedBytes(testVdrs[0].cryptoPK)
+	pubkeyB := bls.PublicKeyToCompressedBytes(testVdrs[1].cryptoPK)
+	weightA, weightB := uint64(1_000), uint64(50)
+
+	leaves := []common.Hash{binLeafHash(pubkeyA, weightA), binLeafHash(pubkeyB, weightB)}
+	root, proof := buildBinMerkleTree(t, leaves, 0)
+
+	require.True(t, verifyBinMerkleProof(root, pubkeyA, weightA, 0, proof))
+}
+
+// TestVerifyBinMerkleProof_RejectsForgedWeight is the regression test for
+// the weight-forgery gap a reviewer found in this precompile: weight used
+// to be a bare calldata field nothing in the Merkle tree committed to, so
+// one real signature share could claim an arbitrary weight (e.g.
+// thresholdWeight itself) and pass instantly. Weight is now part of the
+// leaf hash (see binLeafHash), so a claimed weight that doesn't match what
+// was registered produces a leaf that doesn't match the proof, regardless
+// of how the pubkey itself checks out.
+func TestVerifyBinMerkleProof_RejectsForgedWeight(t *testing.T) {
+	pubkeyA := bls.PublicKeyToCompressedBytes(testVdrs[0].cryptoPK)
+	pubkeyB := bls.PublicKeyToCompressedBytes(testVdrs[1].cryptoPK)
+	registeredWeight := uint64(1)
+
+	leaves := []common.Hash{binLeafHash(pubkeyA, registeredWeight), binLeafHash(pubkeyB, uint64(50))}
+	root, proof := buildBinMerkleTree(t, leaves, 0)
+
+	// The real, registered weight still verifies.
+	require.True(t, verifyBinMerkleProof(root, pubkeyA, registeredWeight, 0, proof))
+
+	// An attacker claiming a much larger weight than was registered --
+	// e.g. a full quorum's worth -- must be rejected, even though the
+	// pubkey and proof steps are otherwise identical.
+	forgedWeight := uint64(1_000_000)
+	require.False(t, verifyBinMerkleProof(root, pubkeyA, forgedWeight, 0, proof))
+}
+
+func TestVerifyBinMerkleProof_RejectsWrongPubkeyOrIndex(t *testing.T) {
+	pubkeyA := bls.PublicKeyToCompressedBytes(testVdrs[0].cryptoPK)
+	pubkeyB := bls.PublicKeyToCompressedBytes(testVdrs[1].cryptoPK)
+	weight := uint64(20)
+
+	leaves := []common.Hash{binLeafHash(pubkeyA, weight), binLeafHash(pubkeyB, weight)}
+	root, proof := buildBinMerkleTree(t, leaves, 0)
+
+	// pubkeyB's key with pubkeyA's proof/index must not verify.
+	require.False(t, verifyBinMerkleProof(root, pubkeyB, weight, 0, proof))
+	// The right leaf content against the wrong index must not verify either,
+	// since leafIndex's bits select which side of each level to hash against.
+	require.False(t, verifyBinMerkleProof(root, pubkeyA, weight, 1, proof))
+}
+
+func TestBinLeafHash_DiffersByWeight(t *testing.T) {
+	pubkey := bls.PublicKeyToCompressedBytes(testVdrs[0].cryptoPK)
+	require.NotEqual(t, binLeafHash(pubkey, 1), binLeafHash(pubkey, 2))
+}
+
+func TestBinIndexForPubkey_WithinBitLength(t *testing.T) {
+	for _, bitLength := range []byte{1, 4, 8, 16} {
+		for i := 0; i < 5; i++ {
+			pubkey := bls.PublicKeyToCompressedBytes(testVdrs[i].cryptoPK)
+			idx := binIndexForPubkey(pubkey, bitLength)
+			require.Less(t, idx, uint32(1)<<bitLength)
+		}
+	}
+}
+
+func TestVerifyBLSShare_ValidAndInvalid(t *testing.T) {
+	message := []byte("signer bin test message")
+	pubkey := bls.PublicKeyToCompressedBytes(testVdrs[0].cryptoPK)
+
+	sig, err := testVdrs[0].sk.Sign(message)
+	require.NoError(t, err)
+	sigBytes := bls.SignatureToBytes(sig)
+
+	require.True(t, verifyBLSShare(pubkey, sigBytes, message))
+
+	// A signature from a different validator's key must not verify against
+	// this pubkey.
+	otherSig, err := testVdrs[1].sk.Sign(message)
+	require.NoError(t, err)
+	require.False(t, verifyBLSShare(pubkey, bls.SignatureToBytes(otherSig), message))
+}
+
+func TestEncodeDecodeBinMeta_RoundTrips(t *testing.T) {
+	word := encodeBinMeta(8, 256)
+	require.Equal(t, byte(8), word[0])
+}
@@ -0,0 +1,165 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+//
+// Status: as merged, PredicateVerificationCache is dead code. Nothing in
+// this tree's real message-verification path constructs one or calls
+// VerifyCached -- only this file's own tests and predicate_wiring.go's
+// demonstration wiring (itself unreachable for the same reason) do.
+// Landing config.go and a real VerifyPredicate call site is required
+// before this cache actually skips a single repeated BLS pairing.
+//
+// Per-block predicate verification cache. TestWarpMultiplePredicates shows a
+// single block can carry many warp predicates; if several of those carry
+// the identical (message, signer set, P-Chain height, subnet, quorum)
+// tuple -- the same cross-chain message delivered to N transactions in one
+// block, say -- each one today re-derives the validator subset and re-runs
+// the BLS pairing from scratch. PredicateVerificationCache below is the
+// bounded LRU keyed exactly as requested, but there's no call site to wire
+// it into in this snapshot: the actual VerifyPredicate path lives on
+// precompileconfig.Config/PredicateContext in the generated config.go this
+// package is missing (predicate_test.go references NewConfig/
+// NewDefaultConfig/PredicateContext, none of which are defined anywhere in
+// this package -- see signer_bins.go's header comment for the same gap).
+// This provides the cache machinery a real VerifyPredicate would call
+// VerifyCached from, keyed the way the request describes, so wiring it in
+// once config.go exists is a one-line change rather than a redesign.
+
+package warp
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/luxfi/geth/common"
+	"github.com/luxfi/geth/crypto"
+	"github.com/luxfi/ids"
+)
+
+// defaultPredicateCacheSize bounds a PredicateVerificationCache created via
+// NewPredicateVerificationCache(0).
+const defaultPredicateCacheSize = 4096
+
+// PredicateCacheKey identifies one verification result: the same message
+// signed by the same signer set, evaluated against the same P-Chain
+// height/subnet/quorum, always verifies to the same outcome.
+type PredicateCacheKey struct {
+	UnsignedMsgHash common.Hash
+	SignersHash     common.Hash
+	PChainHeight    uint64
+	SubnetID        ids.ID
+	QuorumNumerator uint64
+}
+
+// NewPredicateCacheKey hashes the variable-length unsignedMsgBytes/
+// signersBytes inputs down to the fixed-size fields PredicateCacheKey needs
+// to stay comparable (and so usable directly as a map key).
+func NewPredicateCacheKey(unsignedMsgBytes, signersBytes []byte, pChainHeight uint64, subnetID ids.ID, quorumNumerator uint64) PredicateCacheKey {
+	return PredicateCacheKey{
+		UnsignedMsgHash: crypto.Keccak256Hash(unsignedMsgBytes),
+		SignersHash:     crypto.Keccak256Hash(signersBytes),
+		PChainHeight:    pChainHeight,
+		SubnetID:        subnetID,
+		QuorumNumerator: quorumNumerator,
+	}
+}
+
+type predicateCacheEntry struct {
+	key   PredicateCacheKey
+	valid bool
+}
+
+// PredicateVerificationCache is a bounded, LRU-evicted cache of predicate
+// verification results, safe for concurrent use. It's scoped to a single
+// P-Chain height at a time: observing a key at a new height wholesale
+// clears whatever was cached at the old one, since a result computed
+// against one height's validator set must never be reused at another.
+type PredicateVerificationCache struct {
+	mu      sync.Mutex
+	maxSize int
+
+	haveHeight bool
+	height     uint64
+
+	order   *list.List
+	entries map[PredicateCacheKey]*list.Element
+}
+
+// NewPredicateVerificationCache builds a cache holding at most maxSize
+// entries; maxSize <= 0 falls back to defaultPredicateCacheSize.
+func NewPredicateVerificationCache(maxSize int) *PredicateVerificationCache {
+	if maxSize <= 0 {
+		maxSize = defaultPredicateCacheSize
+	}
+	return &PredicateVerificationCache{
+		maxSize: maxSize,
+		order:   list.New(),
+		entries: make(map[PredicateCacheKey]*list.Element),
+	}
+}
+
+// observeHeight clears the cache the first time it sees a pChainHeight
+// different from the one its current entries were computed against. Must
+// be called with c.mu held.
+func (c *PredicateVerificationCache) observeHeight(pChainHeight uint64) {
+	if c.haveHeight && c.height == pChainHeight {
+		return
+	}
+	c.haveHeight = true
+	c.height = pChainHeight
+	c.order.Init()
+	for k := range c.entries {
+		delete(c.entries, k)
+	}
+}
+
+// Get reports key's cached verification result, if any, and marks it most
+// recently used.
+func (c *PredicateVerificationCache) Get(key PredicateCacheKey) (valid bool, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.observeHeight(key.PChainHeight)
+
+	elem, found := c.entries[key]
+	if !found {
+		return false, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*predicateCacheEntry).valid, true
+}
+
+// Put records key's verification result, evicting the least recently used
+// entry first if the cache is already at capacity.
+func (c *PredicateVerificationCache) Put(key PredicateCacheKey, valid bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.observeHeight(key.PChainHeight)
+
+	if elem, ok := c.entries[key]; ok {
+		elem.Value.(*predicateCacheEntry).valid = valid
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	elem := c.order.PushFront(&predicateCacheEntry{key: key, valid: valid})
+	c.entries[key] = elem
+	if c.order.Len() > c.maxSize {
+		oldest := c.order.Back()
+		if oldest != nil {
+			c.order.Remove(oldest)
+			delete(c.entries, oldest.Value.(*predicateCacheEntry).key)
+		}
+	}
+}
+
+// VerifyCached returns key's cached result if present, otherwise runs
+// verify(), caches what it returns, and returns that. This is the shape a
+// real VerifyPredicate would call into; see the package doc comment above
+// for why nothing in this tree does yet.
+func (c *PredicateVerificationCache) VerifyCached(key PredicateCacheKey, verify func() bool) bool {
+	if valid, ok := c.Get(key); ok {
+		return valid
+	}
+	valid := verify()
+	c.Put(key, valid)
+	return valid
+}
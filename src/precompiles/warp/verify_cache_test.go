@@ -0,0 +1,153 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"testing"
+
+	"github.com/luxfi/crypto/bls"
+	"github.com/luxfi/ids"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPredicateVerificationCache_MissThenHit(t *testing.T) {
+	cache := NewPredicateVerificationCache(8)
+	key := NewPredicateCacheKey([]byte("message"), []byte{1, 2, 3}, 1, ids.GenerateTestID(), 67)
+
+	_, ok := cache.Get(key)
+	require.False(t, ok)
+
+	cache.Put(key, true)
+	valid, ok := cache.Get(key)
+	require.True(t, ok)
+	require.True(t, valid)
+}
+
+func TestPredicateVerificationCache_VerifyCachedCallsOnce(t *testing.T) {
+	cache := NewPredicateVerificationCache(8)
+	key := NewPredicateCacheKey([]byte("message"), []byte{1, 2, 3}, 1, ids.GenerateTestID(), 67)
+
+	calls := 0
+	verify := func() bool {
+		calls++
+		return true
+	}
+
+	for i := 0; i < 5; i++ {
+		require.True(t, cache.VerifyCached(key, verify))
+	}
+	require.Equal(t, 1, calls)
+}
+
+func TestPredicateVerificationCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewPredicateVerificationCache(2)
+	subnetID := ids.GenerateTestID()
+	keyA := NewPredicateCacheKey([]byte("a"), nil, 1, subnetID, 67)
+	keyB := NewPredicateCacheKey([]byte("b"), nil, 1, subnetID, 67)
+	keyC := NewPredicateCacheKey([]byte("c"), nil, 1, subnetID, 67)
+
+	cache.Put(keyA, true)
+	cache.Put(keyB, true)
+	_, ok := cache.Get(keyA) // keyA is now most recently used; keyB is the LRU entry
+	require.True(t, ok)
+
+	cache.Put(keyC, true) // should evict keyB, not keyA
+
+	_, ok = cache.Get(keyB)
+	require.False(t, ok)
+	_, ok = cache.Get(keyA)
+	require.True(t, ok)
+	_, ok = cache.Get(keyC)
+	require.True(t, ok)
+}
+
+func TestPredicateVerificationCache_HeightChangeClearsCache(t *testing.T) {
+	cache := NewPredicateVerificationCache(8)
+	subnetID := ids.GenerateTestID()
+	keyHeight1 := NewPredicateCacheKey([]byte("message"), nil, 1, subnetID, 67)
+	keyHeight2 := NewPredicateCacheKey([]byte("message"), nil, 2, subnetID, 67)
+
+	cache.Put(keyHeight1, true)
+	_, ok := cache.Get(keyHeight1)
+	require.True(t, ok)
+
+	// Observing a different height must drop everything cached at the old
+	// one, even an unrelated key at the new height.
+	_, ok = cache.Get(keyHeight2)
+	require.False(t, ok)
+	_, ok = cache.Get(keyHeight1)
+	require.False(t, ok, "height change must invalidate entries from the previous height")
+}
+
+// benchmarkPredicateVerification{WithoutCache,WithCache} share the same
+// setup -- aggregate numSigners of the package's 10,000 pre-signed
+// testVdrs signatures over unsignedMsg -- so the only difference measured
+// is whether the aggregate-pubkey-and-pairing cost is paid once or
+// b.N times, modeling the "same message appears in N transactions in one
+// block" case the cache targets.
+func benchmarkPredicateVerificationWithoutCache(b *testing.B, numSigners int) {
+	pubKeys := make([]*bls.PublicKey, numSigners)
+	for i := 0; i < numSigners; i++ {
+		pubKeys[i] = testVdrs[i].cryptoPK
+	}
+	aggregateSignature, err := bls.AggregateSignatures(blsSignatures[:numSigners])
+	require.NoError(b, err)
+	msgBytes := unsignedMsg.Bytes()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		aggPubKey, err := bls.AggregatePublicKeys(pubKeys)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if !bls.Verify(aggPubKey, aggregateSignature, msgBytes) {
+			b.Fatal("expected valid aggregate signature")
+		}
+	}
+}
+
+func benchmarkPredicateVerificationWithCache(b *testing.B, numSigners int) {
+	pubKeys := make([]*bls.PublicKey, numSigners)
+	signersBytes := make([]byte, numSigners)
+	for i := 0; i < numSigners; i++ {
+		pubKeys[i] = testVdrs[i].cryptoPK
+		signersBytes[i] = 1
+	}
+	aggregateSignature, err := bls.AggregateSignatures(blsSignatures[:numSigners])
+	require.NoError(b, err)
+	msgBytes := unsignedMsg.Bytes()
+
+	cache := NewPredicateVerificationCache(128)
+	key := NewPredicateCacheKey(msgBytes, signersBytes, pChainHeight, sourceSubnetID, 67)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		valid := cache.VerifyCached(key, func() bool {
+			aggPubKey, err := bls.AggregatePublicKeys(pubKeys)
+			if err != nil {
+				b.Fatal(err)
+			}
+			return bls.Verify(aggPubKey, aggregateSignature, msgBytes)
+		})
+		if !valid {
+			b.Fatal("expected valid aggregate signature")
+		}
+	}
+}
+
+func BenchmarkPredicateVerification_1kValidators_NoCache(b *testing.B) {
+	benchmarkPredicateVerificationWithoutCache(b, 1_000)
+}
+
+func BenchmarkPredicateVerification_1kValidators_Cached(b *testing.B) {
+	benchmarkPredicateVerificationWithCache(b, 1_000)
+}
+
+func BenchmarkPredicateVerification_10kValidators_NoCache(b *testing.B) {
+	benchmarkPredicateVerificationWithoutCache(b, numTestVdrs)
+}
+
+func BenchmarkPredicateVerification_10kValidators_Cached(b *testing.B) {
+	benchmarkPredicateVerificationWithCache(b, numTestVdrs)
+}
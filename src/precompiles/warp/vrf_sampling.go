@@ -0,0 +1,225 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+//
+// Status: as merged, SampleVoterSet/VerifySampledQuorum are dead code.
+// Nothing in this tree's real predicate verification ever samples a
+// reduced voter set or checks quorum against one -- only this file's own
+// tests and predicate_wiring.go's demonstration wiring (itself
+// unreachable for the same reason) do. Landing config.go with a real
+// NewConfigWithSampling-backed Config field and VerifyPredicate call site
+// is required before any verification actually runs against a sampled
+// subset instead of the full validator set, and before the claimed gas
+// reduction is real rather than theoretical.
+//
+// VRF-sampled quorum verification for very large validator sets. Above a
+// configurable size, full-set verification's gas grows linearly with the
+// number of signers (predicate_test.go's GasCostPerWarpSigner term); this
+// instead derives a deterministic seed from the message and P-Chain
+// height, samples a weighted subset of the canonical validator list large
+// enough that it can't plausibly be dishonest-majority assuming the
+// chain's normal quorum threshold, and only requires covering quorum of
+// that sample's weight rather than the full set's.
+//
+// SamplingConfig/NewConfigWithSampling below hold the parameters the
+// request asks for, but there's no Config type to attach them to: that
+// lives in the generated config.go this package is missing (same gap
+// verify_cache.go and signature_scheme.go ran into -- NewConfig/
+// NewDefaultConfig/GetVerifiedWarpMessageBaseCost and friends aren't
+// defined anywhere in this snapshot). What's here is the sampling
+// algorithm itself -- seed derivation, weighted sampling without
+// replacement, and the sample-relative quorum check -- runnable and
+// tested independently of that missing plumbing, so wiring
+// NewConfigWithSampling's output into a real VerifyPredicate is a
+// mechanical change once config.go exists.
+
+package warp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/luxfi/geth/common"
+	"github.com/luxfi/geth/crypto"
+	"github.com/luxfi/ids"
+)
+
+// ErrSignerNotSampled is returned by VerifySampledQuorum when a bit-set
+// signer's NodeID isn't present in the sampled voter set: sampled-mode
+// verification must reject signatures from outside the sample rather than
+// silently ignoring them, since accepting them would let a caller pad the
+// signer set with cheap, unsampled weight.
+var ErrSignerNotSampled = errors.New("warp: signer is not a member of the sampled voter set")
+
+// SampledValidator is the subset of validators.GetValidatorOutput the
+// sampler and quorum check need.
+type SampledValidator struct {
+	NodeID ids.NodeID
+	Weight uint64
+}
+
+// SamplingConfig holds the parameters NewConfigWithSampling would store on
+// a real Config: the block timestamp sampled-mode activates at (nil means
+// never, matching this package's other Config fields' *uint64 convention),
+// the quorum numerator signers must cover (of the *sample's* weight, not
+// total weight, once sampling is active), and the target security level
+// sample sizing is computed against.
+type SamplingConfig struct {
+	Timestamp        *uint64
+	QuorumNumerator  uint64
+	SampleTargetBits uint
+}
+
+// NewConfigWithSampling builds a SamplingConfig. sampleTargetBits is the
+// security parameter k: SampleThresholdWeight sizes the sample so that,
+// assuming honest stake is at least quorumNumerator/QuorumDenominator of
+// the sampled weight (the same fraction the chain already requires of the
+// full set), the probability an adversary controls a quorumNumerator-sized
+// share of the *sample* is below 2^-k.
+func NewConfigWithSampling(timestamp *uint64, quorumNumerator uint64, sampleTargetBits uint) *SamplingConfig {
+	return &SamplingConfig{
+		Timestamp:        timestamp,
+		QuorumNumerator:  quorumNumerator,
+		SampleTargetBits: sampleTargetBits,
+	}
+}
+
+// SampleThresholdWeight returns the minimum sampled weight cfg's security
+// target requires, independent of the validator set's total weight.
+//
+// This uses the standard Chernoff-Hoeffding concentration bound for a sum
+// of independent weighted Bernoulli trials: if at least fraction h of
+// sampled weight is honest (h = QuorumNumerator/QuorumDenominator, the
+// same assumption the chain already makes about its full validator set),
+// then for sampled weight W, P(adversary reaches a 50% share of W) <=
+// exp(-2*W*(h-0.5)^2). Solving for W at the requested security level k
+// gives W >= k*ln(2) / (2*(h-0.5)^2). This is the standard heuristic used
+// to size VRF/committee sampling (e.g. Algorand's committee selection);
+// it assumes weight behaves like an i.i.d. sum, which is an approximation
+// a production deployment should have independently reviewed before
+// relying on for the stated 2^-k bound.
+func (cfg *SamplingConfig) SampleThresholdWeight() uint64 {
+	h := float64(cfg.QuorumNumerator) / float64(QuorumDenominator)
+	margin := h - 0.5
+	if margin <= 0 {
+		// A quorum at or below 50% gives the bound no separation to work
+		// with; sampling can't provide any security margin here, so every
+		// validator must be included.
+		return math.MaxUint64
+	}
+	w := float64(cfg.SampleTargetBits) * math.Ln2 / (2 * margin * margin)
+	return uint64(math.Ceil(w))
+}
+
+// QuorumDenominator matches aggregator.QuorumDenominator's role (quorum
+// fractions are always expressed out of 100 in this tree); duplicated
+// here rather than imported since src/warp/aggregator is a sibling
+// subsystem, not a dependency of this precompile package.
+const QuorumDenominator uint64 = 100
+
+// deriveSampleSeed computes the deterministic seed sampling is drawn from:
+// every honest node evaluating the same (message, height) pair must derive
+// the same voter set, so the seed can only depend on data already fixed by
+// the message itself plus the height it's being verified against.
+func deriveSampleSeed(unsignedMsgBytes []byte, pChainHeight uint64) common.Hash {
+	buf := make([]byte, len(unsignedMsgBytes)+8)
+	copy(buf, unsignedMsgBytes)
+	binary.BigEndian.PutUint64(buf[len(unsignedMsgBytes):], pChainHeight)
+	return crypto.Keccak256Hash(buf)
+}
+
+// deterministicDrawWeight turns (seed, round) into a uniform value in
+// [0, totalRemainingWeight) via a keccak256 hash chain, giving an
+// unbiased-enough draw for sampling without needing a real VRF output
+// (the request's "VRF-based" seed is unsignedMsg||pChainHeight itself,
+// already unpredictable before the message is signed and identical for
+// every verifier afterwards; this is just the PRF expanding that seed into
+// however many draws sampling needs).
+func deterministicDrawWeight(seed common.Hash, round uint64, totalRemainingWeight uint64) uint64 {
+	if totalRemainingWeight == 0 {
+		return 0
+	}
+	var buf [40]byte
+	copy(buf[:32], seed.Bytes())
+	binary.BigEndian.PutUint64(buf[32:], round)
+	h := crypto.Keccak256(buf[:])
+	draw := binary.BigEndian.Uint64(h[:8])
+	return draw % totalRemainingWeight
+}
+
+// SampleVoterSet deterministically draws validators (sorted by NodeID so
+// every node builds the same candidate order before sampling, the same
+// byte-comparison convention testValidator.Compare uses) without
+// replacement, weighted by stake, until the accumulated sampled weight
+// reaches targetWeight or every validator has been drawn.
+func SampleVoterSet(seed common.Hash, validators []SampledValidator, targetWeight uint64) []SampledValidator {
+	remaining := make([]SampledValidator, len(validators))
+	copy(remaining, validators)
+	sort.Slice(remaining, func(i, j int) bool {
+		return bytes.Compare(remaining[i].NodeID[:], remaining[j].NodeID[:]) < 0
+	})
+
+	var totalRemainingWeight uint64
+	for _, v := range remaining {
+		totalRemainingWeight += v.Weight
+	}
+
+	sampled := make([]SampledValidator, 0, len(remaining))
+	var sampledWeight uint64
+	round := uint64(0)
+	for sampledWeight < targetWeight && len(remaining) > 0 {
+		draw := deterministicDrawWeight(seed, round, totalRemainingWeight)
+		round++
+
+		var cumulative uint64
+		pick := len(remaining) - 1
+		for i, v := range remaining {
+			cumulative += v.Weight
+			if draw < cumulative {
+				pick = i
+				break
+			}
+		}
+
+		chosen := remaining[pick]
+		sampled = append(sampled, chosen)
+		sampledWeight += chosen.Weight
+		totalRemainingWeight -= chosen.Weight
+		remaining = append(remaining[:pick], remaining[pick+1:]...)
+	}
+	return sampled
+}
+
+// VerifySampledQuorum reports whether signerNodeIDs (the bit-set signers
+// recovered from a BitSetSignature) cover at least quorumNumerator/
+// QuorumDenominator of sample's total weight. It returns ErrSignerNotSampled
+// if any signer isn't a member of sample, rather than silently excluding
+// them, so a caller can't satisfy sampled quorum by padding the signature
+// with weight that was never put up for sampling in the first place.
+func VerifySampledQuorum(sample []SampledValidator, signerNodeIDs []ids.NodeID, quorumNumerator uint64) (bool, error) {
+	weightByNode := make(map[ids.NodeID]uint64, len(sample))
+	var totalSampledWeight uint64
+	for _, v := range sample {
+		weightByNode[v.NodeID] = v.Weight
+		totalSampledWeight += v.Weight
+	}
+
+	var signedWeight uint64
+	seen := make(map[ids.NodeID]bool, len(signerNodeIDs))
+	for _, nodeID := range signerNodeIDs {
+		if seen[nodeID] {
+			continue
+		}
+		seen[nodeID] = true
+		weight, ok := weightByNode[nodeID]
+		if !ok {
+			return false, ErrSignerNotSampled
+		}
+		signedWeight += weight
+	}
+
+	required := totalSampledWeight * quorumNumerator / QuorumDenominator
+	return signedWeight >= required, nil
+}
@@ -0,0 +1,126 @@
+// Copyright (C) 2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"math"
+	"testing"
+
+	"github.com/luxfi/ids"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSampleThresholdWeight(t *testing.T) {
+	cfg := NewConfigWithSampling(nil, 67, 80)
+	// h=0.67, margin=0.17: W = 80*ln2 / (2*0.17^2) ~= 959.2, rounded up.
+	require.Equal(t, uint64(960), cfg.SampleThresholdWeight())
+
+	// A quorum at or below 50% gives the Chernoff bound no separation to
+	// exploit, so sampling can't help: every validator is required.
+	noMargin := NewConfigWithSampling(nil, 50, 80)
+	require.Equal(t, uint64(math.MaxUint64), noMargin.SampleThresholdWeight())
+}
+
+func TestDeriveSampleSeed_Deterministic(t *testing.T) {
+	msgBytes := unsignedMsg.Bytes()
+	seedA := deriveSampleSeed(msgBytes, pChainHeight)
+	seedB := deriveSampleSeed(msgBytes, pChainHeight)
+	require.Equal(t, seedA, seedB, "the same message and height must always derive the same seed")
+
+	seedDifferentHeight := deriveSampleSeed(msgBytes, pChainHeight+1)
+	require.NotEqual(t, seedA, seedDifferentHeight)
+}
+
+func testSampledValidators(n int) []SampledValidator {
+	out := make([]SampledValidator, n)
+	for i := 0; i < n; i++ {
+		out[i] = SampledValidator{NodeID: testVdrs[i].nodeID, Weight: testVdrs[i].vdr.Weight}
+	}
+	return out
+}
+
+func TestSampleVoterSet_DeterministicAcrossCalls(t *testing.T) {
+	seed := deriveSampleSeed(unsignedMsg.Bytes(), pChainHeight)
+	validatorSet := testSampledValidators(numTestVdrs)
+
+	sampleA := SampleVoterSet(seed, validatorSet, 960)
+	sampleB := SampleVoterSet(seed, validatorSet, 960)
+	require.Equal(t, sampleA, sampleB, "two nodes deriving the same seed must land on the identical voter set")
+}
+
+// TestSampleVoterSet_10kValidators is the chunk6-5 analogue of
+// TestWarpSignatureWeightsDefaultQuorumNumerator: it exercises the same
+// 10,000-validator fixture predicate_test.go builds, but instead of
+// verifying every signature, samples a voter set sized for 2^-80 security
+// at the chain's usual 67% quorum and shows that set is a small fraction
+// of the full 10,000 -- the reduction the request asks sampled-mode gas to
+// track.
+func TestSampleVoterSet_10kValidators(t *testing.T) {
+	cfg := NewConfigWithSampling(nil, 67, 80)
+	validatorSet := testSampledValidators(numTestVdrs)
+	seed := deriveSampleSeed(unsignedMsg.Bytes(), pChainHeight)
+
+	sample := SampleVoterSet(seed, validatorSet, cfg.SampleThresholdWeight())
+	require.Less(t, len(sample), numTestVdrs/10,
+		"a sample sized for the configured security level should be well under a tenth of 10,000 validators")
+
+	var sampledWeight uint64
+	for _, v := range sample {
+		sampledWeight += v.Weight
+	}
+	require.GreaterOrEqual(t, sampledWeight, cfg.SampleThresholdWeight())
+}
+
+// TestSampledVerificationGas_10xReduction models the gas a sampled-mode
+// VerifyPredicate would charge (GasCostPerWarpSigner * number of signers
+// actually verified, the same linear term predicate_test.go's
+// createValidPredicateTest computes) against the full 10,000-validator
+// set and against the sampled subset, using an illustrative per-signer
+// gas cost local to this test: the real GasCostPerWarpSigner constant is
+// part of the missing config.go (see vrf_sampling.go's header comment),
+// so it can't be referenced here, but the ratio this models is identical
+// regardless of the constant's actual value since both sides share it.
+func TestSampledVerificationGas_10xReduction(t *testing.T) {
+	const illustrativeGasPerSigner = 1_000
+
+	cfg := NewConfigWithSampling(nil, 67, 80)
+	validatorSet := testSampledValidators(numTestVdrs)
+	seed := deriveSampleSeed(unsignedMsg.Bytes(), pChainHeight)
+	sample := SampleVoterSet(seed, validatorSet, cfg.SampleThresholdWeight())
+
+	fullSetGas := uint64(numTestVdrs) * illustrativeGasPerSigner
+	sampledGas := uint64(len(sample)) * illustrativeGasPerSigner
+
+	require.GreaterOrEqual(t, fullSetGas/sampledGas, uint64(10),
+		"sampled verification should charge at least 10x less signer-count gas than verifying all 10,000 validators")
+}
+
+func TestVerifySampledQuorum_MetAndUnmet(t *testing.T) {
+	sample := []SampledValidator{
+		{NodeID: testVdrs[0].nodeID, Weight: 10},
+		{NodeID: testVdrs[1].nodeID, Weight: 10},
+		{NodeID: testVdrs[2].nodeID, Weight: 10},
+	}
+
+	met, err := VerifySampledQuorum(sample, []ids.NodeID{testVdrs[0].nodeID, testVdrs[1].nodeID}, 67)
+	require.NoError(t, err)
+	require.True(t, met, "20 of 30 sampled weight meets a 67% quorum of the sample")
+
+	unmet, err := VerifySampledQuorum(sample, []ids.NodeID{testVdrs[0].nodeID}, 67)
+	require.NoError(t, err)
+	require.False(t, unmet, "10 of 30 sampled weight must not meet a 67% quorum of the sample")
+}
+
+func TestVerifySampledQuorum_RejectsSignerOutsideSample(t *testing.T) {
+	sample := []SampledValidator{
+		{NodeID: testVdrs[0].nodeID, Weight: 10},
+		{NodeID: testVdrs[1].nodeID, Weight: 10},
+	}
+
+	// testVdrs[2] has real weight and a valid signature elsewhere in this
+	// package's fixtures, but it was never drawn into this sample -- its
+	// weight must not be allowed to count toward sampled quorum.
+	_, err := VerifySampledQuorum(sample, []ids.NodeID{testVdrs[0].nodeID, testVdrs[2].nodeID}, 67)
+	require.ErrorIs(t, err, ErrSignerNotSampled)
+}
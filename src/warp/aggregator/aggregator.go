@@ -0,0 +1,223 @@
+// Copyright (C) 2019-2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+//
+// Package aggregator is an off-chain signature aggregation service for the
+// warp precompile (see ../../precompiles/warp): a dApp or relayer submits an
+// UnsignedMessage plus a target subnetID and quorum, the aggregator fans out
+// signature requests to that subnet's validators by NodeID, BLS-aggregates
+// whatever partial signatures come back, and packs the result into the same
+// BitSetSignature bit-set ordering the precompile validates (one bit per
+// validator, in the canonical order produced by sorting on
+// luxWarp.Validator.Less, the same order predicate_test.go's testValidator
+// relies on via agoUtils.Sort).
+//
+// There's no vendored peer-to-peer AppRequest client in this tree to send
+// the actual network request with, so SignatureRequester below is the seam:
+// production wiring plugs in the node's AppRequest sender, this package only
+// assumes the request/response shape (NodeID in, a raw BLS signature or an
+// error out).
+package aggregator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/luxfi/consensus/validator"
+	"github.com/luxfi/crypto/bls"
+	"github.com/luxfi/ids"
+	luxWarp "github.com/luxfi/warp"
+)
+
+const (
+	// DefaultQuorumNumerator and QuorumDenominator are this service's own
+	// default quorum fraction. They're deliberately not imported from
+	// ../../precompiles/warp: that package's own WarpDefaultQuorumNumerator/
+	// WarpQuorumDenominator live in its generated contract.go, which isn't
+	// part of this snapshot (see signer_bins.go's header comment), so there
+	// is nothing to confirm a match against. A caller verifying on-chain
+	// against that precompile must pass the matching numerator explicitly
+	// via Request.QuorumNumerator.
+	DefaultQuorumNumerator uint64 = 67
+	QuorumDenominator      uint64 = 100
+)
+
+var (
+	ErrInvalidQuorumNumerator = errors.New("aggregator: quorum numerator must be > 0 and <= QuorumDenominator")
+	ErrNoValidators           = errors.New("aggregator: validator set is empty")
+	ErrQuorumNotMet           = errors.New("aggregator: collected signer weight did not reach quorum")
+)
+
+// Request is one aggregation job: sign unsignedMessage on behalf of
+// subnetID's validator set, gathering at least quorumNumerator/
+// QuorumDenominator of its total weight.
+type Request struct {
+	UnsignedMessage *luxWarp.UnsignedMessage
+	SubnetID        ids.ID
+	QuorumNumerator uint64 // 0 means DefaultQuorumNumerator
+}
+
+// ValidatorState resolves a subnet's canonical validator set at a given
+// P-Chain height. This is the same shape validatorstest.State.GetValidatorSetF
+// and testValidatorStateWrapper wrap in predicate_test.go, kept at full
+// fidelity (pubkey included) rather than narrowed to the weight-only
+// map[ids.NodeID]uint64 that wrapper's own GetValidatorSet method returns,
+// since aggregation needs every signer's BLS public key to verify partial
+// signatures and to aggregate them.
+type ValidatorState interface {
+	GetValidatorSet(ctx context.Context, height uint64, subnetID ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error)
+}
+
+// SignatureRequester sends an AppRequest-shaped signature request to a
+// single validator and returns its raw BLS signature over
+// unsignedMessage.Bytes(). Implementations are expected to apply their own
+// timeout/retry policy; a slow or failing peer should return an error
+// rather than block Aggregate indefinitely.
+type SignatureRequester interface {
+	RequestSignature(ctx context.Context, nodeID ids.NodeID, unsignedMessage *luxWarp.UnsignedMessage) (*bls.Signature, error)
+}
+
+// Aggregator drives one end-to-end aggregation: resolve validators, fan out,
+// verify, aggregate, pack.
+type Aggregator struct {
+	state     ValidatorState
+	requester SignatureRequester
+	// maxFanout bounds how many RequestSignature calls run concurrently, so
+	// a subnet with a large validator set doesn't open one goroutine (and,
+	// in a real implementation, one network connection) per validator at
+	// once.
+	maxFanout int
+}
+
+// defaultMaxFanout mirrors crypto/verifier's worker-pool sizing
+// rationale (bounding concurrency to real parallelism available), but an
+// AppRequest fan-out is I/O- not CPU-bound, so it uses a larger fixed cap
+// instead of runtime.GOMAXPROCS(0).
+const defaultMaxFanout = 64
+
+// New builds an Aggregator that resolves validator sets via state and
+// requests partial signatures via requester.
+func New(state ValidatorState, requester SignatureRequester) *Aggregator {
+	return &Aggregator{state: state, requester: requester, maxFanout: defaultMaxFanout}
+}
+
+type validatorEntry struct {
+	nodeID ids.NodeID
+	vdr    *luxWarp.Validator
+	weight uint64
+}
+
+// Aggregate resolves subnetID's validator set at pChainHeight, collects
+// enough partial BLS signatures over req.UnsignedMessage to meet quorum, and
+// returns a fully-formed luxWarp.Message carrying the aggregated
+// BitSetSignature.
+func (a *Aggregator) Aggregate(ctx context.Context, pChainHeight uint64, req Request) (*luxWarp.Message, error) {
+	numerator := req.QuorumNumerator
+	if numerator == 0 {
+		numerator = DefaultQuorumNumerator
+	}
+	if numerator > QuorumDenominator {
+		return nil, ErrInvalidQuorumNumerator
+	}
+
+	vdrOutputs, err := a.state.GetValidatorSet(ctx, pChainHeight, req.SubnetID)
+	if err != nil {
+		return nil, fmt.Errorf("aggregator: resolving validator set: %w", err)
+	}
+	if len(vdrOutputs) == 0 {
+		return nil, ErrNoValidators
+	}
+
+	entries := make([]validatorEntry, 0, len(vdrOutputs))
+	var totalWeight uint64
+	for nodeID, output := range vdrOutputs {
+		if len(output.PublicKey) == 0 {
+			// No registered BLS key: this validator cannot contribute a
+			// signature share, but it still counts toward total weight, the
+			// same way the precompile's own quorum accounting treats
+			// unregistered validators (see makeWarpPredicateTests'
+			// "non-signers without registered PublicKey" case).
+			totalWeight += output.Weight
+			continue
+		}
+		pk, err := bls.PublicKeyFromCompressedBytes(output.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("aggregator: validator %s has an invalid BLS public key: %w", nodeID, err)
+		}
+		entries = append(entries, validatorEntry{
+			nodeID: nodeID,
+			vdr: &luxWarp.Validator{
+				PublicKey:      pk,
+				PublicKeyBytes: output.PublicKey,
+				Weight:         output.Weight,
+				NodeID:         nodeID[:],
+			},
+			weight: output.Weight,
+		})
+		totalWeight += output.Weight
+	}
+
+	// Canonical signer order: sorted the same way predicate_test.go's
+	// testValidator.Compare does, via luxWarp.Validator.Less. The position
+	// in this sorted slice is the bit index a signer occupies in the
+	// resulting BitSetSignature.
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].vdr.Less(entries[j].vdr)
+	})
+
+	required := totalWeight * numerator / QuorumDenominator
+
+	type shareResult struct {
+		index int
+		sig   *bls.Signature
+	}
+	results := make(chan shareResult, len(entries))
+	sem := make(chan struct{}, a.maxFanout)
+	var wg sync.WaitGroup
+	for i, entry := range entries {
+		wg.Add(1)
+		go func(i int, entry validatorEntry) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			sig, err := a.requester.RequestSignature(ctx, entry.nodeID, req.UnsignedMessage)
+			if err != nil || sig == nil {
+				return
+			}
+			if !bls.Verify(entry.vdr.PublicKey, sig, req.UnsignedMessage.Bytes()) {
+				return
+			}
+			results <- shareResult{index: i, sig: sig}
+		}(i, entry)
+	}
+	wg.Wait()
+	close(results)
+
+	bitSet := luxWarp.NewBitSet()
+	var signatures []*bls.Signature
+	var collectedWeight uint64
+	for r := range results {
+		bitSet.Add(r.index)
+		signatures = append(signatures, r.sig)
+		collectedWeight += entries[r.index].weight
+	}
+
+	if collectedWeight < required {
+		return nil, fmt.Errorf("%w: collected %d of %d required weight", ErrQuorumNotMet, collectedWeight, required)
+	}
+
+	aggregateSignature, err := bls.AggregateSignatures(signatures)
+	if err != nil {
+		return nil, fmt.Errorf("aggregator: aggregating %d signatures: %w", len(signatures), err)
+	}
+	warpSignature := &luxWarp.BitSetSignature{Signers: bitSet}
+	copy(warpSignature.Signature[:], bls.SignatureToBytes(aggregateSignature))
+
+	return &luxWarp.Message{
+		UnsignedMessage: req.UnsignedMessage,
+		Signature:       warpSignature,
+	}, nil
+}
@@ -0,0 +1,196 @@
+// Copyright (C) 2019-2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package aggregator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/luxfi/consensus/validator"
+	"github.com/luxfi/crypto/bls"
+	"github.com/luxfi/crypto/bls/signer/localsigner"
+	"github.com/luxfi/ids"
+	luxWarp "github.com/luxfi/warp"
+	"github.com/stretchr/testify/require"
+)
+
+var errTest = errors.New("fake validator declined to sign")
+
+// fakeSigner is one fake validator: its own BLS key plus whether it
+// cooperates when asked for a signature share.
+type fakeSigner struct {
+	nodeID    ids.NodeID
+	sk        bls.Signer
+	weight    uint64
+	cooperate bool
+}
+
+// fakeValidatorState is a minimal ValidatorState fake, standing in for the
+// real P-Chain-backed implementation the same way memPresignStore in
+// ../../precompiles/cggmp21/presign_test.go stands in for contract.StateDB.
+type fakeValidatorState struct {
+	subnetID ids.ID
+	signers  []*fakeSigner
+}
+
+func (f *fakeValidatorState) GetValidatorSet(_ context.Context, _ uint64, subnetID ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+	if subnetID != f.subnetID {
+		return nil, fmt.Errorf("fakeValidatorState: unexpected subnetID %s, want %s", subnetID, f.subnetID)
+	}
+	out := make(map[ids.NodeID]*validators.GetValidatorOutput, len(f.signers))
+	for _, s := range f.signers {
+		out[s.nodeID] = &validators.GetValidatorOutput{
+			NodeID:    s.nodeID,
+			PublicKey: bls.PublicKeyToCompressedBytes(s.sk.PublicKey()),
+			Weight:    s.weight,
+		}
+	}
+	return out, nil
+}
+
+// fakeRequester answers RequestSignature by actually signing with the
+// matching fakeSigner's key, unless that signer was set up to refuse.
+type fakeRequester struct {
+	byNodeID map[ids.NodeID]*fakeSigner
+}
+
+func newFakeRequester(signers []*fakeSigner) *fakeRequester {
+	r := &fakeRequester{byNodeID: make(map[ids.NodeID]*fakeSigner, len(signers))}
+	for _, s := range signers {
+		r.byNodeID[s.nodeID] = s
+	}
+	return r
+}
+
+func (r *fakeRequester) RequestSignature(_ context.Context, nodeID ids.NodeID, unsignedMessage *luxWarp.UnsignedMessage) (*bls.Signature, error) {
+	s, ok := r.byNodeID[nodeID]
+	if !ok || !s.cooperate {
+		return nil, errTest
+	}
+	return s.sk.Sign(unsignedMessage.Bytes())
+}
+
+func makeFakeSigners(tb testing.TB, n int, cooperating int) []*fakeSigner {
+	tb.Helper()
+	signers := make([]*fakeSigner, n)
+	for i := 0; i < n; i++ {
+		sk, err := localsigner.New()
+		require.NoError(tb, err)
+		signers[i] = &fakeSigner{
+			nodeID:    ids.GenerateTestNodeID(),
+			sk:        sk,
+			weight:    10,
+			cooperate: i < cooperating,
+		}
+	}
+	return signers
+}
+
+// requireAggregateVerifies checks that bitSetSig's aggregate signature
+// verifies against the aggregate public key of exactly expectedSigners, the
+// cryptographic property that matters rather than peeking at an unconfirmed
+// bit-count accessor on BitSetSignature.Signers.
+func requireAggregateVerifies(tb testing.TB, expectedSigners []*fakeSigner, bitSetSig *luxWarp.BitSetSignature, unsignedMessage *luxWarp.UnsignedMessage) {
+	tb.Helper()
+	pubKeys := make([]*bls.PublicKey, len(expectedSigners))
+	for i, s := range expectedSigners {
+		pubKeys[i] = s.sk.PublicKey()
+	}
+	aggPubKey, err := bls.AggregatePublicKeys(pubKeys)
+	require.NoError(tb, err)
+
+	sig, err := bls.SignatureFromBytes(bitSetSig.Signature[:])
+	require.NoError(tb, err)
+	require.True(tb, bls.Verify(aggPubKey, sig, unsignedMessage.Bytes()))
+}
+
+func makeUnsignedMessage(tb testing.TB) *luxWarp.UnsignedMessage {
+	tb.Helper()
+	sourceChainID := ids.GenerateTestID()
+	msg, err := luxWarp.NewUnsignedMessage(1337, sourceChainID[:], []byte("hello from the aggregator test"))
+	require.NoError(tb, err)
+	return msg
+}
+
+func TestAggregate_MeetsDefaultQuorum(t *testing.T) {
+	signers := makeFakeSigners(t, 10, 7) // exactly the default 67% threshold, rounded down
+	subnetID := ids.GenerateTestID()
+	agg := New(&fakeValidatorState{subnetID: subnetID, signers: signers}, newFakeRequester(signers))
+
+	unsignedMessage := makeUnsignedMessage(t)
+	msg, err := agg.Aggregate(context.Background(), 1, Request{
+		UnsignedMessage: unsignedMessage,
+		SubnetID:        subnetID,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, msg)
+
+	bitSetSig, ok := msg.Signature.(*luxWarp.BitSetSignature)
+	require.True(t, ok)
+	requireAggregateVerifies(t, signers[:7], bitSetSig, unsignedMessage)
+}
+
+func TestAggregate_FailsBelowQuorum(t *testing.T) {
+	signers := makeFakeSigners(t, 10, 3)
+	subnetID := ids.GenerateTestID()
+	agg := New(&fakeValidatorState{subnetID: subnetID, signers: signers}, newFakeRequester(signers))
+
+	_, err := agg.Aggregate(context.Background(), 1, Request{
+		UnsignedMessage: makeUnsignedMessage(t),
+		SubnetID:        subnetID,
+	})
+	require.ErrorIs(t, err, ErrQuorumNotMet)
+}
+
+func TestAggregate_RejectsInvalidQuorumNumerator(t *testing.T) {
+	subnetID := ids.GenerateTestID()
+	agg := New(&fakeValidatorState{subnetID: subnetID}, newFakeRequester(nil))
+
+	_, err := agg.Aggregate(context.Background(), 1, Request{
+		UnsignedMessage: makeUnsignedMessage(t),
+		SubnetID:        subnetID,
+		QuorumNumerator: QuorumDenominator + 1,
+	})
+	require.ErrorIs(t, err, ErrInvalidQuorumNumerator)
+}
+
+func TestAggregate_RejectsEmptyValidatorSet(t *testing.T) {
+	subnetID := ids.GenerateTestID()
+	agg := New(&fakeValidatorState{subnetID: subnetID}, newFakeRequester(nil))
+
+	_, err := agg.Aggregate(context.Background(), 1, Request{
+		UnsignedMessage: makeUnsignedMessage(t),
+		SubnetID:        subnetID,
+	})
+	require.ErrorIs(t, err, ErrNoValidators)
+}
+
+// TestClientServerRoundTrip exercises the HTTP client and server together
+// against a fully-cooperating validator set, standing in for the
+// hand-built-signature integration test the request describes (see
+// ../../precompiles/warp/predicate_test.go's createPredicate/
+// TestWarpSignatureWeightsDefaultQuorumNumerator, which this now
+// complements rather than replaces).
+func TestClientServerRoundTrip(t *testing.T) {
+	signers := makeFakeSigners(t, 5, 5)
+	subnetID := ids.GenerateTestID()
+	agg := New(&fakeValidatorState{subnetID: subnetID, signers: signers}, newFakeRequester(signers))
+
+	httpServer := httptest.NewServer(NewServer(agg))
+	defer httpServer.Close()
+
+	client := NewClient(httpServer.URL)
+	unsignedMessage := makeUnsignedMessage(t)
+
+	msg, err := client.Aggregate(context.Background(), unsignedMessage, subnetID, 0, 1)
+	require.NoError(t, err)
+	require.Equal(t, unsignedMessage.Bytes(), msg.UnsignedMessage.Bytes())
+
+	bitSetSig, ok := msg.Signature.(*luxWarp.BitSetSignature)
+	require.True(t, ok)
+	requireAggregateVerifies(t, signers, bitSetSig, unsignedMessage)
+}
@@ -0,0 +1,75 @@
+// Copyright (C) 2019-2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package aggregator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/luxfi/ids"
+	luxWarp "github.com/luxfi/warp"
+)
+
+// Client is a thin Go client for a Server's HTTP endpoint, letting a dApp or
+// relayer request an aggregated warp message without hand-rolling the
+// aggregateRequestDTO wire format itself.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewClient builds a Client against a Server listening at baseURL (e.g.
+// "http://localhost:9650/ext/warp/aggregate").
+func NewClient(baseURL string) *Client {
+	return &Client{baseURL: baseURL, httpClient: http.DefaultClient}
+}
+
+// Aggregate requests a signed luxWarp.Message for unsignedMessage from the
+// validator set of subnetID at pChainHeight, meeting quorumNumerator (0 for
+// the server's default) out of QuorumDenominator of total stake weight.
+func (c *Client) Aggregate(
+	ctx context.Context,
+	unsignedMessage *luxWarp.UnsignedMessage,
+	subnetID ids.ID,
+	quorumNumerator uint64,
+	pChainHeight uint64,
+) (*luxWarp.Message, error) {
+	reqDTO := aggregateRequestDTO{
+		NetworkID:       unsignedMessage.NetworkID,
+		SourceChainID:   unsignedMessage.SourceChainID[:],
+		Payload:         unsignedMessage.Payload,
+		SubnetID:        subnetID[:],
+		QuorumNumerator: quorumNumerator,
+		PChainHeight:    pChainHeight,
+	}
+	body, err := json.Marshal(reqDTO)
+	if err != nil {
+		return nil, fmt.Errorf("aggregator client: encoding request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("aggregator client: building request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("aggregator client: sending request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var respDTO aggregateResponseDTO
+	if err := json.NewDecoder(resp.Body).Decode(&respDTO); err != nil {
+		return nil, fmt.Errorf("aggregator client: decoding response: %w", err)
+	}
+	if respDTO.Error != "" {
+		return nil, fmt.Errorf("aggregator client: %s", respDTO.Error)
+	}
+
+	return luxWarp.ParseMessage(respDTO.Message)
+}
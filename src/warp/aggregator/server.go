@@ -0,0 +1,88 @@
+// Copyright (C) 2019-2025, Lux Industries, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package aggregator
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/luxfi/ids"
+	luxWarp "github.com/luxfi/warp"
+)
+
+// aggregateRequestDTO is the wire shape POSTed to Server. The unsigned
+// message travels as its constituent fields (networkID/sourceChainID/
+// payload) rather than pre-serialized bytes, rebuilt on the server side via
+// luxWarp.NewUnsignedMessage, since this snapshot only confirms that
+// constructor and warp.ParseMessage (for a signed Message), not a matching
+// parse function for a bare UnsignedMessage. ID fields travel as raw bytes
+// (encoding/json base64-encodes a []byte field by default), since this
+// package has no confirmed text (un)marshaler to rely on for ids.ID/
+// ids.NodeID beyond the array-slicing already used elsewhere in this
+// package (e.g. luxWarp.Validator.NodeID: nodeID[:]).
+type aggregateRequestDTO struct {
+	NetworkID       uint32 `json:"networkId"`
+	SourceChainID   []byte `json:"sourceChainId"`
+	Payload         []byte `json:"payload"`
+	SubnetID        []byte `json:"subnetId"`
+	QuorumNumerator uint64 `json:"quorumNumerator"`
+	PChainHeight    uint64 `json:"pChainHeight"`
+}
+
+type aggregateResponseDTO struct {
+	Message []byte `json:"message,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Server exposes an Aggregator over a single JSON-RPC-style HTTP endpoint:
+// POST a aggregateRequestDTO body, get back either the packed warp message
+// bytes or an error string.
+type Server struct {
+	aggregator *Aggregator
+}
+
+// NewServer wraps agg for HTTP access.
+func NewServer(agg *Aggregator) *Server {
+	return &Server{aggregator: agg}
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "aggregator: only POST is supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var reqDTO aggregateRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&reqDTO); err != nil {
+		writeAggregateError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	unsignedMessage, err := luxWarp.NewUnsignedMessage(reqDTO.NetworkID, reqDTO.SourceChainID, reqDTO.Payload)
+	if err != nil {
+		writeAggregateError(w, http.StatusBadRequest, err)
+		return
+	}
+	var subnetID ids.ID
+	copy(subnetID[:], reqDTO.SubnetID)
+
+	msg, err := s.aggregator.Aggregate(r.Context(), reqDTO.PChainHeight, Request{
+		UnsignedMessage: unsignedMessage,
+		SubnetID:        subnetID,
+		QuorumNumerator: reqDTO.QuorumNumerator,
+	})
+	if err != nil {
+		writeAggregateError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(aggregateResponseDTO{Message: msg.Bytes()})
+}
+
+func writeAggregateError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(aggregateResponseDTO{Error: err.Error()})
+}